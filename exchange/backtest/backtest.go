@@ -0,0 +1,346 @@
+//Package backtest implement an event driven backtester. historical market
+//data is replayed with a simulated clock, strategy orders reach the
+//simulated venue after a configurable latency and resting limit orders are
+//filled according to their queue position.
+//
+//all symbols are treated as linear contracts settled in a single cash
+//balance, margin is not checked
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	//Config of the backtest
+	Config struct {
+		//Cash initial balance
+		Cash decimal.Decimal
+		//Fees trade fee keyed by symbol string
+		Fees map[string]*exchange.TradeFee
+		//DefaultFee used by symbols which are not in Fees, zero fee if nil
+		DefaultFee *exchange.TradeFee
+		//Latency between order or cancel request and it take effect
+		Latency time.Duration
+	}
+
+	//Strategy receive market data events. the engine implement
+	//exchange.Trader which is used to place orders
+	Strategy interface {
+		//OnEvent is called after event is applied to the simulated market
+		OnEvent(ctx context.Context, e *Engine, event interface{})
+	}
+
+	//FillHandler is optionally implemented by Strategy to get notified for
+	//each fill of its orders
+	FillHandler interface {
+		OnFill(ctx context.Context, e *Engine, trade *exchange.Trade)
+	}
+
+	//Engine simulated venue and clock of a backtest run. Engine is not safe
+	//for concurrent use, strategy must call it from OnEvent or OnFill
+	Engine struct {
+		cfg      *Config
+		ctx      context.Context
+		strategy Strategy
+		now      time.Time
+
+		orderID   int64
+		orders    map[string]*order
+		open      []*order
+		actions   []*action
+		markets   map[string]*market
+		positions map[string]*position
+		trades    []exchange.Trade
+
+		cash     decimal.Decimal
+		realized decimal.Decimal
+		fees     decimal.Decimal
+		funding  decimal.Decimal
+		equity   []EquityPoint
+	}
+
+	order struct {
+		exchange.Order
+		tif      exchange.TimeInForceFlag
+		postOnly bool
+		//active is set when the order reach the venue
+		active bool
+		//queue is the amount ahead of the order at its price level
+		queue decimal.Decimal
+	}
+
+	action struct {
+		at     time.Time
+		order  *order
+		cancel bool
+	}
+)
+
+var (
+	_ exchange.Trader = (*Engine)(nil)
+)
+
+//NewEngine create backtest engine with cfg
+func NewEngine(cfg *Config) *Engine {
+	if cfg.Fees == nil {
+		cfg.Fees = map[string]*exchange.TradeFee{}
+	}
+	return &Engine{
+		cfg:       cfg,
+		orders:    map[string]*order{},
+		markets:   map[string]*market{},
+		positions: map[string]*position{},
+		cash:      cfg.Cash,
+	}
+}
+
+//Run replay events of src to strategy until src return io.EOF and return the
+//backtest report. requests which are still in flight at the end are applied
+//after the last event
+func (e *Engine) Run(ctx context.Context, src Source, strategy Strategy) (*Report, error) {
+	e.ctx = ctx
+	e.strategy = strategy
+
+	var start time.Time
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		event, err := src.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, "read event fail")
+		}
+
+		ts, ok := EventTime(event)
+		if !ok {
+			return nil, exchange.NewBadArg("unsupport event", event)
+		}
+		if ts.Before(e.now) {
+			return nil, exchange.NewBadArg("event out of order", fmt.Sprintf("%s before %s", ts, e.now))
+		}
+		if start.IsZero() {
+			start = ts
+		}
+
+		e.process(ts)
+		e.now = ts
+		e.apply(event)
+		strategy.OnEvent(ctx, e, event)
+		e.process(e.now)
+		e.record()
+	}
+
+	for len(e.actions) != 0 {
+		e.now = e.actions[0].at
+		e.process(e.now)
+		e.record()
+	}
+	return e.report(start), nil
+}
+
+//Now return the simulated time which is the time of current event
+func (e *Engine) Now() time.Time {
+	return e.now
+}
+
+//Cash return current cash balance which include realized pnl, fees and funding
+func (e *Engine) Cash() decimal.Decimal {
+	return e.cash
+}
+
+//Position return signed position amount and average entry price of symbol
+func (e *Engine) Position(symbol exchange.Symbol) (decimal.Decimal, decimal.Decimal) {
+	pos, ok := e.positions[symbol.String()]
+	if !ok {
+		return decimal.Zero, decimal.Zero
+	}
+	return pos.amount, pos.entry
+}
+
+//Equity return cash plus unrealized pnl of positions at mark price
+func (e *Engine) Equity() decimal.Decimal {
+	ret := e.cash
+	for key, pos := range e.positions {
+		ret = ret.Add(pos.unrealized(e.markPrice(key)))
+	}
+	return ret
+}
+
+//CreateOrder send order to the simulated venue, it take effect after
+//Config.Latency. post only order which would take liquidity and fok order
+//which can not be filled are finished with OrderStatusFailed and
+//OrderStatusCancel when the order arrive
+func (e *Engine) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+	if req.Type != exchange.OrderTypeLimit && req.Type != exchange.OrderTypeMarket {
+		return nil, exchange.NewBadArg("unsupport order type", req.Type)
+	}
+
+	o := &order{tif: exchange.TimeInForceGTC}
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			o.postOnly = t.PostOnly
+
+		case *exchange.TimeInForceOption:
+			switch t.Flag {
+			case exchange.TimeInForceGTC, exchange.TimeInForceIOC, exchange.TimeInForceFOK:
+				o.tif = t.Flag
+			default:
+				return nil, exchange.NewBadArg("unsupport time in force", t.Flag)
+			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
+	e.orderID++
+	o.Order = exchange.Order{
+		ID:       exchange.NewIntID(e.orderID),
+		ClientID: req.ClientID,
+		Symbol:   req.Symbol,
+		Amount:   req.Amount,
+		Price:    req.Price,
+		Created:  e.now,
+		Updated:  e.now,
+		Side:     req.Side,
+		Type:     req.Type,
+		Status:   exchange.OrderStatusOpen,
+	}
+	e.orders[o.ID.String()] = o
+	e.open = append(e.open, o)
+	e.schedule(&action{at: e.now.Add(e.cfg.Latency), order: o})
+	return o.snapshot(), nil
+}
+
+//CancelOrder send cancel request which take effect after Config.Latency,
+//the order may be filled before the cancel arrive
+func (e *Engine) CancelOrder(ctx context.Context, ord *exchange.Order) (*exchange.Order, error) {
+	o, err := e.getOrder(ord)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != exchange.OrderStatusOpen {
+		return nil, exchange.NewAPIError(exchange.ErrOrderNotFound, "order_closed",
+			fmt.Sprintf("order %s is finished", o.ID.String()))
+	}
+
+	e.schedule(&action{at: e.now.Add(e.cfg.Latency), order: o, cancel: true})
+	return o.snapshot(), nil
+}
+
+//FetchOrder return the latest state of order
+func (e *Engine) FetchOrder(ctx context.Context, ord *exchange.Order) (*exchange.Order, error) {
+	o, err := e.getOrder(ord)
+	if err != nil {
+		return nil, err
+	}
+	return o.snapshot(), nil
+}
+
+//OpenOrders return open orders of symbol including orders which do not
+//reach the venue yet. orders of all symbols are returned if symbol is nil
+func (e *Engine) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	ret := []*exchange.Order{}
+	for _, o := range e.open {
+		if symbol == nil || o.Symbol.String() == symbol.String() {
+			ret = append(ret, o.snapshot())
+		}
+	}
+	return ret, nil
+}
+
+func (e *Engine) getOrder(ord *exchange.Order) (*order, error) {
+	if ord.ID != nil {
+		if o, ok := e.orders[ord.ID.String()]; ok {
+			return o, nil
+		}
+	}
+	return nil, exchange.NewAPIError(exchange.ErrOrderNotFound, "order_not_found", "unknown order")
+}
+
+//schedule add act to the in flight requests in arrive time order
+func (e *Engine) schedule(act *action) {
+	idx := sort.Search(len(e.actions), func(i int) bool {
+		return e.actions[i].at.After(act.at)
+	})
+	e.actions = append(e.actions, nil)
+	copy(e.actions[idx+1:], e.actions[idx:])
+	e.actions[idx] = act
+}
+
+//process apply in flight requests which arrive before or at ts
+func (e *Engine) process(ts time.Time) {
+	for len(e.actions) != 0 && !e.actions[0].at.After(ts) {
+		act := e.actions[0]
+		e.actions = e.actions[1:]
+		if act.at.After(e.now) {
+			e.now = act.at
+		}
+
+		o := act.order
+		if o.Status != exchange.OrderStatusOpen {
+			continue
+		}
+		if act.cancel {
+			e.finish(o, exchange.OrderStatusCancel)
+			continue
+		}
+		e.arrive(o)
+	}
+}
+
+func (e *Engine) finish(o *order, status exchange.OrderStatus) {
+	o.Status = status
+	o.Updated = e.now
+	o.active = false
+	for i, oo := range e.open {
+		if oo == o {
+			e.open = append(e.open[:i], e.open[i+1:]...)
+			break
+		}
+	}
+}
+
+func (e *Engine) fee(sym exchange.Symbol, maker bool) decimal.Decimal {
+	fee, ok := e.cfg.Fees[sym.String()]
+	if !ok {
+		fee = e.cfg.DefaultFee
+	}
+	if fee == nil {
+		return decimal.Zero
+	}
+	if maker {
+		return fee.Maker
+	}
+	return fee.Taker
+}
+
+func (o *order) snapshot() *exchange.Order {
+	ret := o.Order
+	return &ret
+}
+
+func (o *order) remain() decimal.Decimal {
+	return o.Amount.Sub(o.Filled)
+}
+
+func isBuy(side exchange.OrderSide) bool {
+	return side == exchange.OrderSideBuy || side == exchange.OrderSideCloseShort
+}
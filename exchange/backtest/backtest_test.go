@@ -0,0 +1,203 @@
+package backtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	testSymbol struct {
+		*exchange.BaseSwapSymbol
+	}
+
+	testStrategy struct {
+		onEvent func(e *Engine, event interface{})
+		fills   []*exchange.Trade
+	}
+)
+
+var (
+	btcswap = &testSymbol{exchange.NewBaseSwapSymbol("BTCUSDT")}
+	t0      = time.Unix(1600000000, 0)
+)
+
+func (ts *testSymbol) String() string {
+	return "BTCUSDT-SWAP"
+}
+
+func (ts *testStrategy) OnEvent(ctx context.Context, e *Engine, event interface{}) {
+	if ts.onEvent != nil {
+		ts.onEvent(e, event)
+	}
+}
+
+func (ts *testStrategy) OnFill(ctx context.Context, e *Engine, trade *exchange.Trade) {
+	ts.fills = append(ts.fills, trade)
+}
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func at(ms int) time.Time {
+	return t0.Add(time.Duration(ms) * time.Millisecond)
+}
+
+func book(ms int, bid, bidAmt, ask, askAmt float64) *exchange.OrderBook {
+	return &exchange.OrderBook{
+		Symbol:  btcswap,
+		Bids:    []exchange.OrderElem{{Price: bid, Amount: bidAmt}},
+		Asks:    []exchange.OrderElem{{Price: ask, Amount: askAmt}},
+		Created: at(ms),
+	}
+}
+
+func trade(ms int, side exchange.OrderSide, price string, amount string) *exchange.PublicTrade {
+	return &exchange.PublicTrade{
+		Symbol: btcswap,
+		Side:   side,
+		Price:  d(price),
+		Amount: d(amount),
+		Time:   at(ms),
+	}
+}
+
+func TestQueueAndFunding(t *testing.T) {
+	events := []interface{}{
+		book(0, 100, 5, 101, 5),
+		//the order is in flight
+		trade(50, exchange.OrderSideSell, "100", "3"),
+		//orders ahead cancelled
+		book(200, 100, 4, 101, 5),
+		//4 consumed by queue ahead, 1 filled
+		trade(300, exchange.OrderSideSell, "100", "5"),
+		//trade through the order price
+		trade(400, exchange.OrderSideSell, "99", "3"),
+		&exchange.OrderBook{
+			Symbol:  btcswap,
+			Bids:    []exchange.OrderElem{{Price: 105, Amount: 1}, {Price: 104, Amount: 5}},
+			Asks:    []exchange.OrderElem{{Price: 106, Amount: 5}},
+			Created: at(500),
+		},
+		&exchange.FundingRate{Symbol: btcswap, FundingRate: d("0.001"), Time: at(550)},
+		book(700, 103, 1, 104, 1),
+	}
+
+	e := NewEngine(&Config{
+		Cash:       d("1000"),
+		DefaultFee: &exchange.TradeFee{Taker: d("0.001")},
+		Latency:    time.Millisecond * 100,
+	})
+	var buy *exchange.Order
+	ts := &testStrategy{}
+	ts.onEvent = func(e *Engine, event interface{}) {
+		ctx := context.Background()
+		switch event.(type) {
+		case *exchange.OrderBook:
+			if buy != nil {
+				return
+			}
+			req := exchange.NewDecimalOrderRequest(btcswap, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit, d("100"), d("2"))
+			o, err := e.CreateOrder(ctx, req, exchange.NewPostOnlyOption(true))
+			if err != nil {
+				t.Fatalf("create order fail %s", err.Error())
+			}
+			buy = o
+
+		case *exchange.PublicTrade:
+			o, _ := e.FetchOrder(ctx, buy)
+			if e.Now().Equal(at(300)) && !o.Filled.Equal(d("1")) {
+				t.Errorf("bad queue fill %+v", o)
+			}
+
+		case *exchange.FundingRate:
+			req := exchange.NewDecimalOrderRequest(btcswap, nil, exchange.OrderSideSell, exchange.OrderTypeMarket, decimal.Zero, d("2"))
+			if _, err := e.CreateOrder(ctx, req); err != nil {
+				t.Fatalf("create market order fail %s", err.Error())
+			}
+		}
+	}
+
+	report, err := e.Run(context.Background(), NewSliceSource(events), ts)
+	if err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+
+	o, _ := e.FetchOrder(context.Background(), buy)
+	if o.Status != exchange.OrderStatusDone || !o.AvgPrice.Equal(d("100")) {
+		t.Errorf("bad buy order %+v", o)
+	}
+	if len(ts.fills) != 4 || !ts.fills[0].IsMaker || !ts.fills[1].Time.Equal(at(400)) ||
+		ts.fills[2].IsMaker || !ts.fills[2].Price.Equal(d("105")) || !ts.fills[3].Price.Equal(d("104")) {
+		t.Errorf("bad fills %+v", ts.fills)
+	}
+
+	//1000 + 9 pnl - 0.209 fee - 2 * 105.5 * 0.001 funding
+	if !report.FinalEquity.Equal(d("1008.58")) || !report.RealizedPnL.Equal(d("9")) ||
+		!report.Fees.Equal(d("0.209")) || !report.Funding.Equal(d("-0.211")) || !report.UnrealizedPnL.IsZero() {
+		t.Errorf("bad report %+v", report)
+	}
+	//peak 1011 at 500ms
+	if !report.MaxDrawdown.Equal(d("2.42")) || !report.End.Equal(at(700)) || len(report.Equity) != 8 {
+		t.Errorf("bad drawdown %s %s %d", report.MaxDrawdown, report.End, len(report.Equity))
+	}
+}
+
+func TestKlineAndLatency(t *testing.T) {
+	kline := func(ms int, high, low, close float64) *exchange.Kline {
+		return &exchange.Kline{Symbol: btcswap, High: high, Low: low, Close: close, Time: at(ms)}
+	}
+	klines := NewSliceSource([]interface{}{
+		kline(3000, 100, 96, 98),
+		kline(1000, 100, 97, 99),
+		kline(2000, 101, 95, 100),
+	})
+	funding := NewSliceSource([]interface{}{
+		&exchange.FundingRate{Symbol: btcswap, FundingRate: d("-0.01"), Time: at(2500)},
+	})
+
+	e := NewEngine(&Config{Cash: d("100"), Latency: time.Second})
+	ctx := context.Background()
+	var orders []*exchange.Order
+	ts := &testStrategy{}
+	ts.onEvent = func(e *Engine, event interface{}) {
+		if !e.Now().Equal(at(1000)) {
+			return
+		}
+		req := exchange.NewDecimalOrderRequest(btcswap, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit, d("96"), d("1"))
+		o1, _ := e.CreateOrder(ctx, req)
+		req = exchange.NewDecimalOrderRequest(btcswap, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit, d("95"), d("1"))
+		o2, _ := e.CreateOrder(ctx, req)
+		//cancel arrive with the order
+		if _, err := e.CancelOrder(ctx, o2); err != nil {
+			t.Errorf("cancel order fail %s", err.Error())
+		}
+		req = exchange.NewDecimalOrderRequest(btcswap, nil, exchange.OrderSideSell, exchange.OrderTypeLimit, d("99"), d("1"))
+		o3, _ := e.CreateOrder(ctx, req, exchange.NewPostOnlyOption(true))
+		orders = append(orders, o1, o2, o3)
+	}
+
+	report, err := e.Run(ctx, Merge(klines, funding), ts)
+	if err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+
+	statuses := []exchange.OrderStatus{exchange.OrderStatusDone, exchange.OrderStatusCancel, exchange.OrderStatusFailed}
+	for i, o := range orders {
+		o, _ = e.FetchOrder(ctx, o)
+		if o.Status != statuses[i] {
+			t.Errorf("bad order %d status %+v", i, o)
+		}
+	}
+	//long 1 at 96 receive 1 * 100 * 0.01 funding, mark 98
+	if amt, entry := e.Position(btcswap); !amt.Equal(d("1")) || !entry.Equal(d("96")) {
+		t.Errorf("bad position %s %s", amt, entry)
+	}
+	if !report.Funding.Equal(d("1")) || !report.FinalEquity.Equal(d("103")) || !report.Start.Equal(at(1000)) {
+		t.Errorf("bad report %+v", report)
+	}
+}
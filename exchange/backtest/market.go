@@ -0,0 +1,387 @@
+package backtest
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	level struct {
+		price  decimal.Decimal
+		amount decimal.Decimal
+	}
+
+	//market is the latest public data of a symbol
+	market struct {
+		bids    []level
+		asks    []level
+		hasBook bool
+		//last price of public trade or kline close
+		last decimal.Decimal
+	}
+
+	fill struct {
+		price  decimal.Decimal
+		amount decimal.Decimal
+	}
+
+	//position is one way position of a symbol, positive amount means long
+	position struct {
+		cv     decimal.Decimal
+		amount decimal.Decimal
+		entry  decimal.Decimal
+	}
+)
+
+func (e *Engine) market(sym exchange.Symbol) *market {
+	key := sym.String()
+	m, ok := e.markets[key]
+	if !ok {
+		m = &market{}
+		e.markets[key] = m
+	}
+	return m
+}
+
+//apply update market with event and match resting orders
+func (e *Engine) apply(event interface{}) {
+	switch t := event.(type) {
+	case *exchange.OrderBook:
+		m := e.market(t.Symbol)
+		m.setBook(t)
+		for _, o := range e.resting(t.Symbol) {
+			e.matchBook(o, m)
+		}
+
+	case *exchange.PublicTrade:
+		e.market(t.Symbol).last = t.Price
+		e.matchTrade(t)
+
+	case *exchange.Kline:
+		e.market(t.Symbol).last = decimal.NewFromFloat(t.Close)
+		e.matchKline(t)
+
+	case *exchange.FundingRate:
+		e.settleFunding(t)
+	}
+}
+
+//arrive handle order which reach the venue. marketable amount is filled as
+//taker and the rest of gtc limit order join the end of its price level queue
+func (e *Engine) arrive(o *order) {
+	o.active = true
+	m := e.market(o.Symbol)
+	buy := isBuy(o.Side)
+	mkt := o.Type == exchange.OrderTypeMarket
+
+	var fills []fill
+	if m.hasBook {
+		fills = m.take(buy, mkt, o.Price, o.remain(), true)
+	} else if m.last.IsPositive() && (mkt || buy && o.Price.GreaterThanOrEqual(m.last) || !buy && o.Price.LessThanOrEqual(m.last)) {
+		//no book, assume the last price has enough liquidity
+		fills = []fill{{price: m.last, amount: o.remain()}}
+	}
+
+	if o.postOnly && len(fills) != 0 {
+		e.finish(o, exchange.OrderStatusFailed)
+		return
+	}
+	if mkt && len(fills) == 0 {
+		e.finish(o, exchange.OrderStatusFailed)
+		return
+	}
+	if o.tif == exchange.TimeInForceFOK && sumAmount(fills).LessThan(o.remain()) {
+		e.finish(o, exchange.OrderStatusCancel)
+		return
+	}
+
+	if m.hasBook {
+		fills = m.take(buy, mkt, o.Price, o.remain(), false)
+	}
+	for _, f := range fills {
+		e.fill(o, f.price, f.amount, false)
+	}
+
+	if o.Status != exchange.OrderStatusOpen {
+		return
+	}
+	if mkt || o.tif != exchange.TimeInForceGTC {
+		e.finish(o, exchange.OrderStatusCancel)
+		return
+	}
+	o.queue = m.depth(buy, o.Price)
+}
+
+//resting return active open orders of sym in time priority
+func (e *Engine) resting(sym exchange.Symbol) []*order {
+	var ret []*order
+	for _, o := range e.open {
+		if o.active && o.Symbol.String() == sym.String() {
+			ret = append(ret, o)
+		}
+	}
+	return ret
+}
+
+//matchBook fill o if the book cross its price, otherwise the queue ahead
+//of o is shrunk to the level amount as orders ahead are cancelled or filled
+func (e *Engine) matchBook(o *order, m *market) {
+	buy := isBuy(o.Side)
+	for _, f := range m.take(buy, false, o.Price, o.remain(), false) {
+		e.fill(o, o.Price, f.amount, true)
+	}
+	if o.Status == exchange.OrderStatusOpen {
+		o.queue = decimal.Min(o.queue, m.depth(buy, o.Price))
+	}
+}
+
+//matchTrade fill resting orders hit by public trade. orders at better price
+//than the trade are filled first, orders at the trade price are filled after
+//the queue ahead of them is consumed
+func (e *Engine) matchTrade(t *exchange.PublicTrade) {
+	takerBuy := t.Side == exchange.OrderSideBuy
+	var orders []*order
+	for _, o := range e.resting(t.Symbol) {
+		if isBuy(o.Side) == takerBuy {
+			continue
+		}
+		if takerBuy && o.Price.LessThanOrEqual(t.Price) || !takerBuy && o.Price.GreaterThanOrEqual(t.Price) {
+			orders = append(orders, o)
+		}
+	}
+	sort.SliceStable(orders, func(i, j int) bool {
+		if takerBuy {
+			return orders[i].Price.LessThan(orders[j].Price)
+		}
+		return orders[i].Price.GreaterThan(orders[j].Price)
+	})
+
+	left := t.Amount
+	for _, o := range orders {
+		if !left.IsPositive() {
+			break
+		}
+		if o.Price.Equal(t.Price) {
+			ahead := decimal.Min(o.queue, left)
+			o.queue = o.queue.Sub(ahead)
+			left = left.Sub(ahead)
+		}
+		if amt := decimal.Min(o.remain(), left); amt.IsPositive() {
+			left = left.Sub(amt)
+			e.fill(o, o.Price, amt, true)
+		}
+	}
+}
+
+//matchKline fill resting orders whose price is strictly crossed by the kline
+//range. queue position is unknown so touching the price is not a fill
+func (e *Engine) matchKline(k *exchange.Kline) {
+	low := decimal.NewFromFloat(k.Low)
+	high := decimal.NewFromFloat(k.High)
+	for _, o := range e.resting(k.Symbol) {
+		if isBuy(o.Side) && o.Price.GreaterThan(low) || !isBuy(o.Side) && o.Price.LessThan(high) {
+			e.fill(o, o.Price, o.remain(), true)
+		}
+	}
+}
+
+//settleFunding charge funding of position at mark price. long position pay
+//short position if rate is positive
+func (e *Engine) settleFunding(fr *exchange.FundingRate) {
+	key := fr.Symbol.String()
+	pos, ok := e.positions[key]
+	if !ok || pos.amount.IsZero() {
+		return
+	}
+	payment := pos.amount.Mul(e.markPrice(key)).Mul(pos.cv).Mul(fr.FundingRate).Neg()
+	e.cash = e.cash.Add(payment)
+	e.funding = e.funding.Add(payment)
+}
+
+//fill apply a fill of order o to position, cash and trades
+func (e *Engine) fill(o *order, price decimal.Decimal, amount decimal.Decimal, maker bool) {
+	sym := o.Symbol
+	key := sym.String()
+	pos, ok := e.positions[key]
+	if !ok {
+		pos = &position{cv: contractVal(sym)}
+		e.positions[key] = pos
+	}
+
+	delta := amount
+	if !isBuy(o.Side) {
+		delta = amount.Neg()
+	}
+	fee := price.Mul(amount).Mul(pos.cv).Mul(e.fee(sym, maker))
+	pnl := pos.update(delta, price)
+	e.cash = e.cash.Add(pnl).Sub(fee)
+	e.realized = e.realized.Add(pnl)
+	e.fees = e.fees.Add(fee)
+
+	total := o.Filled.Add(amount)
+	o.AvgPrice = o.AvgPrice.Mul(o.Filled).Add(price.Mul(amount)).Div(total)
+	o.Filled = total
+	o.Fee = o.Fee.Sub(fee)
+	o.Updated = e.now
+
+	e.trades = append(e.trades, exchange.Trade{
+		ID:      strconv.Itoa(len(e.trades) + 1),
+		OrderID: o.ID.String(),
+		Symbol:  sym,
+		Price:   price,
+		Amount:  amount,
+		Fee:     fee.Neg(),
+		Time:    e.now,
+		Side:    o.Side,
+		IsMaker: maker,
+	})
+	if o.Filled.Equal(o.Amount) {
+		e.finish(o, exchange.OrderStatusDone)
+	}
+
+	if fh, ok := e.strategy.(FillHandler); ok {
+		trade := e.trades[len(e.trades)-1]
+		fh.OnFill(e.ctx, e, &trade)
+	}
+}
+
+//markPrice return book mid price, the last price is used if there is no book
+func (e *Engine) markPrice(key string) decimal.Decimal {
+	m, ok := e.markets[key]
+	if !ok {
+		return decimal.Zero
+	}
+	if len(m.bids) != 0 && len(m.asks) != 0 {
+		return m.bids[0].price.Add(m.asks[0].price).Div(decimal.NewFromInt(2))
+	}
+	return m.last
+}
+
+func (m *market) setBook(ob *exchange.OrderBook) {
+	convert := func(elems []exchange.OrderElem) []level {
+		ret := make([]level, 0, len(elems))
+		for _, elem := range elems {
+			if elem.Price <= 0 || elem.Amount <= 0 {
+				continue
+			}
+			ret = append(ret, level{
+				price:  decimal.NewFromFloat(elem.Price),
+				amount: decimal.NewFromFloat(elem.Amount),
+			})
+		}
+		return ret
+	}
+
+	m.hasBook = true
+	m.bids = convert(ob.Bids)
+	m.asks = convert(ob.Asks)
+	sort.Slice(m.bids, func(i, j int) bool {
+		return m.bids[i].price.GreaterThan(m.bids[j].price)
+	})
+	sort.Slice(m.asks, func(i, j int) bool {
+		return m.asks[i].price.LessThan(m.asks[j].price)
+	})
+}
+
+//take walk the opposite side of book from the best price and return fills
+//of amount whose price is not worse than limit. the book is not changed if
+//dry is true
+func (m *market) take(buy bool, mkt bool, limit decimal.Decimal, amount decimal.Decimal, dry bool) []fill {
+	levels := m.asks
+	if !buy {
+		levels = m.bids
+	}
+
+	var ret []fill
+	for i := range levels {
+		if !amount.IsPositive() {
+			break
+		}
+		lv := &levels[i]
+		if !lv.amount.IsPositive() {
+			continue
+		}
+		if !mkt && (buy && lv.price.GreaterThan(limit) || !buy && lv.price.LessThan(limit)) {
+			break
+		}
+
+		amt := decimal.Min(amount, lv.amount)
+		ret = append(ret, fill{price: lv.price, amount: amt})
+		amount = amount.Sub(amt)
+		if !dry {
+			lv.amount = lv.amount.Sub(amt)
+		}
+	}
+	return ret
+}
+
+//depth return amount at price of bids if buy otherwise asks
+func (m *market) depth(buy bool, price decimal.Decimal) decimal.Decimal {
+	levels := m.bids
+	if !buy {
+		levels = m.asks
+	}
+	for _, lv := range levels {
+		if lv.price.Equal(price) {
+			return lv.amount
+		}
+	}
+	return decimal.Zero
+}
+
+//update apply signed delta filled at price and return the realized pnl
+func (p *position) update(delta decimal.Decimal, price decimal.Decimal) decimal.Decimal {
+	if p.amount.IsZero() || p.amount.Sign() == delta.Sign() {
+		total := p.amount.Add(delta)
+		p.entry = p.entry.Mul(p.amount.Abs()).Add(price.Mul(delta.Abs())).Div(total.Abs())
+		p.amount = total
+		return decimal.Zero
+	}
+
+	closed := decimal.Min(delta.Abs(), p.amount.Abs())
+	pnl := price.Sub(p.entry).Mul(closed).Mul(p.cv)
+	if p.amount.IsNegative() {
+		pnl = pnl.Neg()
+	}
+
+	reverse := delta.Abs().GreaterThan(closed)
+	p.amount = p.amount.Add(delta)
+	if p.amount.IsZero() {
+		p.entry = decimal.Zero
+	} else if reverse {
+		p.entry = price
+	}
+	return pnl
+}
+
+func (p *position) unrealized(mark decimal.Decimal) decimal.Decimal {
+	if mark.IsZero() || p.amount.IsZero() {
+		return decimal.Zero
+	}
+	return mark.Sub(p.entry).Mul(p.amount).Mul(p.cv)
+}
+
+func sumAmount(fills []fill) decimal.Decimal {
+	ret := decimal.Zero
+	for _, f := range fills {
+		ret = ret.Add(f.amount)
+	}
+	return ret
+}
+
+func contractVal(sym exchange.Symbol) decimal.Decimal {
+	var cv decimal.Decimal
+	switch t := sym.(type) {
+	case exchange.SwapSymbol:
+		cv = t.ContractVal()
+	case exchange.FuturesSymbol:
+		cv = t.ContractVal()
+	}
+	if cv.Sign() <= 0 {
+		return decimal.NewFromInt(1)
+	}
+	return cv
+}
@@ -0,0 +1,80 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	//EquityPoint equity at Time
+	EquityPoint struct {
+		Time   time.Time
+		Equity decimal.Decimal
+	}
+
+	//Report is the result of a backtest run
+	Report struct {
+		Start         time.Time
+		End           time.Time
+		InitialEquity decimal.Decimal
+		FinalEquity   decimal.Decimal
+		//PnL FinalEquity - InitialEquity
+		PnL           decimal.Decimal
+		RealizedPnL   decimal.Decimal
+		UnrealizedPnL decimal.Decimal
+		//Fees total paid trade fees
+		Fees decimal.Decimal
+		//Funding total received funding, negative means paid
+		Funding decimal.Decimal
+		//MaxDrawdown largest equity drop from a previous peak
+		MaxDrawdown decimal.Decimal
+		//MaxDrawdownRatio MaxDrawdown divided by the peak
+		MaxDrawdownRatio decimal.Decimal
+		Trades           []exchange.Trade
+		//Equity curve recorded after every event
+		Equity []EquityPoint
+	}
+)
+
+//record append current equity to equity curve
+func (e *Engine) record() {
+	point := EquityPoint{Time: e.now, Equity: e.Equity()}
+	if l := len(e.equity); l != 0 && e.equity[l-1].Time.Equal(point.Time) {
+		e.equity[l-1] = point
+		return
+	}
+	e.equity = append(e.equity, point)
+}
+
+func (e *Engine) report(start time.Time) *Report {
+	final := e.Equity()
+	ret := &Report{
+		Start:         start,
+		End:           e.now,
+		InitialEquity: e.cfg.Cash,
+		FinalEquity:   final,
+		PnL:           final.Sub(e.cfg.Cash),
+		RealizedPnL:   e.realized,
+		UnrealizedPnL: final.Sub(e.cash),
+		Fees:          e.fees,
+		Funding:       e.funding,
+		Trades:        e.trades,
+		Equity:        e.equity,
+	}
+
+	peak := e.cfg.Cash
+	for _, p := range e.equity {
+		if p.Equity.GreaterThan(peak) {
+			peak = p.Equity
+		}
+		if dd := peak.Sub(p.Equity); dd.GreaterThan(ret.MaxDrawdown) {
+			ret.MaxDrawdown = dd
+			if peak.IsPositive() {
+				ret.MaxDrawdownRatio = dd.Div(peak)
+			}
+		}
+	}
+	return ret
+}
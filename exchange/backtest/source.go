@@ -0,0 +1,115 @@
+package backtest
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
+type (
+	//Source provide market data events in time order. Next return io.EOF if
+	//there is no more event. supported events are *exchange.OrderBook,
+	//*exchange.PublicTrade, *exchange.Kline and *exchange.FundingRate
+	Source interface {
+		Next() (interface{}, error)
+	}
+
+	sliceSource struct {
+		events []interface{}
+		idx    int
+	}
+
+	mergeSource struct {
+		sources []Source
+		heads   []interface{}
+		times   []time.Time
+		err     error
+	}
+)
+
+//EventTime return the time when event happen. OrderBook.Created,
+//PublicTrade.Time, Kline.Time and FundingRate.Time is used. kline should be
+//stamped with its close time to avoid look ahead
+func EventTime(event interface{}) (time.Time, bool) {
+	switch t := event.(type) {
+	case *exchange.OrderBook:
+		return t.Created, true
+	case *exchange.PublicTrade:
+		return t.Time, true
+	case *exchange.Kline:
+		return t.Time, true
+	case *exchange.FundingRate:
+		return t.Time, true
+	}
+	return time.Time{}, false
+}
+
+//NewSliceSource return source of events sorted by EventTime. events with same
+//time keep the given order
+func NewSliceSource(events []interface{}) Source {
+	ret := make([]interface{}, len(events))
+	copy(ret, events)
+	sort.SliceStable(ret, func(i, j int) bool {
+		ti, _ := EventTime(ret[i])
+		tj, _ := EventTime(ret[j])
+		return ti.Before(tj)
+	})
+	return &sliceSource{events: ret}
+}
+
+//Merge return source which merge events of sources in time order. events
+//with same time are ordered by source index
+func Merge(sources ...Source) Source {
+	return &mergeSource{
+		sources: sources,
+		heads:   make([]interface{}, len(sources)),
+		times:   make([]time.Time, len(sources)),
+	}
+}
+
+func (ss *sliceSource) Next() (interface{}, error) {
+	if ss.idx == len(ss.events) {
+		return nil, io.EOF
+	}
+	ret := ss.events[ss.idx]
+	ss.idx++
+	return ret, nil
+}
+
+func (ms *mergeSource) Next() (interface{}, error) {
+	if ms.err != nil {
+		return nil, ms.err
+	}
+
+	idx := -1
+	for i, src := range ms.sources {
+		if src == nil {
+			continue
+		}
+		if ms.heads[i] == nil {
+			event, err := src.Next()
+			if err == io.EOF {
+				ms.sources[i] = nil
+				continue
+			}
+			if err != nil {
+				ms.err = err
+				return nil, err
+			}
+			ms.heads[i] = event
+			ms.times[i], _ = EventTime(event)
+		}
+		if idx == -1 || ms.times[i].Before(ms.times[idx]) {
+			idx = i
+		}
+	}
+
+	if idx == -1 {
+		return nil, io.EOF
+	}
+	ret := ms.heads[idx]
+	ms.heads[idx] = nil
+	return ret, nil
+}
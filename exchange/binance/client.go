@@ -13,16 +13,25 @@ import (
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/clock"
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
 	"github.com/NadiaSama/ccexgo/misc/request"
+	"github.com/NadiaSama/ccexgo/misc/tconv"
 	"github.com/pkg/errors"
 )
 
 type (
 	//Binance Rest client instance
 	RestClient struct {
-		key     string
-		secret  string
-		apiHost string
+		key          string
+		secret       string
+		apiHost      string
+		scheme       string
+		client       *http.Client
+		limiter      *ratelimit.Limiter
+		interceptors []request.Interceptor
+		retry        *request.RetryPolicy
+		clock        *clock.Clock
 	}
 
 	//RestReq basic binance rest request instance add recvWindow param support
@@ -35,15 +44,75 @@ type (
 	}
 )
 
-func NewRestClient(key, secret, host string) *RestClient {
+//NewRestClient create rest client which send requests to host. opts can
+//specific the http client and override the host
+func NewRestClient(key, secret, host string, opts ...request.Option) *RestClient {
+	cfg := request.NewConfig(opts...)
+	scheme, host := cfg.SchemeHost("https", host)
 	ret := &RestClient{
-		key:     key,
-		secret:  secret,
-		apiHost: host,
+		key:          key,
+		secret:       secret,
+		apiHost:      host,
+		scheme:       scheme,
+		client:       cfg.Client,
+		interceptors: cfg.Interceptors,
 	}
 	return ret
 }
 
+//Use append interceptors which run around every request of the client.
+//Use is not concurrent safe and should be called before sending requests
+func (rc *RestClient) Use(interceptors ...request.Interceptor) {
+	rc.interceptors = append(rc.interceptors, interceptors...)
+}
+
+//Host return the api host of the client which may be overridden by BaseURL
+func (rc *RestClient) Host() string {
+	return rc.apiHost
+}
+
+//SetRateLimiter replace the rate limiter of the client, nil disable rate limit
+func (rc *RestClient) SetRateLimiter(l *ratelimit.Limiter) {
+	rc.limiter = l
+}
+
+//RateLimiter return the rate limiter of the client
+func (rc *RestClient) RateLimiter() *ratelimit.Limiter {
+	return rc.limiter
+}
+
+//SetRetryPolicy replace the retry policy of the client, nil disable retry
+func (rc *RestClient) SetRetryPolicy(p *request.RetryPolicy) {
+	rc.retry = p
+}
+
+//RetryPolicy return the retry policy of the client
+func (rc *RestClient) RetryPolicy() *request.RetryPolicy {
+	return rc.retry
+}
+
+//SetClock set the clock which provide timestamp of signed requests, nil use
+//local time
+func (rc *RestClient) SetClock(c *clock.Clock) {
+	rc.clock = c
+}
+
+//Clock return the clock of the client
+func (rc *RestClient) Clock() *clock.Clock {
+	return rc.clock
+}
+
+//GetServerTime return binance server time via endPoint such as /api/v3/time
+func (rc *RestClient) GetServerTime(ctx context.Context, endPoint string) (time.Time, error) {
+	var resp struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	if err := rc.Request(ctx, http.MethodGet, endPoint, nil, nil, false, &resp); err != nil {
+		return time.Time{}, errors.WithMessagef(err, "request %s fail", endPoint)
+	}
+	return tconv.Milli2Time(resp.ServerTime), nil
+}
+
 func NewRestReq() *RestReq {
 	return &RestReq{
 		exchange.NewRestReq(),
@@ -79,41 +148,67 @@ func (rc *RestClient) Request(ctx context.Context, method, endPoint string, para
 }
 
 func (rc *RestClient) request(ctx context.Context, method, endPoint string, param url.Values, data io.Reader, signed bool, dst interface{}) error {
+	body, err := request.ReplayBody(data)
+	if err != nil {
+		return errors.WithMessage(err, "read data fail")
+	}
+	ctx = request.WithEndpoint(ctx, endPoint)
+	return rc.retry.Retry(ctx, method, endPoint, func() error {
+		return rc.doRequest(ctx, method, endPoint, param, body(), signed, dst)
+	})
+}
+
+func (rc *RestClient) doRequest(ctx context.Context, method, endPoint string, param url.Values, data io.Reader, signed bool, dst interface{}) error {
+	if err := rc.limiter.Wait(ctx, method, endPoint); err != nil {
+		return err
+	}
 	req, err := rc.buildRequest(ctx, method, endPoint, param, data, signed)
 	if err != nil {
 		return err
 	}
-	rerr := request.DoReqWithCtx(req, func(resp *http.Response, ierr error) error {
+	rerr := request.DoWithClient(rc.client, req, func(resp *http.Response, ierr error) error {
 		if ierr != nil {
 			return ierr
 		}
+		rc.limiter.Observe(resp.Header)
 		content, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			return err
 		}
 		defer resp.Body.Close()
 
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			ae := APIError{Status: resp.StatusCode}
+			if err := json.Unmarshal(content, &ae); err != nil || ae.Code == 0 {
+				ae.Message = string(content)
+			}
+			return request.NewResponseError(&ae, resp)
+		}
+
 		if err := json.Unmarshal(content, dst); err != nil {
 			return err
 		}
 
 		if api, ok := dst.(APIIF); ok {
 			if code := api.ECode(); code != 0 {
-				return errors.Errorf("api error code=%d message=%s", api.ECode(), api.EMessage())
+				return &APIError{Code: code, Message: api.EMessage(), Status: resp.StatusCode}
 			}
 		}
 
 		return nil
-	})
+	}, rc.interceptors...)
 	return rerr
 }
 
 func (rc *RestClient) buildRequest(ctx context.Context, method, endPoint string, values url.Values, data io.Reader, signed bool) (*http.Request, error) {
 	if signed {
-		if values == nil {
-			values = url.Values{}
+		//copy values, the request may be signed again when retry
+		signValues := url.Values{}
+		for k, v := range values {
+			signValues[k] = append([]string{}, v...)
 		}
-		values.Add("timestamp", fmt.Sprintf("%d", timeStamp()))
+		values = signValues
+		values.Add("timestamp", fmt.Sprintf("%d", tconv.Time2Milli(rc.clock.Now())))
 	}
 	query := values.Encode()
 	if data != nil {
@@ -130,7 +225,7 @@ func (rc *RestClient) buildRequest(ctx context.Context, method, endPoint string,
 		query = fmt.Sprintf("%s&signature=%s", query, sig)
 	}
 
-	u := url.URL{Scheme: "https", Path: endPoint, RawQuery: query, Host: rc.apiHost}
+	u := url.URL{Scheme: rc.scheme, Path: endPoint, RawQuery: query, Host: rc.apiHost}
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
 	if err != nil {
 		return nil, errors.WithMessage(err, "get request fail")
@@ -140,8 +235,3 @@ func (rc *RestClient) buildRequest(ctx context.Context, method, endPoint string,
 	}
 	return req, nil
 }
-
-func timeStamp() int64 {
-	now := time.Now()
-	return now.UnixNano() / 1e6
-}
@@ -1,6 +1,21 @@
 package binance
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/clock"
+	"github.com/NadiaSama/ccexgo/misc/request"
+	"github.com/NadiaSama/ccexgo/misc/tconv"
+	"github.com/jarcoal/httpmock"
+)
 
 func TestHash(t *testing.T) {
 	cl := &RestClient{
@@ -12,3 +27,113 @@ func TestHash(t *testing.T) {
 		t.Errorf("unequal signature %s", sig)
 	}
 }
+
+func TestRequestRetry(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.Deactivate()
+
+	var queries []url.Values
+	httpmock.RegisterResponder(http.MethodGet, "https://api.binance.com/api/v3/account",
+		func(req *http.Request) (*http.Response, error) {
+			queries = append(queries, req.URL.Query())
+			if len(queries) == 1 {
+				return httpmock.NewStringResponse(503, `{"code": -1001, "msg": "Internal error"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+
+	client := NewRestClient("key", "secret", "api.binance.com")
+	client.SetRetryPolicy(&request.RetryPolicy{MaxAttempts: 2})
+	values := url.Values{}
+	values.Add("recvWindow", "5000")
+	var dst struct{}
+	if err := client.Request(context.Background(), http.MethodGet, "/api/v3/account", values, nil, true, &dst); err != nil {
+		t.Fatalf("request fail %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("expect 2 attempts got %d", len(queries))
+	}
+	for _, q := range queries {
+		if len(q["timestamp"]) != 1 || len(q["signature"]) != 1 {
+			t.Errorf("bad signed query %v", q)
+		}
+	}
+	if len(values) != 1 {
+		t.Errorf("param is modified %v", values)
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"code": 0}`))
+	}))
+	defer srv.Close()
+
+	hc := &http.Client{Timeout: time.Second}
+	client := NewRestClient("", "", "api.binance.com", request.WithClient(hc), request.WithBaseURL(srv.URL))
+	var dst struct{}
+	if err := client.Request(context.Background(), http.MethodGet, "/api/v3/time", nil, nil, false, &dst); err != nil {
+		t.Fatalf("request fail %v", err)
+	}
+	if path != "/api/v3/time" {
+		t.Errorf("bad path %s", path)
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	var signed string
+	client := NewRestClient("key", "secret", "api.binance.com", request.WithInterceptors(
+		request.Fault(func(req *http.Request) (*http.Response, error) {
+			signed = req.URL.Query().Get("signature")
+			return httpmock.NewStringResponse(http.StatusTooManyRequests, `{"code": -1003, "msg": "Too many requests"}`), nil
+		})))
+
+	var dst struct{}
+	err := client.Request(context.Background(), http.MethodGet, "/api/v3/account", nil, nil, true, &dst)
+	var ae *APIError
+	if !errors.As(err, &ae) || ae.Code != -1003 {
+		t.Errorf("expect injected error got %v", err)
+	}
+	if signed == "" {
+		t.Errorf("interceptor should see signed request")
+	}
+}
+
+func TestClock(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.Deactivate()
+
+	serverTime := time.Now().Add(time.Hour)
+	httpmock.RegisterResponder(http.MethodGet, "https://api.binance.com/api/v3/time",
+		httpmock.NewStringResponder(200, fmt.Sprintf(`{"serverTime": %d}`, tconv.Time2Milli(serverTime))))
+	var query url.Values
+	httpmock.RegisterResponder(http.MethodGet, "https://api.binance.com/api/v3/account",
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+
+	client := NewRestClient("key", "secret", "api.binance.com")
+	c := clock.New(func(ctx context.Context) (time.Time, error) {
+		return client.GetServerTime(ctx, "/api/v3/time")
+	})
+	if err := c.Sync(context.Background()); err != nil {
+		t.Fatalf("sync fail %v", err)
+	}
+	client.SetClock(c)
+
+	var dst struct{}
+	if err := client.Request(context.Background(), http.MethodGet, "/api/v3/account", url.Values{}, nil, true, &dst); err != nil {
+		t.Fatalf("request fail %v", err)
+	}
+	ts, err := strconv.ParseInt(query.Get("timestamp"), 10, 64)
+	if err != nil {
+		t.Fatalf("bad timestamp %v", query)
+	}
+	if d := tconv.Milli2Time(ts).Sub(serverTime); d < -time.Second || d > time.Second {
+		t.Errorf("timestamp not synced with server diff=%s", d)
+	}
+}
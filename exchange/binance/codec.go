@@ -64,7 +64,16 @@ func (cc *CodeC) DecodeByCB(raw []byte, cb func(g *gjson.Result) (rpc.Response,
 	// by now only handle subscribe response which result is nil
 	if g.Get("id").Exists() && g.Get("result").Exists() {
 		return &rpc.Result{
-			ID: g.Get("id").String(),
+			ID:     g.Get("id").String(),
+			Result: json.RawMessage(g.Get("result").Raw),
+		}, nil
+	}
+
+	// rejected request such as {"error":{"code":2,"msg":"Invalid request"},"id":1}
+	if e := g.Get("error"); g.Get("id").Exists() && e.Exists() {
+		return &rpc.Result{
+			ID:    g.Get("id").String(),
+			Error: errors.Errorf("error code: %d msg: %s", e.Get("code").Int(), e.Get("msg").String()),
 		}, nil
 	}
 
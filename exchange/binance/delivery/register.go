@@ -0,0 +1,19 @@
+package delivery
+
+import (
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
+const (
+	ExchangeName = "binance.delivery"
+)
+
+func init() {
+	exchange.Register(&exchange.Registration{
+		Name: ExchangeName,
+		NewWSClient: func(cfg *exchange.Config, data chan interface{}) (exchange.WSConn, error) {
+			return NewWSClient(data), nil
+		},
+		Capabilities: exchange.CapabilityPublicStream,
+	})
+}
@@ -1,6 +1,13 @@
 package binance
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
 
 type (
 	APIIF interface {
@@ -12,6 +19,26 @@ type (
 	APIError struct {
 		Code    int    `json:"code"`
 		Message string `json:"msg"`
+		//Status http status code of the response, 0 if unknown
+		Status int `json:"-"`
+	}
+)
+
+var (
+	codeKinds = map[int]error{
+		-1001: exchange.ErrOverloaded,
+		-1003: exchange.ErrRateLimited,
+		-1008: exchange.ErrOverloaded,
+		-1015: exchange.ErrRateLimited,
+		-1022: exchange.ErrAuthFailed,
+		-1121: exchange.ErrInvalidSymbol,
+		-2011: exchange.ErrOrderNotFound,
+		-2013: exchange.ErrOrderNotFound,
+		-2014: exchange.ErrAuthFailed,
+		-2015: exchange.ErrAuthFailed,
+		-2018: exchange.ErrInsufficientBalance,
+		-2019: exchange.ErrInsufficientBalance,
+		-5022: exchange.ErrPostOnlyRejected,
 	}
 )
 
@@ -27,7 +54,42 @@ func (ae *APIError) Error() string {
 	return fmt.Sprintf("api error code:%d message:'%s'", ae.Code, ae.Message)
 }
 
-func (ae *APIError) Is(target interface{}) bool {
+//Kind return the normalized exchange error kind, nil if unclassified
+func (ae *APIError) Kind() error {
+	if kind, ok := codeKinds[ae.Code]; ok {
+		return kind
+	}
+
+	//-2010 NEW_ORDER_REJECTED carry the reason in message
+	if ae.Code == -2010 {
+		msg := strings.ToLower(ae.Message)
+		if strings.Contains(msg, "insufficient balance") {
+			return exchange.ErrInsufficientBalance
+		}
+		if strings.Contains(msg, "immediately match and take") {
+			return exchange.ErrPostOnlyRejected
+		}
+	}
+
+	switch ae.Status {
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		return exchange.ErrRateLimited
+	case http.StatusUnauthorized:
+		return exchange.ErrAuthFailed
+	case http.StatusServiceUnavailable:
+		return exchange.ErrOverloaded
+	}
+	return nil
+}
+
+//Is match any *APIError
+func (ae *APIError) Is(target error) bool {
 	_, ok := target.(*APIError)
 	return ok
 }
+
+//Unwrap return the error as *exchange.ErrAPI so that errors.Is match the
+//normalized error kind
+func (ae *APIError) Unwrap() error {
+	return exchange.NewAPIError(ae.Kind(), strconv.Itoa(ae.Code), ae.Message)
+}
@@ -0,0 +1,43 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAPIError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.Deactivate()
+
+	httpmock.RegisterResponder(http.MethodPost, "https://api.binance.com/api/v3/order",
+		httpmock.NewStringResponder(400, `{"code": -2010, "msg": "Account has insufficient balance for requested action."}`))
+
+	client := NewRestClient("", "", "api.binance.com")
+	var dst struct{}
+	err := client.Request(context.Background(), http.MethodPost, "/api/v3/order", nil, nil, false, &dst)
+	if !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("expect insufficient balance got %v", err)
+	}
+
+	var ae *APIError
+	if !errors.As(err, &ae) || ae.Code != -2010 || ae.Status != 400 {
+		t.Errorf("expect APIError got %v", err)
+	}
+
+	var apiErr *exchange.ErrAPI
+	if !errors.As(err, &apiErr) || apiErr.Kind != exchange.ErrInsufficientBalance || apiErr.Code != "-2010" {
+		t.Errorf("expect exchange.ErrAPI got %v", err)
+	}
+
+	if kind := (&APIError{Code: -1003}).Kind(); kind != exchange.ErrRateLimited {
+		t.Errorf("bad kind %v", kind)
+	}
+	if kind := (&APIError{Status: http.StatusTooManyRequests}).Kind(); kind != exchange.ErrRateLimited {
+		t.Errorf("bad kind %v", kind)
+	}
+}
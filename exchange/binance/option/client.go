@@ -3,7 +3,9 @@ package option
 import (
 	"context"
 
+	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
 
@@ -11,6 +13,7 @@ type (
 	RestClient struct {
 		*binance.RestClient
 		wsAddr string
+		store  *exchange.SymbolStore
 	}
 
 	RestResp struct {
@@ -20,18 +23,24 @@ type (
 	}
 )
 
-func NewRestClient(key, secret string) *RestClient {
-	return &RestClient{
+func NewRestClient(key, secret string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
 		wsAddr:     "vstream.binance.com",
-		RestClient: binance.NewRestClient(key, secret, "vapi.binance.com"),
+		RestClient: binance.NewRestClient(key, secret, "vapi.binance.com", opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	ret.SetRateLimiter(NewHostRateLimiter(ret.Host()))
+	return ret
 }
 
-func NewTestRestClient(key, secret string) *RestClient {
-	return &RestClient{
+func NewTestRestClient(key, secret string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
 		wsAddr:     "testnetws.binanceops.com",
-		RestClient: binance.NewRestClient(key, secret, "testnet.binanceops.com"),
+		RestClient: binance.NewRestClient(key, secret, "testnet.binanceops.com", opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	ret.SetRateLimiter(NewHostRateLimiter(ret.Host()))
+	return ret
 }
 
 //GetRequest do get request. the dst field will be wrapped in restResp data field
@@ -12,6 +12,7 @@ import (
 	"github.com/NadiaSama/ccexgo/internal/rpc"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
 )
 
 type (
@@ -142,6 +143,23 @@ func (cc *CodeC) Decode(raw []byte) (rpc.Response, error) {
 		return &rpc.Result{}, nil
 	}
 
+	//subscribe response such as {"id":1,"result":null} or rejected request
+	//such as {"error":{"code":2,"msg":"Invalid request"},"id":1}
+	if g := gjson.ParseBytes(all); g.Get("id").Exists() {
+		if e := g.Get("error"); e.Exists() {
+			return &rpc.Result{
+				ID:    g.Get("id").String(),
+				Error: errors.Errorf("error code: %d msg: %s", e.Get("code").Int(), e.Get("msg").String()),
+			}, nil
+		}
+		if r := g.Get("result"); r.Exists() {
+			return &rpc.Result{
+				ID:     g.Get("id").String(),
+				Result: json.RawMessage(r.Raw),
+			}, nil
+		}
+	}
+
 	var resp wsResp
 	if err := json.Unmarshal(all, &resp); err != nil {
 		fmt.Printf("%s %s\n", string(all), err.Error())
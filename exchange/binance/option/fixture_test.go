@@ -0,0 +1,56 @@
+package option
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureOrders(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+
+	call, err := rc.ParseSymbol("BTC-211231-60000-C")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	if call.Type() != exchange.OptionTypeCall || !call.Strike().Equal(decimal.NewFromInt(60000)) ||
+		call.SettleTime().Unix() != 1640937600 || !call.AmountMin().Equal(decimal.RequireFromString("0.01")) {
+		t.Errorf("bad symbol %v", call)
+	}
+
+	orders, err := rc.OpenOrders(ctx, call)
+	if err != nil {
+		t.Fatalf("open orders fail %s", err.Error())
+	}
+	if len(orders) != 1 {
+		t.Fatalf("bad orders %v", orders)
+	}
+	o := orders[0]
+	if o.ID.String() != "4611875134427365377" || o.ClientID.String() != "myOption1" || o.Side != exchange.OrderSideBuy ||
+		o.Status != exchange.OrderStatusOpen || !o.Filled.Equal(decimal.RequireFromString("0.1")) ||
+		o.Created.UnixNano()/1e6 != 1633072800123 {
+		t.Errorf("bad order %+v", o)
+	}
+
+	positions, err := rc.FetchPosition(ctx)
+	if err != nil {
+		t.Fatalf("fetch position fail %s", err.Error())
+	}
+	if len(positions) != 1 {
+		t.Fatalf("bad positions %v", positions)
+	}
+	p := positions[0]
+	if p.Symbol.String() != "BTC-211231-40000-P" || p.Side != exchange.PositionSideShort ||
+		!p.Position.Equal(decimal.RequireFromString("0.5")) || !p.UNRealizedPNL.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("bad position %+v", p)
+	}
+
+	if _, err := rc.FetchOrder(ctx, &exchange.Order{ID: exchange.NewStrID("4611875134427365378"), Symbol: call}); err == nil {
+		t.Errorf("expect fetch order fail")
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+var _ exchange.Trader = (*RestClient)(nil)
+
 type (
 	GetOrderReq struct {
 		*binance.RestReq
@@ -43,11 +45,15 @@ type (
 )
 
 const (
-	OrderEndPoint   = "/vapi/v1/order"
-	OrderSideBuy    = "BUY"
-	OrderSideSell   = "SELL"
-	OrderTypeLimit  = "LIMIT"
-	OrderTypeMarket = "MARKET"
+	OrderEndPoint      = "/vapi/v1/order"
+	OpenOrdersEndPoint = "/vapi/v1/openOrders"
+	OrderSideBuy       = "BUY"
+	OrderSideSell      = "SELL"
+	OrderTypeLimit     = "LIMIT"
+	OrderTypeMarket    = "MARKET"
+	TimeInForceGTC     = "GTC"
+	TimeInForceIOC     = "IOC"
+	TimeInForceFOK     = "FOK"
 )
 
 const (
@@ -97,6 +103,12 @@ var (
 		OrderTypeMarket: exchange.OrderTypeMarket,
 	}
 	typeToBnOrderType = map[exchange.OrderType]string{}
+
+	tifToBnTimeInForce = map[exchange.TimeInForceFlag]string{
+		exchange.TimeInForceGTC: TimeInForceGTC,
+		exchange.TimeInForceIOC: TimeInForceIOC,
+		exchange.TimeInForceFOK: TimeInForceFOK,
+	}
 )
 
 func init() {
@@ -123,7 +135,11 @@ func (os OrderStatus) String() string {
 
 //NewPostOrderReq build create order request, the amount and price param will be formatted according to symbol precision
 func NewPostOrderReq(symbol string, side string, typ string, amount float64, price float64) (*PostOrdreReq, error) {
-	s, err := ParseSymbol(symbol)
+	return newPostOrderReq(getDefaultClient(), symbol, side, typ, amount, price)
+}
+
+func newPostOrderReq(rc *RestClient, symbol string, side string, typ string, amount float64, price float64) (*PostOrdreReq, error) {
+	s, err := rc.ParseSymbol(symbol)
 	if err != nil {
 		return nil, errors.WithMessage(err, "parse symbol fail")
 	}
@@ -148,6 +164,21 @@ func (or *PostOrdreReq) ReduceOnly(r bool) *PostOrdreReq {
 	return or
 }
 
+func (or *PostOrdreReq) PostOnly(p bool) *PostOrdreReq {
+	or.AddFields("postOnly", p)
+	return or
+}
+
+func (or *PostOrdreReq) TimeInForce(tif string) *PostOrdreReq {
+	or.AddFields("timeInForce", tif)
+	return or
+}
+
+func (or *PostOrdreReq) ClientOrderID(cid string) *PostOrdreReq {
+	or.AddFields("clientOrderId", cid)
+	return or
+}
+
 func NewDeleteOrderReq(symbol string, orderID string) *GetOrderReq {
 	req := NewGetOrderReq(symbol)
 	req.OrderID(orderID)
@@ -174,7 +205,11 @@ func (gor *GetOrderReq) ClientOrderID(cid string) *GetOrderReq {
 }
 
 func (resp *OrderResp) Transfer() (*exchange.Order, error) {
-	sym, err := ParseSymbol(resp.Symbol)
+	return resp.transfer(getDefaultClient())
+}
+
+func (resp *OrderResp) transfer(rc *RestClient) (*exchange.Order, error) {
+	sym, err := rc.ParseSymbol(resp.Symbol)
 	if err != nil {
 		return nil, errors.WithMessage(err, "parse symbol fail")
 	}
@@ -268,9 +303,19 @@ func (rc *RestClient) DeleteOrder(ctx context.Context, req *GetOrderReq) (*Order
 	return &ret, nil
 }
 
+func (rc *RestClient) GetOpenOrders(ctx context.Context, req *GetOrderReq) ([]OrderResp, error) {
+	var ret []OrderResp
+
+	if err := rc.GetRequest(ctx, OpenOrdersEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get request fail")
+	}
+
+	return ret, nil
+}
+
 func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
-	if len(options) != 0 {
-		return nil, errors.Errorf("options is not support yet")
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
 	}
 
 	side, ok := sideToBnOrderSide[req.Side]
@@ -284,16 +329,37 @@ func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 	price, _ := req.Price.Float64()
 	amt, _ := req.Amount.Float64()
 
-	or, err := NewPostOrderReq(req.Symbol.String(), side, typ, amt, price)
+	or, err := newPostOrderReq(rc, req.Symbol.String(), side, typ, amt, price)
 	if err != nil {
 		return nil, errors.WithMessage(err, "create order req fail")
 	}
 
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			or.PostOnly(t.PostOnly)
+
+		case *exchange.TimeInForceOption:
+			tif, ok := tifToBnTimeInForce[t.Flag]
+			if !ok {
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
+			}
+			or.TimeInForce(tif)
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
+	if req.ClientID != nil {
+		or.ClientOrderID(req.ClientID.String())
+	}
+
 	resp, err := rc.PostOrder(ctx, or)
 	if err != nil {
 		return nil, errors.WithMessage(err, "postOrder fail")
 	}
-	return resp.Transfer()
+	return resp.transfer(rc)
 }
 
 func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
@@ -302,7 +368,7 @@ func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*
 		return nil, errors.WithMessage(err, "delete order fail")
 	}
 
-	return resp.Transfer()
+	return resp.transfer(rc)
 }
 
 func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
@@ -311,7 +377,24 @@ func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*e
 		return nil, errors.WithMessage(err, "fetch order fail")
 	}
 
-	return resp.Transfer()
+	return resp.transfer(rc)
+}
+
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	resp, err := rc.GetOpenOrders(ctx, NewGetOrderReq(symbol.String()))
+	if err != nil {
+		return nil, errors.WithMessage(err, "fetch open orders fail")
+	}
+
+	ret := make([]*exchange.Order, len(resp))
+	for i := range resp {
+		o, err := resp[i].transfer(rc)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = o
+	}
+	return ret, nil
 }
 
 //ParseOrderResp extract orderResp info from order.Raw field
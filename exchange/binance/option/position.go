@@ -75,7 +75,7 @@ func (rc *RestClient) FetchPosition(ctx context.Context, sym ...exchange.Symbol)
 
 	ret := make([]exchange.Position, len(pos))
 	for i, p := range pos {
-		sp, err := p.Transfer()
+		sp, err := p.transfer(rc)
 		if err != nil {
 			return nil, errors.WithMessage(err, "parse position fail")
 		}
@@ -86,6 +86,10 @@ func (rc *RestClient) FetchPosition(ctx context.Context, sym ...exchange.Symbol)
 }
 
 func (p *Position) Transfer() (*exchange.Position, error) {
+	return p.transfer(getDefaultClient())
+}
+
+func (p *Position) transfer(rc *RestClient) (*exchange.Position, error) {
 	var posSide exchange.PositionSide
 
 	if p.Side == PositionSideLong {
@@ -96,7 +100,7 @@ func (p *Position) Transfer() (*exchange.Position, error) {
 		return nil, errors.Errorf("unknown side='%s'", p.Side)
 	}
 
-	sym, err := ParseSymbol(p.Symbol)
+	sym, err := rc.ParseSymbol(p.Symbol)
 	if err != nil {
 		return nil, errors.WithMessage(err, "parse symbol fail")
 	}
@@ -0,0 +1,40 @@
+package option
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+)
+
+const (
+	BucketWeight = "weight"
+	BucketOrders = "orders"
+)
+
+var (
+	//weight limit is per-IP, shared by all option clients of the same host
+	ipWeightBuckets = ratelimit.NewSharedBuckets(BucketWeight, 400, time.Minute)
+)
+
+//NewRateLimiter return the default option rate limiter of the mainnet host
+func NewRateLimiter() *ratelimit.Limiter {
+	return NewHostRateLimiter("vapi.binance.com")
+}
+
+//NewHostRateLimiter return the option rate limiter of host, the weight
+//bucket is shared by all option clients of host
+func NewHostRateLimiter(host string) *ratelimit.Limiter {
+	weight := func(w int) ratelimit.Cost {
+		return ratelimit.Cost{Bucket: BucketWeight, Weight: w}
+	}
+
+	return ratelimit.NewLimiter(ratelimit.PolicyBlock,
+		ipWeightBuckets.Get(host),
+		ratelimit.NewBucket(BucketOrders, 100, 10*time.Second),
+	).SetDefault(weight(1)).
+		SetWeight(http.MethodGet, AccountEndPoint, weight(5)).
+		SetWeight(http.MethodGet, PositionEndPoint, weight(5)).
+		SetWeight(http.MethodPost, OrderEndPoint, weight(1), ratelimit.Cost{Bucket: BucketOrders, Weight: 1}).
+		SetUsedHeader("X-MBX-USED-WEIGHT-1M", BucketWeight)
+}
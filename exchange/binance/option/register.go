@@ -0,0 +1,37 @@
+package option
+
+import (
+	"context"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+const (
+	ExchangeName = "binance.option"
+)
+
+func init() {
+	exchange.Register(&exchange.Registration{
+		Name: ExchangeName,
+		NewRestClient: func(cfg *exchange.Config) (interface{}, error) {
+			if cfg.TestNet {
+				return NewTestRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...), nil
+			}
+			return NewRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...), nil
+		},
+		LoadSymbols: func(ctx context.Context, cfg *exchange.Config) error {
+			return Init(ctx, cfg.TestNet)
+		},
+		ParseSymbol: func(symbol string) (exchange.Symbol, error) {
+			return ParseSymbol(symbol)
+		},
+		NewSymbolStore: func(cfg *exchange.Config) (*exchange.SymbolStore, error) {
+			if cfg.TestNet {
+				return NewTestRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...).NewSymbolStore(), nil
+			}
+			return NewRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...).NewSymbolStore(), nil
+		},
+		Capabilities: exchange.CapabilityTrader | exchange.CapabilityTestNet | exchange.CapabilityBalance,
+	})
+}
@@ -53,13 +53,22 @@ const (
 )
 
 var (
-	symbolMap  = map[string]*Symbol{}
-	mu         sync.Mutex
-	useTestNet bool
+	defaultClient = NewRestClient("", "")
+	mu            sync.Mutex
 )
 
 func Init(ctx context.Context, testNet bool) error {
-	useTestNet = testNet
+	var rc *RestClient
+	if testNet {
+		rc = NewTestRestClient("", "")
+	} else {
+		rc = NewRestClient("", "")
+	}
+
+	mu.Lock()
+	defaultClient = rc
+	mu.Unlock()
+
 	st, err := updateSymbol(ctx)
 	if err != nil {
 		return errors.WithMessage(err, "updateSymbol fail")
@@ -94,57 +103,82 @@ func Init(ctx context.Context, testNet bool) error {
 	return nil
 }
 
+//SymbolStore return symbol store of the default client which is set by Init
+func SymbolStore() *exchange.SymbolStore {
+	return getDefaultClient().SymbolStore()
+}
+
 func ParseSymbol(symbol string) (exchange.OptionSymbol, error) {
-	mu.Lock()
-	defer mu.Unlock()
-	if sym, ok := symbolMap[symbol]; ok {
-		return sym, nil
-	} else {
+	return getDefaultClient().ParseSymbol(symbol)
+}
+
+//SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
+
+//LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
+}
+
+//ParseSymbol parse symbol with symbol store of rc. the default client store
+//is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(symbol string) (exchange.OptionSymbol, error) {
+	store := rc.store
+	if store.Updated().IsZero() {
+		store = getDefaultClient().store
+	}
+	sym, err := store.Lookup(symbol)
+	if err != nil {
 		return nil, errors.Errorf("unknown symbol='%s'", symbol)
 	}
+	return sym.(exchange.OptionSymbol), nil
 }
 
-func updateSymbol(ctx context.Context) (minSettleTime time.Time, err error) {
-	var rc *RestClient
+func getDefaultClient() *RestClient {
+	mu.Lock()
+	defer mu.Unlock()
+	return defaultClient
+}
 
-	if useTestNet {
-		rc = NewTestRestClient("", "")
-	} else {
-		rc = NewRestClient("", "")
-	}
+//NewSymbolStore return a symbol store which fetch symbols with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		symbols, err := rc.Symbols(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	var (
-		symbols []exchange.OptionSymbol
-	)
-	symbols, err = rc.Symbols(ctx)
-	if err != nil {
-		err = errors.WithMessage(err, "fetch symbols fail")
+		ret := make([]exchange.Symbol, len(symbols))
+		for i, s := range symbols {
+			ret[i] = s
+		}
+		return ret, nil
+	}, opts...)
+}
+
+func updateSymbol(ctx context.Context) (minSettleTime time.Time, err error) {
+	store := SymbolStore()
+	if _, err = store.Refresh(ctx); err != nil {
 		return
 	}
 
+	symbols := store.Symbols()
 	if len(symbols) == 0 {
 		err = errors.Errorf("no symbols")
 		return
 	}
 
 	sort.Slice(symbols, func(i, j int) bool {
-		si := symbols[i]
-		sj := symbols[j]
+		si := symbols[i].(exchange.OptionSymbol)
+		sj := symbols[j].(exchange.OptionSymbol)
 
 		return si.SettleTime().Before(sj.SettleTime())
 	})
 
-	nm := make(map[string]*Symbol, len(symbols))
-	for _, s := range symbols {
-		sym := s.(*Symbol)
-		nm[s.String()] = sym
-	}
-
-	minSettleTime = symbols[0].SettleTime()
-
-	mu.Lock()
-	defer mu.Unlock()
-	symbolMap = nm
+	minSettleTime = symbols[0].(exchange.OptionSymbol).SettleTime()
 	return
 }
 
@@ -0,0 +1,22 @@
+package option
+
+import (
+	"context"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/misc/tconv"
+)
+
+const (
+	ServerTimeEndPoint = "/vapi/v1/time"
+)
+
+//ServerTime return binance option server time, it can be used as clock.Source
+func (rc *RestClient) ServerTime(ctx context.Context) (time.Time, error) {
+	var ts int64
+	if err := rc.GetRequest(ctx, ServerTimeEndPoint, binance.NewRestReq(), false, &ts); err != nil {
+		return time.Time{}, err
+	}
+	return tconv.Milli2Time(ts), nil
+}
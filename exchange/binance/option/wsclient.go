@@ -41,7 +41,11 @@ func (wl *WSClient) Subscribe(ctx context.Context, channels ...exchange.Channel)
 		params[i] = c.String()
 	}
 
-	if err := wl.Call(ctx, "1", "", params, nil); err != nil {
+	ctx, cancel := context.WithTimeout(ctx, binance.AckTimeout)
+	defer cancel()
+
+	var r interface{}
+	if err := wl.Call(ctx, rpc.NextID(), MethodSubscribe, params, &r); err != nil {
 		return errors.WithMessage(err, "rpc call fail")
 	}
 	return nil
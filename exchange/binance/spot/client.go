@@ -1,15 +1,23 @@
 package spot
 
-import "github.com/NadiaSama/ccexgo/exchange/binance"
+import (
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
 
 type (
 	RestClient struct {
 		*binance.RestClient
+		store *exchange.SymbolStore
 	}
 )
 
-func NewRestClient(key, secret string) *RestClient {
-	return &RestClient{
-		binance.NewRestClient(key, secret, "api.binance.com"),
+func NewRestClient(key, secret string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		RestClient: binance.NewRestClient(key, secret, "api.binance.com", opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	ret.SetRateLimiter(NewHostRateLimiter(ret.Host()))
+	return ret
 }
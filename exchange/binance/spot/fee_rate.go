@@ -51,7 +51,7 @@ func (rc *RestClient) FeeRate(ctx context.Context, symbols []exchange.Symbol) ([
 	for i := range tfs {
 		tf := tfs[i]
 
-		r, err := tf.Parse()
+		r, err := tf.parse(rc)
 		if err != nil {
 			return nil, errors.WithMessage(err, "parse trade fee fail")
 		}
@@ -65,7 +65,11 @@ func (rc *RestClient) FeeRate(ctx context.Context, symbols []exchange.Symbol) ([
 }
 
 func (tf *TradeFee) Parse() (*exchange.TradeFee, error) {
-	s, err := ParseSymbol(tf.Symbol)
+	return tf.parse(defaultClient)
+}
+
+func (tf *TradeFee) parse(rc *RestClient) (*exchange.TradeFee, error) {
+	s, err := rc.ParseSymbol(tf.Symbol)
 	if err != nil {
 		return nil, err
 	}
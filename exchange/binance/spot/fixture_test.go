@@ -0,0 +1,57 @@
+package spot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureOrders(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+
+	sym, err := rc.ParseSymbol("BTCUSDT")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	if sym.Base() != "BTC" || sym.Quote() != "USDT" {
+		t.Errorf("bad symbol %v", sym)
+	}
+
+	orders, err := rc.OpenOrders(ctx, sym)
+	if err != nil {
+		t.Fatalf("open orders fail %s", err.Error())
+	}
+	if len(orders) != 1 {
+		t.Fatalf("bad orders %v", orders)
+	}
+	o := orders[0]
+	if o.ID.String() != "5981734" || o.Side != exchange.OrderSideBuy || o.Type != exchange.OrderTypeLimit ||
+		o.Status != exchange.OrderStatusOpen || !o.Filled.Equal(decimal.RequireFromString("0.004")) ||
+		!o.AvgPrice.Equal(decimal.NewFromInt(43000)) || o.Created.UnixNano()/1e6 != 1633072800123 {
+		t.Errorf("bad order %+v", o)
+	}
+
+	eth, err := rc.ParseSymbol("ETHBTC")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	o, err = rc.FetchOrder(ctx, &exchange.Order{ID: exchange.NewIntID(5981735), Symbol: eth})
+	if err != nil {
+		t.Fatalf("fetch order fail %s", err.Error())
+	}
+	if o.Status != exchange.OrderStatusCancel || o.Side != exchange.OrderSideSell || o.ClientID.String() != "myOrder1" ||
+		!o.AvgPrice.IsZero() {
+		t.Errorf("bad order %+v", o)
+	}
+
+	_, err = rc.CancelOrder(ctx, &exchange.Order{ID: exchange.NewIntID(5981735), Symbol: eth})
+	if !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("expect order not found got %v", err)
+	}
+}
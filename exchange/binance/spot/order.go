@@ -0,0 +1,353 @@
+package spot
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/misc/tconv"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var _ exchange.Trader = (*RestClient)(nil)
+
+type (
+	AddOrderReq struct {
+		*binance.RestReq
+	}
+
+	OrderReq struct {
+		*binance.RestReq
+	}
+
+	OrderResp struct {
+		binance.APIError                    //in case of error
+		Symbol              string          `json:"symbol"`
+		OrderID             int64           `json:"orderId"`
+		ClientOrderID       string          `json:"clientOrderId"`
+		OrigClientOrderID   string          `json:"origClientOrderId"`
+		Price               decimal.Decimal `json:"price"`
+		OrigQty             decimal.Decimal `json:"origQty"`
+		ExecutedQty         decimal.Decimal `json:"executedQty"`
+		CummulativeQuoteQty decimal.Decimal `json:"cummulativeQuoteQty"`
+		Status              string          `json:"status"`
+		TimeInForce         string          `json:"timeInForce"`
+		Type                string          `json:"type"`
+		Side                string          `json:"side"`
+		Time                int64           `json:"time"`
+		TransactTime        int64           `json:"transactTime"`
+		UpdateTime          int64           `json:"updateTime"`
+	}
+)
+
+const (
+	OrderEndPoint      = "/api/v3/order"
+	OpenOrdersEndPoint = "/api/v3/openOrders"
+
+	SideBuy             = "BUY"
+	SideSell            = "SELL"
+	OrderTypeLimit      = "LIMIT"
+	OrderTypeMarket     = "MARKET"
+	OrderTypeLimitMaker = "LIMIT_MAKER"
+	TimeInForceGTC      = "GTC"
+	TimeInForceIOC      = "IOC"
+	TimeInForceFOK      = "FOK"
+	NewOrderRespTypeRes = "RESULT"
+)
+
+var (
+	orderType2ExType = map[string]exchange.OrderType{
+		OrderTypeLimit:      exchange.OrderTypeLimit,
+		OrderTypeLimitMaker: exchange.OrderTypeLimit,
+		OrderTypeMarket:     exchange.OrderTypeMarket,
+	}
+
+	exType2OrderType = map[exchange.OrderType]string{
+		exchange.OrderTypeLimit:  OrderTypeLimit,
+		exchange.OrderTypeMarket: OrderTypeMarket,
+	}
+
+	side2ExSide = map[string]exchange.OrderSide{
+		SideBuy:  exchange.OrderSideBuy,
+		SideSell: exchange.OrderSideSell,
+	}
+
+	exSide2Side = map[exchange.OrderSide]string{
+		exchange.OrderSideBuy:  SideBuy,
+		exchange.OrderSideSell: SideSell,
+	}
+
+	exTimeInForce2TimeInForce = map[exchange.TimeInForceFlag]string{
+		exchange.TimeInForceGTC: TimeInForceGTC,
+		exchange.TimeInForceIOC: TimeInForceIOC,
+		exchange.TimeInForceFOK: TimeInForceFOK,
+	}
+
+	status2ExStatus = map[string]exchange.OrderStatus{
+		"NEW":              exchange.OrderStatusOpen,
+		"PARTIALLY_FILLED": exchange.OrderStatusOpen,
+		"PENDING_CANCEL":   exchange.OrderStatusOpen,
+		"FILLED":           exchange.OrderStatusDone,
+		"CANCELED":         exchange.OrderStatusCancel,
+		"REJECTED":         exchange.OrderStatusFailed,
+		"EXPIRED":          exchange.OrderStatusCancel,
+	}
+)
+
+//NewAddOrderReq according symbol, side, type
+func NewAddOrderReq(symbol string, side string, typ string) *AddOrderReq {
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	req.AddFields("side", side)
+	req.AddFields("type", typ)
+	req.AddFields("newOrderRespType", NewOrderRespTypeRes)
+
+	return &AddOrderReq{
+		RestReq: req,
+	}
+}
+
+func (req *AddOrderReq) TimeInForce(tif string) *AddOrderReq {
+	req.AddFields("timeInForce", tif)
+	return req
+}
+
+func (req *AddOrderReq) Price(prc decimal.Decimal) *AddOrderReq {
+	req.AddFields("price", prc.String())
+	return req
+}
+
+func (req *AddOrderReq) Quantity(q decimal.Decimal) *AddOrderReq {
+	req.AddFields("quantity", q.String())
+	return req
+}
+
+func (req *AddOrderReq) NewClientOrderID(id string) *AddOrderReq {
+	req.AddFields("newClientOrderId", id)
+	return req
+}
+
+func NewOrderReq(symbol string) *OrderReq {
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	return &OrderReq{
+		RestReq: req,
+	}
+}
+
+func (r *OrderReq) OrderID(id int64) *OrderReq {
+	r.AddFields("orderId", id)
+	return r
+}
+
+func (rc *RestClient) AddOrder(ctx context.Context, req *AddOrderReq) (*OrderResp, error) {
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get param fail")
+	}
+
+	var ret OrderResp
+	if err := rc.Request(ctx, http.MethodPost, OrderEndPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "add order fail")
+	}
+	return &ret, nil
+}
+
+func (rc *RestClient) GetOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
+	var ret OrderResp
+	if err := rc.GetRequest(ctx, OrderEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get order fail")
+	}
+	return &ret, nil
+}
+
+func (rc *RestClient) DeleteOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get param fail")
+	}
+
+	var ret OrderResp
+	if err := rc.Request(ctx, http.MethodDelete, OrderEndPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "cancel order fail")
+	}
+	return &ret, nil
+}
+
+func (rc *RestClient) GetOpenOrders(ctx context.Context, req *OrderReq) ([]OrderResp, error) {
+	var ret []OrderResp
+	if err := rc.GetRequest(ctx, OpenOrdersEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get open orders fail")
+	}
+	return ret, nil
+}
+
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	side, ok := exSide2Side[req.Side]
+	if !ok {
+		return nil, exchange.NewBadArg("unsupport order side", req.Side)
+	}
+
+	typ, ok := exType2OrderType[req.Type]
+	if !ok {
+		return nil, exchange.NewBadArg("unsupport order type", req.Type)
+	}
+
+	tif := TimeInForceGTC
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			if t.PostOnly && typ == OrderTypeLimit {
+				typ = OrderTypeLimitMaker
+			}
+
+		case *exchange.TimeInForceOption:
+			v, ok := exTimeInForce2TimeInForce[t.Flag]
+			if !ok {
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
+			}
+			tif = v
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
+	or := NewAddOrderReq(req.Symbol.String(), side, typ)
+	or.Quantity(req.Amount)
+	if typ != OrderTypeMarket {
+		or.Price(req.Price)
+	}
+	//LIMIT_MAKER order do not accept timeInForce param
+	if typ == OrderTypeLimit {
+		or.TimeInForce(tif)
+	}
+	if req.ClientID != nil {
+		or.NewClientOrderID(req.ClientID.String())
+	}
+
+	resp, err := rc.AddOrder(ctx, or)
+	if err != nil {
+		return nil, err
+	}
+	return resp.transfer(rc)
+}
+
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req, err := orderReq(order)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rc.DeleteOrder(ctx, req)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "cancel order fail ID=%s", order.ID.String())
+	}
+	return resp.transfer(rc)
+}
+
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req, err := orderReq(order)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rc.GetOrder(ctx, req)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "get order fail ID=%s", order.ID.String())
+	}
+	return resp.transfer(rc)
+}
+
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	resp, err := rc.GetOpenOrders(ctx, NewOrderReq(symbol.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, len(resp))
+	for i := range resp {
+		o, err := resp[i].transfer(rc)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = o
+	}
+	return ret, nil
+}
+
+func (resp *OrderResp) Transfer() (*exchange.Order, error) {
+	return resp.transfer(defaultClient)
+}
+
+func (resp *OrderResp) transfer(rc *RestClient) (*exchange.Order, error) {
+	symbol, err := rc.ParseSymbol(resp.Symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	typ, ok := orderType2ExType[resp.Type]
+	if !ok {
+		return nil, errors.Errorf("unknown resp type=%s", resp.Type)
+	}
+
+	side, ok := side2ExSide[resp.Side]
+	if !ok {
+		return nil, errors.Errorf("unknown resp side=%s", resp.Side)
+	}
+
+	status, ok := status2ExStatus[resp.Status]
+	if !ok {
+		return nil, errors.Errorf("unknown resp status=%s", resp.Status)
+	}
+
+	var avgPrice decimal.Decimal
+	if !resp.ExecutedQty.IsZero() {
+		avgPrice = resp.CummulativeQuoteQty.Div(resp.ExecutedQty)
+	}
+
+	created := resp.Time
+	if created == 0 {
+		created = resp.TransactTime
+	}
+	updated := resp.UpdateTime
+	if updated == 0 {
+		updated = created
+	}
+
+	cid := resp.ClientOrderID
+	if resp.OrigClientOrderID != "" {
+		cid = resp.OrigClientOrderID
+	}
+
+	return &exchange.Order{
+		ID:       exchange.NewIntID(resp.OrderID),
+		ClientID: exchange.NewStrID(cid),
+		Symbol:   symbol,
+		Amount:   resp.OrigQty,
+		Filled:   resp.ExecutedQty,
+		Price:    resp.Price,
+		AvgPrice: avgPrice,
+		Created:  tconv.Milli2Time(created),
+		Updated:  tconv.Milli2Time(updated),
+		Side:     side,
+		Type:     typ,
+		Status:   status,
+		Raw:      resp,
+	}, nil
+}
+
+func orderReq(order *exchange.Order) (*OrderReq, error) {
+	id, err := strconv.ParseInt(order.ID.String(), 10, 64)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "bad orderID=%s", order.ID.String())
+	}
+	return NewOrderReq(order.Symbol.String()).OrderID(id), nil
+}
@@ -0,0 +1,53 @@
+package spot
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+)
+
+const (
+	BucketWeight    = "weight"
+	BucketOrders    = "orders"
+	BucketOrdersDay = "orders_day"
+)
+
+var (
+	//weight limit is per-IP, shared by all spot clients of the same host
+	ipWeightBuckets = ratelimit.NewSharedBuckets(BucketWeight, 1200, time.Minute)
+)
+
+//NewRateLimiter return the default spot rate limiter of the mainnet host
+func NewRateLimiter() *ratelimit.Limiter {
+	return NewHostRateLimiter("api.binance.com")
+}
+
+//NewHostRateLimiter return the spot rate limiter of host. the weight bucket
+//is shared by all spot clients of host and synced with X-MBX-USED-WEIGHT-1M
+//header while the order buckets are per-account
+func NewHostRateLimiter(host string) *ratelimit.Limiter {
+	weight := func(w int) ratelimit.Cost {
+		return ratelimit.Cost{Bucket: BucketWeight, Weight: w}
+	}
+	order := []ratelimit.Cost{
+		weight(1),
+		{Bucket: BucketOrders, Weight: 1},
+		{Bucket: BucketOrdersDay, Weight: 1},
+	}
+
+	return ratelimit.NewLimiter(ratelimit.PolicyBlock,
+		ipWeightBuckets.Get(host),
+		ratelimit.NewBucket(BucketOrders, 50, 10*time.Second),
+		ratelimit.NewBucket(BucketOrdersDay, 160000, 24*time.Hour),
+	).SetDefault(weight(1)).
+		SetWeight(http.MethodGet, "/api/v3/exchangeInfo", weight(10)).
+		SetWeight(http.MethodGet, AccountEndPoint, weight(10)).
+		SetWeight(http.MethodGet, MyTradesEndPoint, weight(10)).
+		SetWeight(http.MethodGet, OrderEndPoint, weight(2)).
+		SetWeight(http.MethodGet, OpenOrdersEndPoint, weight(3)).
+		SetWeight(http.MethodPost, OrderEndPoint, order...).
+		SetUsedHeader("X-MBX-USED-WEIGHT-1M", BucketWeight).
+		SetUsedHeader("X-MBX-ORDER-COUNT-10S", BucketOrders).
+		SetUsedHeader("X-MBX-ORDER-COUNT-1D", BucketOrdersDay)
+}
@@ -0,0 +1,19 @@
+package spot
+
+import (
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+func TestHostRateLimiter(t *testing.T) {
+	mainnet := NewRestClient("", "").RateLimiter().Bucket(BucketWeight)
+	if mainnet != NewRestClient("", "").RateLimiter().Bucket(BucketWeight) {
+		t.Errorf("weight bucket of the same host is not shared")
+	}
+
+	local := NewRestClient("", "", request.WithBaseURL("http://127.0.0.1:8080")).RateLimiter().Bucket(BucketWeight)
+	if local == mainnet {
+		t.Errorf("weight bucket of base url host is shared with mainnet")
+	}
+}
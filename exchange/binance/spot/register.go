@@ -0,0 +1,34 @@
+package spot
+
+import (
+	"context"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+const (
+	ExchangeName = "binance.spot"
+)
+
+func init() {
+	exchange.Register(&exchange.Registration{
+		Name: ExchangeName,
+		NewRestClient: func(cfg *exchange.Config) (interface{}, error) {
+			return NewRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...), nil
+		},
+		NewWSClient: func(cfg *exchange.Config, data chan interface{}) (exchange.WSConn, error) {
+			return NewWSClient(data), nil
+		},
+		LoadSymbols: func(ctx context.Context, cfg *exchange.Config) error {
+			return Init(ctx)
+		},
+		ParseSymbol: func(symbol string) (exchange.Symbol, error) {
+			return ParseSymbol(symbol)
+		},
+		NewSymbolStore: func(cfg *exchange.Config) (*exchange.SymbolStore, error) {
+			return NewRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...).NewSymbolStore(), nil
+		},
+		Capabilities: exchange.CapabilityTrader | exchange.CapabilityPublicStream | exchange.CapabilityTrades,
+	})
+}
@@ -32,22 +32,59 @@ type (
 )
 
 var (
-	ErrPair   = errors.New("symbol pair not support")
-	symbolMap = map[string]exchange.SpotSymbol{}
+	ErrPair       = errors.New("symbol pair not support")
+	defaultClient = NewRestClient("", "")
 )
 
+//Init load symbols of the default client which are used by ParseSymbol
 func Init(ctx context.Context) error {
-	client := NewRestClient("", "")
-	symbols, err := client.Symbols(ctx)
+	return defaultClient.LoadSymbols(ctx)
+}
+
+//SymbolStore return symbol store of the default client which is used by ParseSymbol
+func SymbolStore() *exchange.SymbolStore {
+	return defaultClient.SymbolStore()
+}
+
+//SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
+
+//LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
+}
+
+//ParseSymbol parse symbol with symbol store of rc. the default client store
+//is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(sym string) (exchange.SpotSymbol, error) {
+	store := rc.store
+	if store.Updated().IsZero() {
+		store = defaultClient.store
+	}
+	ret, err := store.Lookup(sym)
 	if err != nil {
-		return err
+		return nil, ErrPair
 	}
+	return ret.(exchange.SpotSymbol), nil
+}
 
-	for i := range symbols {
-		s := symbols[i]
-		symbolMap[s.String()] = s
-	}
-	return nil
+//NewSymbolStore return a symbol store which fetch symbols with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		symbols, err := rc.Symbols(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]exchange.Symbol, len(symbols))
+		for i, s := range symbols {
+			ret[i] = s
+		}
+		return ret, nil
+	}, opts...)
 }
 
 func (rc *RestClient) ExchangeInfo(ctx context.Context) (*ExchangeInfo, error) {
@@ -87,11 +124,7 @@ func NewSymbol(base, quote string) exchange.SpotSymbol {
 }
 
 func ParseSymbol(sym string) (exchange.SpotSymbol, error) {
-	ret, ok := symbolMap[sym]
-	if !ok {
-		return nil, ErrPair
-	}
-	return ret, nil
+	return defaultClient.ParseSymbol(sym)
 }
 
 func (sym *Symbol) Parse() (exchange.SpotSymbol, error) {
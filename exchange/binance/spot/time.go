@@ -0,0 +1,15 @@
+package spot
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ServerTimeEndPoint = "/api/v3/time"
+)
+
+//ServerTime return binance spot server time, it can be used as clock.Source
+func (rc *RestClient) ServerTime(ctx context.Context) (time.Time, error) {
+	return rc.GetServerTime(ctx, ServerTimeEndPoint)
+}
@@ -60,7 +60,7 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 	ret := []exchange.Trade{}
 	for i := range trades {
 		trade := trades[i]
-		t, err := trade.Parse()
+		t, err := trade.parse(rc)
 		if err != nil {
 			return nil, err
 		}
@@ -71,7 +71,11 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 }
 
 func (t *Trade) Parse() (*exchange.Trade, error) {
-	s, err := ParseSymbol(t.Symbol)
+	return t.parse(defaultClient)
+}
+
+func (t *Trade) parse(rc *RestClient) (*exchange.Trade, error) {
+	s, err := rc.ParseSymbol(t.Symbol)
 	if err != nil {
 		return nil, err
 	}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 )
 
@@ -55,3 +57,28 @@ func ParseBookTickerNotify(g *gjson.Result) *BookTickerNotify {
 	}
 	return tn
 }
+
+//Normalize implement exchange.Normalizer. the symbol is parsed with
+//ParseSymbol so Init must be called first
+func (tn *BookTickerNotify) Normalize() (exchange.DataKind, interface{}, error) {
+	sym, err := ParseSymbol(tn.Symbol)
+	if err != nil {
+		return exchange.DataKindUnknown, nil, err
+	}
+
+	var vals [4]decimal.Decimal
+	for i, s := range []string{tn.Bid1Price, tn.Bid1Amount, tn.Ask1Price, tn.Ask1Amount} {
+		if vals[i], err = decimal.NewFromString(s); err != nil {
+			return exchange.DataKindUnknown, nil, errors.WithMessagef(err, "parse book ticker '%s'", s)
+		}
+	}
+
+	return exchange.DataKindTicker, &exchange.Ticker{
+		Symbol:      sym,
+		BestBid:     vals[0],
+		BestBidSize: vals[1],
+		BestAsk:     vals[2],
+		BestAskSize: vals[3],
+		Raw:         tn,
+	}, nil
+}
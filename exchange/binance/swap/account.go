@@ -51,7 +51,7 @@ type (
 		TotalInitialMargin         decimal.Decimal   `json:"totalInitialMargin"`
 		TotalMaintMargin           decimal.Decimal   `json:"totalMaintMargin"`
 		TotalWalletBalance         decimal.Decimal   `json:"totalWalletBalance"`
-		TotalUnrealizedProfit      decimal.Decimal   `json:"totalUnrealizedPorfit"`
+		TotalUnrealizedProfit      decimal.Decimal   `json:"totalUnrealizedProfit"`
 		TotalMarginBalance         decimal.Decimal   `json:"totalMarginBalance"`
 		TotalPositionInitialMargin decimal.Decimal   `json:"totalPositionInitialMargin"`
 		Assets                     []AccountAsset    `json:"assets"`
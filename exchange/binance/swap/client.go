@@ -1,14 +1,17 @@
 package swap
 
 import (
+	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/misc/request"
 )
 
 type (
 	//RestClient struct
 	RestClient struct {
 		*binance.RestClient
-		side *GetPositionSideResp
+		side  *GetPositionSideResp
+		store *exchange.SymbolStore
 	}
 )
 
@@ -17,14 +20,20 @@ const (
 	SwapTestAPIHost string = "testnet.binancefuture.com"
 )
 
-func NewRestClient(key, secret string) *RestClient {
-	return &RestClient{
-		RestClient: binance.NewRestClient(key, secret, SwapAPIHost),
+func NewRestClient(key, secret string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		RestClient: binance.NewRestClient(key, secret, SwapAPIHost, opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	ret.SetRateLimiter(NewHostRateLimiter(ret.Host()))
+	return ret
 }
 
-func NewTestRestClient(key, secret string) *RestClient {
-	return &RestClient{
-		RestClient: binance.NewRestClient(key, secret, SwapTestAPIHost),
+func NewTestRestClient(key, secret string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		RestClient: binance.NewRestClient(key, secret, SwapTestAPIHost, opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	ret.SetRateLimiter(NewHostRateLimiter(ret.Host()))
+	return ret
 }
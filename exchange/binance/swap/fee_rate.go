@@ -42,7 +42,7 @@ func (rc *RestClient) FeeRate(ctx context.Context, symbols []exchange.Symbol) ([
 		return nil, err
 	}
 
-	rate, err := cr.Parse()
+	rate, err := cr.parse(rc)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +51,11 @@ func (rc *RestClient) FeeRate(ctx context.Context, symbols []exchange.Symbol) ([
 }
 
 func (tf *CommisionRate) Parse() (*exchange.TradeFee, error) {
-	s, err := ParseSymbol(tf.Symbol)
+	return tf.parse(getDefaultClient())
+}
+
+func (tf *CommisionRate) parse(rc *RestClient) (*exchange.TradeFee, error) {
+	s, err := rc.ParseSymbol(tf.Symbol)
 	if err != nil {
 		return nil, err
 	}
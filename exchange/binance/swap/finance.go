@@ -91,7 +91,7 @@ func (rc *RestClient) Finance(ctx context.Context, req *exchange.FinanceReqParam
 	ret := []exchange.Finance{}
 	for i := range incomes {
 		income := incomes[i]
-		finance, err := income.Parse()
+		finance, err := income.parse(rc)
 		if err != nil {
 			return nil, errors.WithMessage(err, "parse income fail")
 		}
@@ -101,12 +101,16 @@ func (rc *RestClient) Finance(ctx context.Context, req *exchange.FinanceReqParam
 }
 
 func (ic *Income) Parse() (*exchange.Finance, error) {
+	return ic.parse(getDefaultClient())
+}
+
+func (ic *Income) parse(rc *RestClient) (*exchange.Finance, error) {
 	var (
 		s   exchange.Symbol
 		err error
 	)
 	if ic.Symbol != "" {
-		s, err = ParseSymbol(ic.Symbol)
+		s, err = rc.ParseSymbol(ic.Symbol)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,76 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureTrades(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+
+	sym, err := rc.ParseSymbol("BTCUSDT")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	if !sym.PricePrecision().Equal(decimal.RequireFromString("0.1")) || !sym.AmountMin().Equal(decimal.RequireFromString("0.001")) ||
+		!sym.ValueMin().Equal(decimal.NewFromInt(5)) {
+		t.Errorf("bad symbol %v", sym)
+	}
+
+	trades, err := rc.Trades(ctx, &exchange.TradeReqParam{Symbol: sym})
+	if err != nil {
+		t.Fatalf("fetch trades fail %s", err.Error())
+	}
+	if len(trades) != 1 {
+		t.Fatalf("bad trades %v", trades)
+	}
+	tr := trades[0]
+	if tr.ID != "698759" || tr.OrderID != "25851813" || tr.Side != exchange.OrderSideSell || !tr.IsMaker ||
+		!tr.Fee.Equal(decimal.RequireFromString("-0.0172")) || tr.Symbol.String() != "BTCUSDT" {
+		t.Errorf("bad trade %+v", tr)
+	}
+
+	finances, err := rc.Finance(ctx, &exchange.FinanceReqParam{Type: exchange.FinanceTypeFunding})
+	if err != nil {
+		t.Fatalf("fetch finance fail %s", err.Error())
+	}
+	if len(finances) != 1 || finances[0].Type != exchange.FinanceTypeFunding ||
+		!finances[0].Amount.Equal(decimal.RequireFromString("-0.01")) || finances[0].ID != "9689322392" {
+		t.Errorf("bad finances %+v", finances)
+	}
+
+	_, err = rc.Account(ctx, NewAccountReq())
+	if !errors.Is(err, exchange.ErrAuthFailed) {
+		t.Errorf("expect auth failed got %v", err)
+	}
+}
+
+func TestFixtureRegistry(t *testing.T) {
+	tr, err := fixture.Load(fixture.Dir)
+	if err != nil {
+		t.Fatalf("load fixture fail %s", err.Error())
+	}
+	reg, err := exchange.Lookup(ExchangeName)
+	if err != nil {
+		t.Fatalf("lookup registration fail %s", err.Error())
+	}
+
+	cfg := &exchange.Config{HTTPClient: &http.Client{Transport: tr}}
+	//symbols are loaded with cfg even if the default store is loaded before
+	for i := 0; i < 2; i++ {
+		if err := reg.Symbols(context.Background(), cfg); err != nil {
+			t.Fatalf("load symbols fail %s", err.Error())
+		}
+	}
+	if _, err := reg.Parse("BTCUSDT"); err != nil {
+		t.Errorf("parse symbol fail %s", err.Error())
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/tconv"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 )
 
@@ -58,3 +62,29 @@ func ParseBookTickerNotify(g *gjson.Result) *BookTickerNotify {
 		Ask1Amount: ask1Amount,
 	}
 }
+
+//Normalize implement exchange.Normalizer. the symbol is parsed with
+//ParseSymbol so Init must be called first
+func (btn *BookTickerNotify) Normalize() (exchange.DataKind, interface{}, error) {
+	sym, err := ParseSymbol(btn.Symbol)
+	if err != nil {
+		return exchange.DataKindUnknown, nil, err
+	}
+
+	var vals [4]decimal.Decimal
+	for i, s := range []string{btn.Bid1Price, btn.Bid1Amount, btn.Ask1Price, btn.Ask1Amount} {
+		if vals[i], err = decimal.NewFromString(s); err != nil {
+			return exchange.DataKindUnknown, nil, errors.WithMessagef(err, "parse book ticker '%s'", s)
+		}
+	}
+
+	return exchange.DataKindTicker, &exchange.Ticker{
+		Symbol:      sym,
+		BestBid:     vals[0],
+		BestBidSize: vals[1],
+		BestAsk:     vals[2],
+		BestAskSize: vals[3],
+		Time:        tconv.Milli2Time(btn.MatchTime),
+		Raw:         btn,
+	}, nil
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+var _ exchange.Trader = (*RestClient)(nil)
+
 type (
 	AddOrderReq struct {
 		*binance.RestReq
@@ -19,7 +21,7 @@ type (
 
 	OrderResp struct {
 		binance.APIError                 //in case of error
-		ClientOrderID    string          `json:"newOrderId"`
+		ClientOrderID    string          `json:"clientOrderId"`
 		CumQty           decimal.Decimal `json:"cumQty"`
 		CumQuote         decimal.Decimal `json:"cumQuote"`
 		ExecutedQty      decimal.Decimal `json:"executedQty"`
@@ -50,15 +52,19 @@ type (
 )
 
 const (
-	OrderEndPoint     = "/fapi/v1/order"
-	PositionSideBoth  = "BOTH"
-	PositionSideLong  = "LONG"
-	PositionSideShort = "SHORT"
-	SideBuy           = "BUY"
-	SideSell          = "SELL"
-	OrderTypeMarket   = "MARKET"
-	OrderTypeLimit    = "LIMIT"
-	TimeInForce       = "GTC"
+	OrderEndPoint      = "/fapi/v1/order"
+	OpenOrdersEndPoint = "/fapi/v1/openOrders"
+	PositionSideBoth   = "BOTH"
+	PositionSideLong   = "LONG"
+	PositionSideShort  = "SHORT"
+	SideBuy            = "BUY"
+	SideSell           = "SELL"
+	OrderTypeMarket    = "MARKET"
+	OrderTypeLimit     = "LIMIT"
+	TimeInForce        = "GTC"
+	TimeInForceIOC     = "IOC"
+	TimeInForceFOK     = "FOK"
+	TimeInForceGTX     = "GTX"
 )
 
 var (
@@ -72,13 +78,19 @@ var (
 		exchange.OrderTypeMarket: OrderTypeMarket,
 	}
 
+	ExTimeInForce2TimeInForce = map[exchange.TimeInForceFlag]string{
+		exchange.TimeInForceGTC: TimeInForce,
+		exchange.TimeInForceIOC: TimeInForceIOC,
+		exchange.TimeInForceFOK: TimeInForceFOK,
+	}
+
 	Status2ExStatus = map[string]exchange.OrderStatus{
-		"NEW":               exchange.OrderStatusOpen,
-		"PARTIALLY_FILLED ": exchange.OrderStatusOpen,
-		"FILLED":            exchange.OrderStatusDone,
-		"CANCELED":          exchange.OrderStatusCancel,
-		"REJECTED":          exchange.OrderStatusFailed,
-		"EXPIRED":           exchange.OrderStatusFailed,
+		"NEW":              exchange.OrderStatusOpen,
+		"PARTIALLY_FILLED": exchange.OrderStatusOpen,
+		"FILLED":           exchange.OrderStatusDone,
+		"CANCELED":         exchange.OrderStatusCancel,
+		"REJECTED":         exchange.OrderStatusFailed,
+		"EXPIRED":          exchange.OrderStatusFailed,
 	}
 )
 
@@ -114,6 +126,11 @@ func (req *AddOrderReq) Quantity(q decimal.Decimal) *AddOrderReq {
 	return req
 }
 
+func (req *AddOrderReq) NewClientOrderID(id string) *AddOrderReq {
+	req.AddFields("newClientOrderId", id)
+	return req
+}
+
 func (cl *RestClient) AddOrder(ctx context.Context, req *AddOrderReq) (*OrderResp, error) {
 	values, err := req.Values()
 	if err != nil {
@@ -141,6 +158,15 @@ func (r *OrderReq) OrderID(id int64) *OrderReq {
 	return r
 }
 
+func (cl *RestClient) GetOpenOrders(ctx context.Context, req *OrderReq) ([]OrderResp, error) {
+	var ret []OrderResp
+	if err := cl.GetRequest(ctx, OpenOrdersEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get open orders fail")
+	}
+
+	return ret, nil
+}
+
 func (cl *RestClient) GetOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
 	var ret OrderResp
 	if err := cl.GetRequest(ctx, OrderEndPoint, req, true, &ret); err != nil {
@@ -165,7 +191,11 @@ func (cl *RestClient) DeleteOrder(ctx context.Context, req *OrderReq) (*OrderRes
 }
 
 func (resp *OrderResp) Transfer() (*exchange.Order, error) {
-	symbol, err := ParseSymbol(resp.Symbol)
+	return resp.transfer(getDefaultClient())
+}
+
+func (resp *OrderResp) transfer(rc *RestClient) (*exchange.Order, error) {
+	symbol, err := rc.ParseSymbol(resp.Symbol)
 	if err != nil {
 		return nil, errors.WithMessage(err, "parse symbol fail")
 	}
@@ -216,6 +246,7 @@ func (resp *OrderResp) Transfer() (*exchange.Order, error) {
 
 	return &exchange.Order{
 		ID:       exchange.NewIntID(resp.OrderID),
+		ClientID: exchange.NewStrID(resp.ClientOrderID),
 		Symbol:   symbol,
 		Amount:   resp.OrigQty,
 		Price:    resp.Price,
@@ -229,7 +260,11 @@ func (resp *OrderResp) Transfer() (*exchange.Order, error) {
 	}, nil
 }
 
-func (cl *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest) (*exchange.Order, error) {
+func (cl *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
 	if cl.side == nil {
 		return nil, errors.Errorf("positionSide not init")
 	}
@@ -284,20 +319,43 @@ func (cl *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 		}
 	}
 
+	tif := TimeInForce
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			if t.PostOnly {
+				tif = TimeInForceGTX
+			}
+
+		case *exchange.TimeInForceOption:
+			v, ok := ExTimeInForce2TimeInForce[t.Flag]
+			if !ok {
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
+			}
+			tif = v
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
 	or := NewAddOrderReq(req.Symbol.String(), side, typ)
 	if typ == OrderTypeLimit {
 		or.Price(req.Price)
-		or.TimeInForce(TimeInForce)
+		or.TimeInForce(tif)
 	}
 	or.Quantity(req.Amount)
 	or.PositionSide(positionSide)
+	if req.ClientID != nil {
+		or.NewClientOrderID(req.ClientID.String())
+	}
 
 	resp, err := cl.AddOrder(ctx, or)
 	if err != nil {
 		return nil, errors.WithMessage(err, "add order fail")
 	}
 
-	ret, err := resp.Transfer()
+	ret, err := resp.transfer(cl)
 	if err != nil {
 		return nil, errors.WithMessage(err, "transfer order fail")
 	}
@@ -316,7 +374,7 @@ func (cl *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*e
 		return nil, errors.WithMessagef(err, "get order fail ID=%s", order.ID.String())
 	}
 
-	return resp.Transfer()
+	return resp.transfer(cl)
 }
 
 func (cl *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
@@ -331,6 +389,22 @@ func (cl *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*
 		return nil, errors.WithMessagef(err, "get order fail ID=%s", order.ID.String())
 	}
 
-	return resp.Transfer()
+	return resp.transfer(cl)
+}
+
+func (cl *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	resp, err := cl.GetOpenOrders(ctx, NewOrderReq(symbol.String()))
+	if err != nil {
+		return nil, err
+	}
 
+	ret := make([]*exchange.Order, len(resp))
+	for i := range resp {
+		o, err := resp[i].transfer(cl)
+		if err != nil {
+			return nil, errors.WithMessage(err, "transfer order fail")
+		}
+		ret[i] = o
+	}
+	return ret, nil
 }
@@ -0,0 +1,55 @@
+package swap
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+)
+
+const (
+	BucketWeight    = "weight"
+	BucketOrders    = "orders"
+	BucketOrdersMin = "orders_min"
+)
+
+var (
+	//weight limit is per-IP, shared by all swap clients of the same host
+	ipWeightBuckets = ratelimit.NewSharedBuckets(BucketWeight, 2400, time.Minute)
+)
+
+//NewRateLimiter return the default usd-m futures rate limiter of the
+//mainnet host
+func NewRateLimiter() *ratelimit.Limiter {
+	return NewHostRateLimiter(SwapAPIHost)
+}
+
+//NewHostRateLimiter return the usd-m futures rate limiter of host. the
+//weight bucket is shared by all swap clients of host and synced with
+//X-MBX-USED-WEIGHT-1M header while the order buckets are per-account
+func NewHostRateLimiter(host string) *ratelimit.Limiter {
+	weight := func(w int) ratelimit.Cost {
+		return ratelimit.Cost{Bucket: BucketWeight, Weight: w}
+	}
+	order := []ratelimit.Cost{
+		weight(1),
+		{Bucket: BucketOrders, Weight: 1},
+		{Bucket: BucketOrdersMin, Weight: 1},
+	}
+
+	return ratelimit.NewLimiter(ratelimit.PolicyBlock,
+		ipWeightBuckets.Get(host),
+		ratelimit.NewBucket(BucketOrders, 300, 10*time.Second),
+		ratelimit.NewBucket(BucketOrdersMin, 1200, time.Minute),
+	).SetDefault(weight(1)).
+		SetWeight(http.MethodGet, "/fapi/v1/exchangeInfo", weight(1)).
+		SetWeight(http.MethodGet, AccountEndPoint, weight(5)).
+		SetWeight(http.MethodGet, UserTradesEndPoint, weight(5)).
+		SetWeight(http.MethodGet, IncomeEndPoint, weight(30)).
+		SetWeight(http.MethodGet, CommiionRateEndPoint, weight(20)).
+		SetWeight(http.MethodGet, OpenOrdersEndPoint, weight(1)).
+		SetWeight(http.MethodPost, OrderEndPoint, order...).
+		SetUsedHeader("X-MBX-USED-WEIGHT-1M", BucketWeight).
+		SetUsedHeader("X-MBX-ORDER-COUNT-10S", BucketOrders).
+		SetUsedHeader("X-MBX-ORDER-COUNT-1M", BucketOrdersMin)
+}
@@ -0,0 +1,48 @@
+package swap
+
+import (
+	"context"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
+	"github.com/pkg/errors"
+)
+
+const (
+	ExchangeName = "binance.swap"
+)
+
+func init() {
+	exchange.Register(&exchange.Registration{
+		Name: ExchangeName,
+		NewRestClient: func(cfg *exchange.Config) (interface{}, error) {
+			return newConfigRestClient(cfg), nil
+		},
+		NewWSClient: func(cfg *exchange.Config, data chan interface{}) (exchange.WSConn, error) {
+			if cfg.TestNet {
+				return nil, errors.Errorf("%s testnet websocket is not supported", ExchangeName)
+			}
+			return NewWSClient(data), nil
+		},
+		LoadSymbols: func(ctx context.Context, cfg *exchange.Config) error {
+			return InitWithClient(ctx, newConfigRestClient(cfg))
+		},
+		ParseSymbol: func(symbol string) (exchange.Symbol, error) {
+			return ParseSymbol(symbol)
+		},
+		NewSymbolStore: func(cfg *exchange.Config) (*exchange.SymbolStore, error) {
+			return newConfigRestClient(cfg).NewSymbolStore(), nil
+		},
+		Capabilities: exchange.CapabilityTrader | exchange.CapabilityPublicStream | exchange.CapabilityTestNet |
+			exchange.CapabilityTrades | exchange.CapabilityFinance,
+	})
+}
+
+//newConfigRestClient return rest client of cfg which honor TestNet, BaseURL
+//and HTTPClient
+func newConfigRestClient(cfg *exchange.Config) *RestClient {
+	if cfg.TestNet {
+		return NewTestRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...)
+	}
+	return NewRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...)
+}
@@ -0,0 +1,618 @@
+package simulator
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	//engine in-memory matching engine and account of the simulator
+	engine struct {
+		mu        sync.Mutex
+		cfg       *Config
+		symbols   map[string]*SymbolConfig
+		books     map[string]*book
+		orders    []*order
+		trades    []*trade
+		incomes   []*income
+		balances  map[string]decimal.Decimal
+		positions map[string]*position
+		dualSide  bool
+		orderID   int64
+		tradeID   int64
+		tranID    int64
+		updateID  int64
+	}
+
+	//book best bid and ask of a symbol. the quantity is consumed by taker
+	//orders until the next update
+	book struct {
+		Bid      decimal.Decimal
+		BidQty   decimal.Decimal
+		Ask      decimal.Decimal
+		AskQty   decimal.Decimal
+		UpdateID int64
+		Time     int64
+	}
+
+	orderParam struct {
+		Symbol        string
+		Side          string
+		PositionSide  string
+		Type          string
+		TimeInForce   string
+		Price         decimal.Decimal
+		Quantity      decimal.Decimal
+		ClientOrderID string
+	}
+
+	order struct {
+		ClientOrderID string          `json:"clientOrderId"`
+		CumQty        decimal.Decimal `json:"cumQty"`
+		CumQuote      decimal.Decimal `json:"cumQuote"`
+		ExecutedQty   decimal.Decimal `json:"executedQty"`
+		OrderID       int64           `json:"orderId"`
+		AvgPrice      decimal.Decimal `json:"avgPrice"`
+		OrigQty       decimal.Decimal `json:"origQty"`
+		Price         decimal.Decimal `json:"price"`
+		ReduceOnly    bool            `json:"reduceOnly"`
+		Side          string          `json:"side"`
+		PositionSide  string          `json:"positionSide"`
+		Status        string          `json:"status"`
+		StopPrice     decimal.Decimal `json:"stopPrice"`
+		ClosePosition bool            `json:"closePosition"`
+		Symbol        string          `json:"symbol"`
+		TimeInForce   string          `json:"timeInForce"`
+		Type          string          `json:"type"`
+		OrigType      string          `json:"origType"`
+		Time          int64           `json:"time"`
+		UpdateTime    int64           `json:"updateTime"`
+		WorkingType   string          `json:"workingType"`
+		PriceProtect  bool            `json:"priceProtect"`
+	}
+
+	trade struct {
+		Buyer           bool            `json:"buyer"`
+		Commission      decimal.Decimal `json:"commission"`
+		CommissionAsset string          `json:"commissionAsset"`
+		ID              int64           `json:"id"`
+		Maker           bool            `json:"maker"`
+		OrderID         int64           `json:"orderId"`
+		Price           decimal.Decimal `json:"price"`
+		Qty             decimal.Decimal `json:"qty"`
+		QuoteQty        decimal.Decimal `json:"quoteQty"`
+		RealizedPnl     decimal.Decimal `json:"realizedPnl"`
+		Side            string          `json:"side"`
+		PositionSide    string          `json:"positionSide"`
+		Symbol          string          `json:"symbol"`
+		Time            int64           `json:"time"`
+	}
+
+	income struct {
+		Symbol     string          `json:"symbol"`
+		IncomeType string          `json:"incomeType"`
+		Income     decimal.Decimal `json:"income"`
+		Asset      string          `json:"asset"`
+		Info       string          `json:"info"`
+		Time       int64           `json:"time"`
+		TranID     int64           `json:"tranId"`
+		TradeID    string          `json:"tradeId"`
+	}
+
+	position struct {
+		Symbol       string
+		PositionSide string
+		Amt          decimal.Decimal
+		EntryPrice   decimal.Decimal
+		UpdateTime   int64
+	}
+)
+
+const (
+	sideBuy  = "BUY"
+	sideSell = "SELL"
+
+	positionSideBoth  = "BOTH"
+	positionSideLong  = "LONG"
+	positionSideShort = "SHORT"
+
+	typeLimit  = "LIMIT"
+	typeMarket = "MARKET"
+
+	tifGTC = "GTC"
+	tifIOC = "IOC"
+	tifFOK = "FOK"
+	tifGTX = "GTX"
+
+	statusNew             = "NEW"
+	statusPartiallyFilled = "PARTIALLY_FILLED"
+	statusFilled          = "FILLED"
+	statusCanceled        = "CANCELED"
+	statusExpired         = "EXPIRED"
+
+	incomeRealizedPnl = "REALIZED_PNL"
+	incomeCommission  = "COMMISSION"
+	incomeFundingFee  = "FUNDING_FEE"
+)
+
+func newEngine(cfg *Config) *engine {
+	ret := &engine{
+		cfg:       cfg,
+		symbols:   map[string]*SymbolConfig{},
+		books:     map[string]*book{},
+		balances:  map[string]decimal.Decimal{},
+		positions: map[string]*position{},
+		dualSide:  cfg.DualSidePosition,
+		orderID:   8389765000000000000,
+	}
+	for i := range cfg.Symbols {
+		sc := &cfg.Symbols[i]
+		ret.symbols[sc.Symbol] = sc
+	}
+	for asset, balance := range cfg.Balances {
+		ret.balances[asset] = balance
+	}
+	return ret
+}
+
+func (e *engine) now() int64 {
+	return e.cfg.Now().UnixNano() / 1e6
+}
+
+//newOrder validate and place the order, taker part is matched immediately
+func (e *engine) newOrder(p *orderParam) (*order, *apiError) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sc, ok := e.symbols[p.Symbol]
+	if !ok {
+		return nil, &apiError{Status: http.StatusBadRequest, Code: -1121, Message: "Invalid symbol."}
+	}
+	if p.Side != sideBuy && p.Side != sideSell {
+		return nil, &apiError{Status: http.StatusBadRequest, Code: -1117, Message: "Invalid side."}
+	}
+	if p.Type != typeLimit && p.Type != typeMarket {
+		return nil, &apiError{Status: http.StatusBadRequest, Code: -1116, Message: "Invalid orderType."}
+	}
+	if p.PositionSide == "" {
+		p.PositionSide = positionSideBoth
+	}
+	if e.dualSide == (p.PositionSide == positionSideBoth) {
+		return nil, &apiError{Status: http.StatusBadRequest, Code: -4061, Message: "Order's position side does not match user's setting."}
+	}
+
+	if ae := e.validate(sc, p); ae != nil {
+		return nil, ae
+	}
+
+	now := e.now()
+	e.orderID++
+	o := &order{
+		ClientOrderID: p.ClientOrderID,
+		OrderID:       e.orderID,
+		OrigQty:       p.Quantity,
+		Price:         p.Price,
+		Side:          p.Side,
+		PositionSide:  p.PositionSide,
+		Status:        statusNew,
+		Symbol:        p.Symbol,
+		TimeInForce:   p.TimeInForce,
+		Type:          p.Type,
+		OrigType:      p.Type,
+		Time:          now,
+		UpdateTime:    now,
+		WorkingType:   "CONTRACT_PRICE",
+	}
+	if o.ClientOrderID == "" {
+		o.ClientOrderID = fmt.Sprintf("sim_%d", o.OrderID)
+	}
+	if o.Type == typeMarket {
+		o.TimeInForce = tifGTC
+	}
+
+	bk := e.books[o.Symbol]
+	price, qty, cross := o.cross(bk)
+	switch {
+	case cross && o.TimeInForce == tifGTX:
+		return nil, &apiError{Status: http.StatusBadRequest, Code: -5022,
+			Message: "Due to the order could not be executed as maker, the Post Only order will be rejected. The order will not be recorded in the order history"}
+
+	case o.TimeInForce == tifFOK && (!cross || qty.LessThan(o.OrigQty)):
+		o.Status = statusExpired
+
+	case cross:
+		e.fill(o, bk, price, qty, false)
+	}
+
+	if o.Status == statusNew || o.Status == statusPartiallyFilled {
+		if o.Type == typeMarket || o.TimeInForce == tifIOC {
+			o.Status = statusExpired
+		}
+	}
+	e.orders = append(e.orders, o)
+	ret := *o
+	return &ret, nil
+}
+
+func (e *engine) validate(sc *SymbolConfig, p *orderParam) *apiError {
+	if !p.Quantity.IsPositive() {
+		return newParamError("quantity", "")
+	}
+	if sc.StepSize.IsPositive() && !p.Quantity.Mod(sc.StepSize).IsZero() {
+		return &apiError{Status: http.StatusBadRequest, Code: -1111, Message: "Precision is over the maximum defined for this asset."}
+	}
+	if p.Quantity.LessThan(sc.MinQty) || sc.MaxQty.IsPositive() && p.Quantity.GreaterThan(sc.MaxQty) {
+		return &apiError{Status: http.StatusBadRequest, Code: -1013, Message: "Filter failure: LOT_SIZE"}
+	}
+
+	price := p.Price
+	if p.Type == typeLimit {
+		if !p.Price.IsPositive() {
+			return newParamError("price", "")
+		}
+		switch p.TimeInForce {
+		case tifGTC, tifIOC, tifFOK, tifGTX:
+		case "":
+			return newParamError("timeInForce", "")
+		default:
+			return &apiError{Status: http.StatusBadRequest, Code: -1115, Message: "Invalid timeInForce."}
+		}
+		if sc.TickSize.IsPositive() && !p.Price.Mod(sc.TickSize).IsZero() {
+			return &apiError{Status: http.StatusBadRequest, Code: -4014, Message: "Price not increased by tick size."}
+		}
+	} else {
+		bk, ok := e.books[p.Symbol]
+		if !ok {
+			return &apiError{Status: http.StatusBadRequest, Code: -2010, Message: "Order would immediately trigger."}
+		}
+		price = bk.Ask
+		if p.Side == sideSell {
+			price = bk.Bid
+		}
+	}
+
+	notional := price.Mul(p.Quantity)
+	reduce, ok := e.reduce(p)
+	if !ok {
+		return &apiError{Status: http.StatusBadRequest, Code: -2022, Message: "ReduceOnly Order is rejected."}
+	}
+	if reduce {
+		return nil
+	}
+	if notional.LessThan(sc.MinNotional) {
+		return &apiError{Status: http.StatusBadRequest, Code: -4164,
+			Message: fmt.Sprintf("Order's notional must be no smaller than %s (unless you choose reduce only)", sc.MinNotional)}
+	}
+
+	required := notional.Div(decimal.NewFromInt(e.cfg.Leverage)).Add(notional.Mul(e.cfg.TakerFee))
+	if e.available(sc.QuoteAsset).LessThan(required) {
+		return &apiError{Status: http.StatusBadRequest, Code: -2019, Message: "Margin is insufficient."}
+	}
+	return nil
+}
+
+//reduce report whether the order only reduce the position. in dual side
+//position mode closing order larger than the position is invalid
+func (e *engine) reduce(p *orderParam) (reduce bool, valid bool) {
+	pos := e.positions[positionKey(p.Symbol, p.PositionSide)]
+	amt := decimal.Zero
+	if pos != nil {
+		amt = pos.Amt
+	}
+
+	switch {
+	case p.PositionSide == positionSideLong && p.Side == sideSell:
+		return true, p.Quantity.LessThanOrEqual(amt)
+	case p.PositionSide == positionSideShort && p.Side == sideBuy:
+		return true, p.Quantity.LessThanOrEqual(amt.Neg())
+	case p.PositionSide == positionSideBoth:
+		delta := signed(p.Side, p.Quantity)
+		return !amt.IsZero() && amt.Sign() != delta.Sign() && delta.Abs().LessThanOrEqual(amt.Abs()), true
+	}
+	return false, true
+}
+
+//cross return the price and quantity the order can take from the book
+func (o *order) cross(bk *book) (decimal.Decimal, decimal.Decimal, bool) {
+	if bk == nil {
+		return decimal.Zero, decimal.Zero, false
+	}
+
+	remain := o.OrigQty.Sub(o.ExecutedQty)
+	if o.Side == sideBuy {
+		if !bk.AskQty.IsPositive() || o.Type == typeLimit && o.Price.LessThan(bk.Ask) {
+			return decimal.Zero, decimal.Zero, false
+		}
+		return bk.Ask, decimal.Min(remain, bk.AskQty), true
+	}
+
+	if !bk.BidQty.IsPositive() || o.Type == typeLimit && o.Price.GreaterThan(bk.Bid) {
+		return decimal.Zero, decimal.Zero, false
+	}
+	return bk.Bid, decimal.Min(remain, bk.BidQty), true
+}
+
+//fill execute qty of order at price, update position, balance, trades and
+//incomes. the liquidity is consumed from bk
+func (e *engine) fill(o *order, bk *book, price decimal.Decimal, qty decimal.Decimal, maker bool) {
+	now := e.now()
+	sc := e.symbols[o.Symbol]
+	quote := price.Mul(qty)
+
+	if o.Side == sideBuy {
+		bk.AskQty = bk.AskQty.Sub(qty)
+	} else {
+		bk.BidQty = bk.BidQty.Sub(qty)
+	}
+
+	o.ExecutedQty = o.ExecutedQty.Add(qty)
+	o.CumQty = o.ExecutedQty
+	o.CumQuote = o.CumQuote.Add(quote)
+	o.AvgPrice = o.CumQuote.Div(o.ExecutedQty)
+	o.UpdateTime = now
+	if o.ExecutedQty.Equal(o.OrigQty) {
+		o.Status = statusFilled
+	} else {
+		o.Status = statusPartiallyFilled
+	}
+
+	rate := e.cfg.TakerFee
+	if maker {
+		rate = e.cfg.MakerFee
+	}
+	fee := quote.Mul(rate)
+	pnl := e.updatePosition(o.Symbol, o.PositionSide, signed(o.Side, qty), price, now)
+	e.balances[sc.QuoteAsset] = e.balances[sc.QuoteAsset].Add(pnl).Sub(fee)
+
+	e.tradeID++
+	t := &trade{
+		Buyer:           o.Side == sideBuy,
+		Commission:      fee,
+		CommissionAsset: sc.QuoteAsset,
+		ID:              e.tradeID,
+		Maker:           maker,
+		OrderID:         o.OrderID,
+		Price:           price,
+		Qty:             qty,
+		QuoteQty:        quote,
+		RealizedPnl:     pnl,
+		Side:            o.Side,
+		PositionSide:    o.PositionSide,
+		Symbol:          o.Symbol,
+		Time:            now,
+	}
+	e.trades = append(e.trades, t)
+
+	tradeID := strconv.FormatInt(t.ID, 10)
+	if !pnl.IsZero() {
+		e.addIncome(o.Symbol, incomeRealizedPnl, pnl, sc.QuoteAsset, tradeID, now)
+	}
+	if !fee.IsZero() {
+		e.addIncome(o.Symbol, incomeCommission, fee.Neg(), sc.QuoteAsset, tradeID, now)
+	}
+}
+
+//updatePosition apply signed delta at price and return the realized pnl
+func (e *engine) updatePosition(symbol string, side string, delta decimal.Decimal, price decimal.Decimal, now int64) decimal.Decimal {
+	key := positionKey(symbol, side)
+	pos, ok := e.positions[key]
+	if !ok {
+		pos = &position{Symbol: symbol, PositionSide: side}
+		e.positions[key] = pos
+	}
+	pos.UpdateTime = now
+
+	pnl := decimal.Zero
+	if pos.Amt.IsZero() || pos.Amt.Sign() == delta.Sign() {
+		total := pos.Amt.Add(delta)
+		pos.EntryPrice = pos.EntryPrice.Mul(pos.Amt.Abs()).Add(price.Mul(delta.Abs())).Div(total.Abs())
+		pos.Amt = total
+		return pnl
+	}
+
+	closed := decimal.Min(pos.Amt.Abs(), delta.Abs())
+	pnl = price.Sub(pos.EntryPrice).Mul(closed)
+	if pos.Amt.IsNegative() {
+		pnl = pnl.Neg()
+	}
+	pos.Amt = pos.Amt.Add(delta)
+	if pos.Amt.IsZero() {
+		pos.EntryPrice = decimal.Zero
+	} else if pos.Amt.Sign() == delta.Sign() {
+		pos.EntryPrice = price
+	}
+	return pnl
+}
+
+func (e *engine) addIncome(symbol string, typ string, amount decimal.Decimal, asset string, tradeID string, now int64) {
+	e.tranID++
+	e.incomes = append(e.incomes, &income{
+		Symbol:     symbol,
+		IncomeType: typ,
+		Income:     amount,
+		Asset:      asset,
+		Time:       now,
+		TranID:     e.tranID,
+		TradeID:    tradeID,
+	})
+}
+
+//setBook update the best bid and ask of symbol and fill resting orders which
+//are crossed by the book
+func (e *engine) setBook(symbol string, bid, bidQty, ask, askQty decimal.Decimal) (*book, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.symbols[symbol]; !ok {
+		return nil, errors.Errorf("unknown symbol %s", symbol)
+	}
+
+	e.updateID++
+	bk := &book{
+		Bid:      bid,
+		BidQty:   bidQty,
+		Ask:      ask,
+		AskQty:   askQty,
+		UpdateID: e.updateID,
+		Time:     e.now(),
+	}
+	e.books[symbol] = bk
+	ret := *bk
+
+	open := e.openOrders(symbol)
+	//best price first then time priority
+	sort.SliceStable(open, func(i, j int) bool {
+		if open[i].Side != open[j].Side {
+			return open[i].Side < open[j].Side
+		}
+		if open[i].Side == sideBuy {
+			return open[i].Price.GreaterThan(open[j].Price)
+		}
+		return open[i].Price.LessThan(open[j].Price)
+	})
+	for _, o := range open {
+		if _, qty, ok := o.cross(bk); ok && qty.IsPositive() {
+			e.fill(o, bk, o.Price, qty, true)
+		}
+	}
+	return &ret, nil
+}
+
+func (e *engine) openOrders(symbol string) []*order {
+	var ret []*order
+	for _, o := range e.orders {
+		if o.Status != statusNew && o.Status != statusPartiallyFilled {
+			continue
+		}
+		if symbol != "" && o.Symbol != symbol {
+			continue
+		}
+		ret = append(ret, o)
+	}
+	return ret
+}
+
+func (e *engine) findOrder(symbol string, id int64, clientID string) *order {
+	for _, o := range e.orders {
+		if o.Symbol != symbol {
+			continue
+		}
+		if id != 0 && o.OrderID == id || id == 0 && clientID != "" && o.ClientOrderID == clientID {
+			return o
+		}
+	}
+	return nil
+}
+
+func (e *engine) getOrder(symbol string, id int64, clientID string) (*order, *apiError) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o := e.findOrder(symbol, id, clientID)
+	if o == nil {
+		return nil, &apiError{Status: http.StatusBadRequest, Code: -2013, Message: "Order does not exist."}
+	}
+	ret := *o
+	return &ret, nil
+}
+
+func (e *engine) cancelOrder(symbol string, id int64, clientID string) (*order, *apiError) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o := e.findOrder(symbol, id, clientID)
+	if o == nil || o.Status != statusNew && o.Status != statusPartiallyFilled {
+		return nil, &apiError{Status: http.StatusBadRequest, Code: -2011, Message: "Unknown order sent."}
+	}
+	o.Status = statusCanceled
+	o.UpdateTime = e.now()
+	ret := *o
+	return &ret, nil
+}
+
+//settleFunding charge funding fee of symbol positions at mark price
+func (e *engine) settleFunding(symbol string, rate decimal.Decimal) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sc, ok := e.symbols[symbol]
+	if !ok {
+		return errors.Errorf("unknown symbol %s", symbol)
+	}
+	now := e.now()
+	for _, pos := range e.sortedPositions() {
+		if pos.Symbol != symbol || pos.Amt.IsZero() {
+			continue
+		}
+		fee := pos.Amt.Mul(e.markPrice(pos)).Mul(rate).Neg()
+		e.balances[sc.QuoteAsset] = e.balances[sc.QuoteAsset].Add(fee)
+		e.addIncome(symbol, incomeFundingFee, fee, sc.QuoteAsset, "", now)
+	}
+	return nil
+}
+
+//markPrice mid price of the book, entry price if there is no book
+func (e *engine) markPrice(pos *position) decimal.Decimal {
+	bk, ok := e.books[pos.Symbol]
+	if !ok {
+		return pos.EntryPrice
+	}
+	return bk.Bid.Add(bk.Ask).Div(decimal.NewFromInt(2))
+}
+
+func (e *engine) unrealized(pos *position) decimal.Decimal {
+	return e.markPrice(pos).Sub(pos.EntryPrice).Mul(pos.Amt)
+}
+
+func (e *engine) positionMargin(pos *position) decimal.Decimal {
+	return pos.Amt.Abs().Mul(pos.EntryPrice).Div(decimal.NewFromInt(e.cfg.Leverage))
+}
+
+func (e *engine) orderMargin(o *order) decimal.Decimal {
+	return o.OrigQty.Sub(o.ExecutedQty).Mul(o.Price).Div(decimal.NewFromInt(e.cfg.Leverage))
+}
+
+//available balance of asset which can be used to open new positions
+func (e *engine) available(asset string) decimal.Decimal {
+	ret := e.balances[asset]
+	for _, pos := range e.positions {
+		if e.symbols[pos.Symbol].QuoteAsset != asset {
+			continue
+		}
+		ret = ret.Add(decimal.Min(e.unrealized(pos), decimal.Zero)).Sub(e.positionMargin(pos))
+	}
+	for _, o := range e.openOrders("") {
+		if e.symbols[o.Symbol].QuoteAsset == asset {
+			ret = ret.Sub(e.orderMargin(o))
+		}
+	}
+	return ret
+}
+
+func (e *engine) sortedPositions() []*position {
+	ret := make([]*position, 0, len(e.positions))
+	for _, pos := range e.positions {
+		ret = append(ret, pos)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return positionKey(ret[i].Symbol, ret[i].PositionSide) < positionKey(ret[j].Symbol, ret[j].PositionSide)
+	})
+	return ret
+}
+
+func positionKey(symbol string, side string) string {
+	return fmt.Sprintf("%s|%s", symbol, side)
+}
+
+func signed(side string, qty decimal.Decimal) decimal.Decimal {
+	if side == sideSell {
+		return qty.Neg()
+	}
+	return qty
+}
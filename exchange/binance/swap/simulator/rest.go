@@ -0,0 +1,407 @@
+package simulator
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	exchangeInfo struct {
+		Timezone   string       `json:"timezone"`
+		ServerTime int64        `json:"serverTime"`
+		Symbols    []symbolInfo `json:"symbols"`
+	}
+
+	symbolInfo struct {
+		Symbol            string                   `json:"symbol"`
+		Pair              string                   `json:"pair"`
+		ContractType      string                   `json:"contractType"`
+		Status            string                   `json:"status"`
+		BaseAsset         string                   `json:"baseAsset"`
+		QuoteAsset        string                   `json:"quoteAsset"`
+		MarginAsset       string                   `json:"marginAsset"`
+		PricePrecision    int32                    `json:"pricePrecision"`
+		QuantityPrecision int32                    `json:"quantityPrecision"`
+		Filters           []map[string]interface{} `json:"filters"`
+		OrderTypes        []string                 `json:"orderTypes"`
+		TimeInForce       []string                 `json:"timeInForce"`
+	}
+
+	accountAsset struct {
+		Asset                  string          `json:"asset"`
+		WalletBalance          decimal.Decimal `json:"walletBalance"`
+		UnrealizedProfit       decimal.Decimal `json:"unrealizedProfit"`
+		MarginBalance          decimal.Decimal `json:"marginBalance"`
+		MaintMargin            decimal.Decimal `json:"maintMargin"`
+		InitialMargin          decimal.Decimal `json:"initialMargin"`
+		PositionInitialMargin  decimal.Decimal `json:"positionInitialMargin"`
+		OpenOrderInitialMargin decimal.Decimal `json:"openOrderInitialMargin"`
+		CrossWalletBalance     decimal.Decimal `json:"crossWalletBalance"`
+		CrossUnPNL             decimal.Decimal `json:"crossUnPnl"`
+		AvailableBalance       decimal.Decimal `json:"availableBalance"`
+		MaxWithdrawAmount      decimal.Decimal `json:"maxWithdrawAmount"`
+		MarginAvailable        bool            `json:"marginAvailable"`
+		UpdateTime             int64           `json:"updateTime"`
+	}
+
+	accountPosition struct {
+		Symbol                 string          `json:"symbol"`
+		InitialMargin          decimal.Decimal `json:"initialMargin"`
+		MaintMargin            decimal.Decimal `json:"maintMargin"`
+		UnrealizedProfit       decimal.Decimal `json:"unrealizedProfit"`
+		PositionInitialMargin  decimal.Decimal `json:"positionInitialMargin"`
+		OpenOrderInitialMargin decimal.Decimal `json:"openOrderInitialMargin"`
+		Leverage               string          `json:"leverage"`
+		Isolated               bool            `json:"isolated"`
+		EntryPrice             decimal.Decimal `json:"entryPrice"`
+		MaxNotional            decimal.Decimal `json:"maxNotional"`
+		PositionSide           string          `json:"positionSide"`
+		PositionAmt            decimal.Decimal `json:"positionAmt"`
+		UpdateTime             int64           `json:"updateTime"`
+	}
+
+	account struct {
+		FeeTier                     int               `json:"feeTier"`
+		CanTrade                    bool              `json:"canTrade"`
+		CanDeposit                  bool              `json:"canDeposit"`
+		CanWithdraw                 bool              `json:"canWithdraw"`
+		UpdateTime                  int64             `json:"updateTime"`
+		TotalInitialMargin          decimal.Decimal   `json:"totalInitialMargin"`
+		TotalMaintMargin            decimal.Decimal   `json:"totalMaintMargin"`
+		TotalWalletBalance          decimal.Decimal   `json:"totalWalletBalance"`
+		TotalUnrealizedProfit       decimal.Decimal   `json:"totalUnrealizedProfit"`
+		TotalMarginBalance          decimal.Decimal   `json:"totalMarginBalance"`
+		TotalPositionInitialMargin  decimal.Decimal   `json:"totalPositionInitialMargin"`
+		TotalOpenOrderInitialMargin decimal.Decimal   `json:"totalOpenOrderInitialMargin"`
+		TotalCrossWalletBalance     decimal.Decimal   `json:"totalCrossWalletBalance"`
+		TotalCrossUnPnl             decimal.Decimal   `json:"totalCrossUnPnl"`
+		AvailableBalance            decimal.Decimal   `json:"availableBalance"`
+		MaxWithdrawAmount           decimal.Decimal   `json:"maxWithdrawAmount"`
+		Assets                      []accountAsset    `json:"assets"`
+		Positions                   []accountPosition `json:"positions"`
+	}
+
+	positionSideResp struct {
+		DualSidePosition bool `json:"dualSidePosition"`
+	}
+
+	codeResp struct {
+		Code    int    `json:"code"`
+		Message string `json:"msg"`
+	}
+)
+
+const (
+	defaultTradeLimit  = 500
+	maxTradeLimit      = 1000
+	defaultIncomeLimit = 100
+	maxIncomeLimit     = 1000
+)
+
+func (s *Server) exchangeInfo(r *http.Request) (interface{}, *apiError) {
+	ret := &exchangeInfo{
+		Timezone:   "UTC",
+		ServerTime: s.engine.now(),
+	}
+	for _, sc := range s.cfg.Symbols {
+		ret.Symbols = append(ret.Symbols, symbolInfo{
+			Symbol:            sc.Symbol,
+			Pair:              sc.Symbol,
+			ContractType:      "PERPETUAL",
+			Status:            "TRADING",
+			BaseAsset:         sc.BaseAsset,
+			QuoteAsset:        sc.QuoteAsset,
+			MarginAsset:       sc.QuoteAsset,
+			PricePrecision:    -sc.TickSize.Exponent(),
+			QuantityPrecision: -sc.StepSize.Exponent(),
+			Filters: []map[string]interface{}{
+				{"filterType": "PRICE_FILTER", "tickSize": sc.TickSize, "minPrice": sc.TickSize, "maxPrice": "1000000"},
+				{"filterType": "LOT_SIZE", "stepSize": sc.StepSize, "minQty": sc.MinQty, "maxQty": sc.MaxQty},
+				{"filterType": "MIN_NOTIONAL", "notional": sc.MinNotional},
+			},
+			OrderTypes:  []string{typeLimit, typeMarket},
+			TimeInForce: []string{tifGTC, tifIOC, tifFOK, tifGTX},
+		})
+	}
+	return ret, nil
+}
+
+func (s *Server) getPositionSide(r *http.Request) (interface{}, *apiError) {
+	s.engine.mu.Lock()
+	defer s.engine.mu.Unlock()
+	return &positionSideResp{DualSidePosition: s.engine.dualSide}, nil
+}
+
+func (s *Server) setPositionSide(r *http.Request) (interface{}, *apiError) {
+	dual, err := strconv.ParseBool(r.Form.Get("dualSidePosition"))
+	if err != nil {
+		return nil, newParamError("dualSidePosition", "")
+	}
+
+	e := s.engine
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if dual == e.dualSide {
+		return nil, &apiError{Status: http.StatusBadRequest, Code: -4059, Message: "No need to change position side."}
+	}
+	for _, pos := range e.positions {
+		if !pos.Amt.IsZero() {
+			return nil, &apiError{Status: http.StatusBadRequest, Code: -4068, Message: "Position side cannot be changed if there exists position."}
+		}
+	}
+	if len(e.openOrders("")) != 0 {
+		return nil, &apiError{Status: http.StatusBadRequest, Code: -4067, Message: "Position side cannot be changed if there exists open orders."}
+	}
+	e.dualSide = dual
+	return &codeResp{Code: 200, Message: "success"}, nil
+}
+
+func (s *Server) addOrder(r *http.Request) (interface{}, *apiError) {
+	p := &orderParam{
+		Symbol:        r.Form.Get("symbol"),
+		Side:          r.Form.Get("side"),
+		PositionSide:  r.Form.Get("positionSide"),
+		Type:          r.Form.Get("type"),
+		TimeInForce:   r.Form.Get("timeInForce"),
+		ClientOrderID: r.Form.Get("newClientOrderId"),
+	}
+	for _, name := range []string{"symbol", "side", "type", "quantity"} {
+		if r.Form.Get(name) == "" {
+			return nil, newParamError(name, "")
+		}
+	}
+
+	var err error
+	if p.Quantity, err = decimal.NewFromString(r.Form.Get("quantity")); err != nil {
+		return nil, newParamError("quantity", "")
+	}
+	if prc := r.Form.Get("price"); prc != "" {
+		if p.Price, err = decimal.NewFromString(prc); err != nil {
+			return nil, newParamError("price", "")
+		}
+	}
+	return s.engine.newOrder(p)
+}
+
+func (s *Server) getOrder(r *http.Request) (interface{}, *apiError) {
+	symbol, id, clientID, ae := orderQuery(r)
+	if ae != nil {
+		return nil, ae
+	}
+	return s.engine.getOrder(symbol, id, clientID)
+}
+
+func (s *Server) deleteOrder(r *http.Request) (interface{}, *apiError) {
+	symbol, id, clientID, ae := orderQuery(r)
+	if ae != nil {
+		return nil, ae
+	}
+	return s.engine.cancelOrder(symbol, id, clientID)
+}
+
+func (s *Server) openOrders(r *http.Request) (interface{}, *apiError) {
+	e := s.engine
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ret := []order{}
+	for _, o := range e.openOrders(r.Form.Get("symbol")) {
+		ret = append(ret, *o)
+	}
+	return ret, nil
+}
+
+func (s *Server) userTrades(r *http.Request) (interface{}, *apiError) {
+	symbol := r.Form.Get("symbol")
+	if symbol == "" {
+		return nil, newParamError("symbol", "")
+	}
+	st, et, limit, ae := rangeQuery(r, defaultTradeLimit, maxTradeLimit)
+	if ae != nil {
+		return nil, ae
+	}
+	fromID, _ := strconv.ParseInt(r.Form.Get("fromId"), 10, 64)
+
+	e := s.engine
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ret := []trade{}
+	for _, t := range e.trades {
+		if t.Symbol != symbol || t.ID < fromID || st != 0 && t.Time < st || et != 0 && t.Time > et {
+			continue
+		}
+		ret = append(ret, *t)
+		if len(ret) == limit {
+			break
+		}
+	}
+	return ret, nil
+}
+
+func (s *Server) income(r *http.Request) (interface{}, *apiError) {
+	symbol := r.Form.Get("symbol")
+	typ := r.Form.Get("incomeType")
+	st, et, limit, ae := rangeQuery(r, defaultIncomeLimit, maxIncomeLimit)
+	if ae != nil {
+		return nil, ae
+	}
+
+	e := s.engine
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ret := []income{}
+	for _, ic := range e.incomes {
+		if symbol != "" && ic.Symbol != symbol || typ != "" && ic.IncomeType != typ ||
+			st != 0 && ic.Time < st || et != 0 && ic.Time > et {
+			continue
+		}
+		ret = append(ret, *ic)
+		if len(ret) == limit {
+			break
+		}
+	}
+	return ret, nil
+}
+
+func (s *Server) account(r *http.Request) (interface{}, *apiError) {
+	e := s.engine
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	leverage := strconv.FormatInt(e.cfg.Leverage, 10)
+	ret := &account{
+		CanTrade:   true,
+		UpdateTime: now,
+	}
+
+	assets := map[string]*accountAsset{}
+	for asset, balance := range e.balances {
+		assets[asset] = &accountAsset{
+			Asset:              asset,
+			WalletBalance:      balance,
+			CrossWalletBalance: balance,
+			MarginAvailable:    true,
+			UpdateTime:         now,
+		}
+	}
+
+	sides := []string{positionSideBoth}
+	if e.dualSide {
+		sides = []string{positionSideLong, positionSideShort}
+	}
+	for _, sc := range e.cfg.Symbols {
+		for _, side := range sides {
+			ap := accountPosition{
+				Symbol:       sc.Symbol,
+				Leverage:     leverage,
+				PositionSide: side,
+			}
+			if pos, ok := e.positions[positionKey(sc.Symbol, side)]; ok {
+				ap.UnrealizedProfit = e.unrealized(pos)
+				ap.PositionInitialMargin = e.positionMargin(pos)
+				ap.EntryPrice = pos.EntryPrice
+				ap.PositionAmt = pos.Amt
+				ap.UpdateTime = pos.UpdateTime
+			}
+			for _, o := range e.openOrders(sc.Symbol) {
+				if o.PositionSide == side {
+					ap.OpenOrderInitialMargin = ap.OpenOrderInitialMargin.Add(e.orderMargin(o))
+				}
+			}
+			ap.InitialMargin = ap.PositionInitialMargin.Add(ap.OpenOrderInitialMargin)
+			ret.Positions = append(ret.Positions, ap)
+
+			as, ok := assets[sc.QuoteAsset]
+			if !ok {
+				continue
+			}
+			as.UnrealizedProfit = as.UnrealizedProfit.Add(ap.UnrealizedProfit)
+			as.CrossUnPNL = as.UnrealizedProfit
+			as.PositionInitialMargin = as.PositionInitialMargin.Add(ap.PositionInitialMargin)
+			as.OpenOrderInitialMargin = as.OpenOrderInitialMargin.Add(ap.OpenOrderInitialMargin)
+			as.InitialMargin = as.PositionInitialMargin.Add(as.OpenOrderInitialMargin)
+		}
+	}
+
+	for _, asset := range sortedKeys(assets) {
+		as := assets[asset]
+		as.MarginBalance = as.WalletBalance.Add(as.UnrealizedProfit)
+		as.AvailableBalance = e.available(asset)
+		as.MaxWithdrawAmount = as.AvailableBalance
+		ret.Assets = append(ret.Assets, *as)
+
+		ret.TotalWalletBalance = ret.TotalWalletBalance.Add(as.WalletBalance)
+		ret.TotalUnrealizedProfit = ret.TotalUnrealizedProfit.Add(as.UnrealizedProfit)
+		ret.TotalMarginBalance = ret.TotalMarginBalance.Add(as.MarginBalance)
+		ret.TotalInitialMargin = ret.TotalInitialMargin.Add(as.InitialMargin)
+		ret.TotalPositionInitialMargin = ret.TotalPositionInitialMargin.Add(as.PositionInitialMargin)
+		ret.TotalOpenOrderInitialMargin = ret.TotalOpenOrderInitialMargin.Add(as.OpenOrderInitialMargin)
+		ret.AvailableBalance = ret.AvailableBalance.Add(as.AvailableBalance)
+	}
+	ret.TotalCrossWalletBalance = ret.TotalWalletBalance
+	ret.TotalCrossUnPnl = ret.TotalUnrealizedProfit
+	ret.MaxWithdrawAmount = ret.AvailableBalance
+	return ret, nil
+}
+
+func orderQuery(r *http.Request) (string, int64, string, *apiError) {
+	symbol := r.Form.Get("symbol")
+	if symbol == "" {
+		return "", 0, "", newParamError("symbol", "")
+	}
+
+	clientID := r.Form.Get("origClientOrderId")
+	var id int64
+	if val := r.Form.Get("orderId"); val != "" {
+		var err error
+		if id, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return "", 0, "", newParamError("orderId", "")
+		}
+	} else if clientID == "" {
+		return "", 0, "", &apiError{Status: http.StatusBadRequest, Code: -1102,
+			Message: "Param 'origClientOrderId' or 'orderId' must be sent, but both were empty/null!"}
+	}
+	return symbol, id, clientID, nil
+}
+
+func rangeQuery(r *http.Request, def int, max int) (int64, int64, int, *apiError) {
+	var (
+		st, et int64
+		limit  = def
+		err    error
+	)
+	if val := r.Form.Get("startTime"); val != "" {
+		if st, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return 0, 0, 0, newParamError("startTime", "")
+		}
+	}
+	if val := r.Form.Get("endTime"); val != "" {
+		if et, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return 0, 0, 0, newParamError("endTime", "")
+		}
+	}
+	if val := r.Form.Get("limit"); val != "" {
+		if limit, err = strconv.Atoi(val); err != nil || limit <= 0 {
+			return 0, 0, 0, newParamError("limit", "")
+		}
+		if limit > max {
+			limit = max
+		}
+	}
+	return st, et, limit, nil
+}
+
+func sortedKeys(assets map[string]*accountAsset) []string {
+	ret := make([]string, 0, len(assets))
+	for k := range assets {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,233 @@
+//Package simulator implement a local stateful exchange which speak the subset
+//of binance usd-m futures rest and websocket protocol used by binance/swap.
+//orders are matched against the book ticker set by the test with an in-memory
+//engine which track balances, positions, trades and incomes
+package simulator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/request"
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	//Config initial state of the simulator
+	Config struct {
+		//Key api key required by signed endpoints, any key is accepted if empty
+		Key string
+		//Secret used to verify request signature, not verified if empty
+		Secret           string
+		Symbols          []SymbolConfig
+		Balances         map[string]decimal.Decimal
+		MakerFee         decimal.Decimal
+		TakerFee         decimal.Decimal
+		Leverage         int64
+		DualSidePosition bool
+		//Now return the current time, time.Now is used if nil
+		Now func() time.Time
+	}
+
+	//SymbolConfig contract and filters of a symbol
+	SymbolConfig struct {
+		Symbol      string
+		BaseAsset   string
+		QuoteAsset  string
+		TickSize    decimal.Decimal
+		StepSize    decimal.Decimal
+		MinQty      decimal.Decimal
+		MaxQty      decimal.Decimal
+		MinNotional decimal.Decimal
+	}
+
+	//Server simulator which serve rest api on URL and websocket stream on WSURL
+	Server struct {
+		//URL base url of rest api
+		URL string
+		//WSURL address of websocket stream
+		WSURL string
+
+		cfg    *Config
+		rest   *httptest.Server
+		stream *wstest.Server
+		engine *engine
+
+		subMu sync.Mutex
+		subs  map[*wstest.Conn]map[string]bool
+	}
+
+	//apiError binance error response
+	apiError struct {
+		Status  int    `json:"-"`
+		Code    int    `json:"code"`
+		Message string `json:"msg"`
+	}
+
+	handlerFunc func(s *Server, r *http.Request) (interface{}, *apiError)
+
+	route struct {
+		handler handlerFunc
+		signed  bool
+	}
+)
+
+var (
+	routes = map[string]route{
+		"GET /fapi/v1/exchangeInfo":       {handler: (*Server).exchangeInfo},
+		"GET /fapi/v1/positionSide/dual":  {handler: (*Server).getPositionSide, signed: true},
+		"POST /fapi/v1/positionSide/dual": {handler: (*Server).setPositionSide, signed: true},
+		"POST /fapi/v1/order":             {handler: (*Server).addOrder, signed: true},
+		"GET /fapi/v1/order":              {handler: (*Server).getOrder, signed: true},
+		"DELETE /fapi/v1/order":           {handler: (*Server).deleteOrder, signed: true},
+		"GET /fapi/v1/openOrders":         {handler: (*Server).openOrders, signed: true},
+		"GET /fapi/v1/userTrades":         {handler: (*Server).userTrades, signed: true},
+		"GET /fapi/v1/income":             {handler: (*Server).income, signed: true},
+		"GET /fapi/v2/account":            {handler: (*Server).account, signed: true},
+	}
+)
+
+//NewConfig return config with BTCUSDT and ETHUSDT symbols, 10000 USDT balance,
+//20x leverage and 0.02%/0.04% maker/taker fee
+func NewConfig() *Config {
+	return &Config{
+		Symbols: []SymbolConfig{
+			{
+				Symbol:      "BTCUSDT",
+				BaseAsset:   "BTC",
+				QuoteAsset:  "USDT",
+				TickSize:    decimal.RequireFromString("0.1"),
+				StepSize:    decimal.RequireFromString("0.001"),
+				MinQty:      decimal.RequireFromString("0.001"),
+				MaxQty:      decimal.NewFromInt(1000),
+				MinNotional: decimal.NewFromInt(5),
+			},
+			{
+				Symbol:      "ETHUSDT",
+				BaseAsset:   "ETH",
+				QuoteAsset:  "USDT",
+				TickSize:    decimal.RequireFromString("0.01"),
+				StepSize:    decimal.RequireFromString("0.001"),
+				MinQty:      decimal.RequireFromString("0.001"),
+				MaxQty:      decimal.NewFromInt(10000),
+				MinNotional: decimal.NewFromInt(5),
+			},
+		},
+		Balances: map[string]decimal.Decimal{
+			"USDT": decimal.NewFromInt(10000),
+		},
+		MakerFee: decimal.RequireFromString("0.0002"),
+		TakerFee: decimal.RequireFromString("0.0004"),
+		Leverage: 20,
+	}
+}
+
+//NewServer start the simulator with cfg, NewConfig is used if cfg is nil
+func NewServer(cfg *Config) *Server {
+	if cfg == nil {
+		cfg = NewConfig()
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if cfg.Leverage <= 0 {
+		cfg.Leverage = 1
+	}
+
+	ret := &Server{
+		cfg:    cfg,
+		engine: newEngine(cfg),
+		subs:   map[*wstest.Conn]map[string]bool{},
+	}
+	ret.rest = httptest.NewServer(http.HandlerFunc(ret.serveHTTP))
+	ret.stream = wstest.NewServer(ret.serveStream)
+	ret.URL = ret.rest.URL
+	ret.WSURL = ret.stream.URL
+	return ret
+}
+
+//Options return rest client options which send requests to the simulator
+func (s *Server) Options() []request.Option {
+	return []request.Option{request.WithBaseURL(s.URL)}
+}
+
+//Close shutdown rest and websocket server
+func (s *Server) Close() {
+	s.stream.Close()
+	s.rest.Close()
+}
+
+//DropStreams close all websocket connections which simulate a network failure
+func (s *Server) DropStreams() {
+	s.stream.Drop()
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	rt, ok := routes[fmt.Sprintf("%s %s", r.Method, r.URL.Path)]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, &apiError{Code: -5000, Message: "Path not found"})
+		return
+	}
+
+	if rt.signed {
+		if ae := s.verify(r); ae != nil {
+			writeJSON(w, ae.Status, ae)
+			return
+		}
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, newParamError("", err.Error()))
+		return
+	}
+
+	resp, ae := rt.handler(s, r)
+	if ae != nil {
+		writeJSON(w, ae.Status, ae)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+//verify api key and hmac signature of signed request
+func (s *Server) verify(r *http.Request) *apiError {
+	if key := r.Header.Get("X-MBX-APIKEY"); key == "" || s.cfg.Key != "" && key != s.cfg.Key {
+		return &apiError{Status: http.StatusUnauthorized, Code: -2015, Message: "Invalid API-key, IP, or permissions for action."}
+	}
+	if s.cfg.Secret == "" {
+		return nil
+	}
+
+	query := r.URL.RawQuery
+	idx := strings.LastIndex(query, "&signature=")
+	if idx == -1 {
+		return &apiError{Status: http.StatusBadRequest, Code: -1102, Message: "Mandatory parameter 'signature' was not sent, was empty/null, or malformed."}
+	}
+	h := hmac.New(sha256.New, []byte(s.cfg.Secret))
+	h.Write([]byte(query[:idx]))
+	if fmt.Sprintf("%x", h.Sum(nil)) != query[idx+len("&signature="):] {
+		return &apiError{Status: http.StatusBadRequest, Code: -1022, Message: "Signature for this request is not valid."}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func newParamError(name string, msg string) *apiError {
+	if msg == "" {
+		msg = fmt.Sprintf("Mandatory parameter '%s' was not sent, was empty/null, or malformed.", name)
+	}
+	return &apiError{Status: http.StatusBadRequest, Code: -1102, Message: msg}
+}
@@ -0,0 +1,206 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/binance/swap"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func newTestClient(t *testing.T, cfg *Config) (*Server, *swap.RestClient) {
+	srv := NewServer(cfg)
+	rc := swap.NewRestClient("key", "secret", srv.Options()...)
+
+	if err := rc.LoadSymbols(context.Background()); err != nil {
+		t.Fatalf("init symbols fail %s", err.Error())
+	}
+	if _, err := rc.GetPositionSide(context.Background(), swap.NewGetPositionSideRequest()); err != nil {
+		t.Fatalf("get position side fail %s", err.Error())
+	}
+	return srv, rc
+}
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Key = "key"
+	cfg.Secret = "secret"
+	srv, rc := newTestClient(t, cfg)
+	defer srv.Close()
+
+	ctx := context.Background()
+	sym, err := rc.ParseSymbol("BTCUSDT")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	srv.SetBookTicker("BTCUSDT", d("40000"), d("1"), d("40001"), d("1"))
+
+	//maker buy rest on the book
+	req := exchange.NewDecimalOrderRequest(sym, exchange.NewStrID("c1"), exchange.OrderSideBuy, exchange.OrderTypeLimit, d("39990"), d("0.1"))
+	order, err := rc.CreateOrder(ctx, req, exchange.NewPostOnlyOption(true))
+	if err != nil {
+		t.Fatalf("create order fail %s", err.Error())
+	}
+	if order.Status != exchange.OrderStatusOpen || order.ClientID.String() != "c1" {
+		t.Errorf("bad order %+v", order)
+	}
+
+	orders, err := rc.OpenOrders(ctx, sym)
+	if err != nil || len(orders) != 1 {
+		t.Fatalf("fetch open orders fail %v %v", err, orders)
+	}
+
+	//post only order which cross the book is rejected
+	req = exchange.NewDecimalOrderRequest(sym, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit, d("40001"), d("0.1"))
+	if _, err := rc.CreateOrder(ctx, req, exchange.NewPostOnlyOption(true)); !errors.Is(err, exchange.ErrPostOnlyRejected) {
+		t.Errorf("expect post only rejected got %v", err)
+	}
+
+	//the book move through the resting order
+	srv.SetBookTicker("BTCUSDT", d("39980"), d("1"), d("39985"), d("1"))
+	order, err = rc.FetchOrder(ctx, order)
+	if err != nil {
+		t.Fatalf("fetch order fail %s", err.Error())
+	}
+	if order.Status != exchange.OrderStatusDone || !order.Filled.Equal(d("0.1")) || !order.AvgPrice.Equal(d("39990")) {
+		t.Errorf("bad filled order %+v", order)
+	}
+
+	//taker sell close the position with profit
+	srv.SetBookTicker("BTCUSDT", d("40100"), d("1"), d("40101"), d("1"))
+	req = exchange.NewDecimalOrderRequest(sym, nil, exchange.OrderSideSell, exchange.OrderTypeMarket, decimal.Zero, d("0.1"))
+	sell, err := rc.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("create market order fail %s", err.Error())
+	}
+	if sell.Status != exchange.OrderStatusDone || !sell.AvgPrice.Equal(d("40100")) {
+		t.Errorf("bad market order %+v", sell)
+	}
+	if amt, _ := srv.Position("BTCUSDT", positionSideBoth); !amt.IsZero() {
+		t.Errorf("position not closed %s", amt)
+	}
+
+	trades, err := rc.Trades(ctx, &exchange.TradeReqParam{Symbol: sym})
+	if err != nil || len(trades) != 2 {
+		t.Fatalf("fetch trades fail %v %v", err, trades)
+	}
+	if !trades[0].IsMaker || trades[0].Side != exchange.OrderSideBuy || !trades[0].Fee.Equal(d("-0.79980")) ||
+		trades[1].IsMaker || trades[1].Side != exchange.OrderSideSell || !trades[1].Fee.Equal(d("-1.6040")) {
+		t.Errorf("bad trades %+v", trades)
+	}
+
+	//10000 + 11 pnl - 0.7998 - 1.604 fee
+	if balance := srv.Balance("USDT"); !balance.Equal(d("10008.5962")) {
+		t.Errorf("bad balance %s", balance)
+	}
+	incomes, err := rc.Income(ctx, "BTCUSDT", swap.IncomeTypeNone, 0, 0, 0)
+	if err != nil || len(incomes) != 3 {
+		t.Fatalf("fetch income fail %v %v", err, incomes)
+	}
+	if incomes[1].IncomeType != swap.IncomeTypeRealizedPnl || !incomes[1].Income.Equal(d("11")) {
+		t.Errorf("bad income %+v", incomes)
+	}
+
+	if _, err := rc.CancelOrder(ctx, order); !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("expect order not found got %v", err)
+	}
+}
+
+func TestMarginAndFunding(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Balances["USDT"] = d("100")
+	srv, rc := newTestClient(t, cfg)
+	defer srv.Close()
+
+	ctx := context.Background()
+	sym, _ := rc.ParseSymbol("BTCUSDT")
+	srv.SetBookTicker("BTCUSDT", d("40000"), d("1"), d("40001"), d("1"))
+
+	//100 USDT with 20x leverage can not open 0.1 BTC
+	req := exchange.NewDecimalOrderRequest(sym, nil, exchange.OrderSideBuy, exchange.OrderTypeMarket, decimal.Zero, d("0.1"))
+	if _, err := rc.CreateOrder(ctx, req); !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("expect insufficient balance got %v", err)
+	}
+
+	req = exchange.NewDecimalOrderRequest(sym, nil, exchange.OrderSideBuy, exchange.OrderTypeMarket, decimal.Zero, d("0.01"))
+	if _, err := rc.CreateOrder(ctx, req); err != nil {
+		t.Fatalf("create order fail %s", err.Error())
+	}
+	if amt, entry := srv.Position("BTCUSDT", positionSideBoth); !amt.Equal(d("0.01")) || !entry.Equal(d("40001")) {
+		t.Errorf("bad position %s %s", amt, entry)
+	}
+
+	srv.SettleFunding("BTCUSDT", d("0.0001"))
+	finance, err := rc.Finance(ctx, &exchange.FinanceReqParam{Type: exchange.FinanceTypeFunding})
+	if err != nil || len(finance) != 1 {
+		t.Fatalf("fetch funding fail %v %v", err, finance)
+	}
+	//0.01 * 40000.5 * 0.0001
+	if !finance[0].Amount.Equal(d("-0.0400005")) || finance[0].Type != exchange.FinanceTypeFunding {
+		t.Errorf("bad funding %+v", finance[0])
+	}
+
+	account, err := rc.Account(ctx, swap.NewAccountReq())
+	if err != nil {
+		t.Fatalf("fetch account fail %s", err.Error())
+	}
+	pos, err := account.GetPosition("BTCUSDT")
+	if err != nil || len(pos) != 1 || !pos[0].PositionAmt.Equal(d("0.01")) || !pos[0].UnrealizedProfit.Equal(d("-0.005")) {
+		t.Errorf("bad account position %v %+v", err, pos)
+	}
+}
+
+func TestBookTickerStream(t *testing.T) {
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	data := make(chan interface{}, 4)
+	ws := swap.NewWSClientWithAddr(srv.WSURL, data)
+	if err := ws.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer ws.Close()
+
+	if err := ws.Subscribe(ctx, swap.NewBookTickerChannel("BTCUSDT")); err != nil {
+		t.Fatalf("subscribe fail %s", err.Error())
+	}
+	//wait the subscription is handled
+	for i := 0; ; i++ {
+		srv.subMu.Lock()
+		n := 0
+		for _, subs := range srv.subs {
+			if subs["btcusdt@bookTicker"] {
+				n++
+			}
+		}
+		srv.subMu.Unlock()
+		if n == 1 {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("wait subscription timeout")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	srv.SetBookTicker("ETHUSDT", d("3000"), d("1"), d("3000.01"), d("1"))
+	srv.SetBookTicker("BTCUSDT", d("40000"), d("1.5"), d("40001"), d("2"))
+	select {
+	case v := <-data:
+		bt := v.(*exchange.WSNotify).Data.(*swap.BookTickerNotify)
+		if bt.Symbol != "BTCUSDT" || bt.Bid1Price != "40000" || bt.Ask1Amount != "2" || bt.UpdateID != 2 {
+			t.Errorf("bad book ticker %+v", bt)
+		}
+	case <-ctx.Done():
+		t.Fatalf("wait book ticker timeout")
+	}
+}
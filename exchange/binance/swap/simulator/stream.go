@@ -0,0 +1,128 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	bookTicker struct {
+		Event     string          `json:"e"`
+		UpdateID  int64           `json:"u"`
+		EventTime int64           `json:"E"`
+		TransTime int64           `json:"T"`
+		Symbol    string          `json:"s"`
+		BidPrice  decimal.Decimal `json:"b"`
+		BidQty    decimal.Decimal `json:"B"`
+		AskPrice  decimal.Decimal `json:"a"`
+		AskQty    decimal.Decimal `json:"A"`
+	}
+)
+
+const (
+	methodSubscribe   = "SUBSCRIBE"
+	methodUnSubscribe = "UNSUBSCRIBE"
+)
+
+//SetBookTicker update best bid and ask of symbol. resting orders crossed by
+//the new book are filled as maker and the book ticker is pushed to streams
+//which subscribe <symbol>@bookTicker
+func (s *Server) SetBookTicker(symbol string, bid, bidQty, ask, askQty decimal.Decimal) error {
+	bk, err := s.engine.setBook(symbol, bid, bidQty, ask, askQty)
+	if err != nil {
+		return err
+	}
+
+	bt := &bookTicker{
+		Event:     "bookTicker",
+		UpdateID:  bk.UpdateID,
+		EventTime: bk.Time,
+		TransTime: bk.Time,
+		Symbol:    symbol,
+		BidPrice:  bid,
+		BidQty:    bidQty,
+		AskPrice:  ask,
+		AskQty:    askQty,
+	}
+	s.publish(fmt.Sprintf("%s@bookTicker", strings.ToLower(symbol)), bt)
+	return nil
+}
+
+//SettleFunding charge funding fee of all symbol positions with rate. long
+//positions pay short positions if rate is positive
+func (s *Server) SettleFunding(symbol string, rate decimal.Decimal) error {
+	return s.engine.settleFunding(symbol, rate)
+}
+
+//Balance return the wallet balance of asset
+func (s *Server) Balance(asset string) decimal.Decimal {
+	s.engine.mu.Lock()
+	defer s.engine.mu.Unlock()
+	return s.engine.balances[asset]
+}
+
+//Position return signed amount and entry price of the position
+func (s *Server) Position(symbol string, positionSide string) (decimal.Decimal, decimal.Decimal) {
+	s.engine.mu.Lock()
+	defer s.engine.mu.Unlock()
+
+	pos, ok := s.engine.positions[positionKey(symbol, positionSide)]
+	if !ok {
+		return decimal.Zero, decimal.Zero
+	}
+	return pos.Amt, pos.EntryPrice
+}
+
+func (s *Server) serveStream(c *wstest.Conn) {
+	s.subMu.Lock()
+	s.subs[c] = map[string]bool{}
+	s.subMu.Unlock()
+
+	defer func() {
+		s.subMu.Lock()
+		delete(s.subs, c)
+		s.subMu.Unlock()
+	}()
+
+	c.Serve(context.Background(),
+		wstest.On(wstest.Field("method", methodSubscribe), s.subscribe(c, true)),
+		wstest.On(wstest.Field("method", methodUnSubscribe), s.subscribe(c, false)),
+	)
+}
+
+func (s *Server) subscribe(c *wstest.Conn, sub bool) wstest.Reply {
+	return func(m *wstest.Message) []interface{} {
+		var req struct {
+			Params []string `json:"params"`
+		}
+		if err := m.Decode(&req); err != nil {
+			return []interface{}{&codeResp{Code: 2, Message: "Invalid request"}}
+		}
+
+		s.subMu.Lock()
+		for _, p := range req.Params {
+			s.subs[c][p] = sub
+		}
+		s.subMu.Unlock()
+		return []interface{}{map[string]interface{}{"result": nil, "id": m.Get("id")}}
+	}
+}
+
+func (s *Server) publish(stream string, data interface{}) {
+	s.subMu.Lock()
+	var conns []*wstest.Conn
+	for c, subs := range s.subs {
+		if subs[stream] {
+			conns = append(conns, c)
+		}
+	}
+	s.subMu.Unlock()
+
+	for _, c := range conns {
+		c.Write(data)
+	}
+}
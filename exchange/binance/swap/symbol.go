@@ -3,6 +3,7 @@ package swap
 import (
 	"context"
 	"net/http"
+	"sync"
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/pkg/errors"
@@ -62,45 +63,91 @@ const (
 )
 
 var (
-	symbolMap  = map[string]exchange.SwapSymbol{}
-	restClient *RestClient
+	defaultMu     sync.RWMutex
+	defaultClient = NewRestClient("", "")
 )
 
 func Init(ctx context.Context) error {
-	if restClient != nil {
-		return errors.Errorf("client alreaduy init")
-	}
-	restClient = NewRestClient("", "")
-	return UpdateSymbolMap(ctx)
+	return InitWithClient(ctx, NewRestClient("", ""))
 }
 
 func InitTest(ctx context.Context) error {
-	if restClient != nil {
-		return errors.Errorf("client alreaduy init")
-	}
-	restClient = NewTestRestClient("", "")
-	return UpdateSymbolMap(ctx)
+	return InitWithClient(ctx, NewTestRestClient("", ""))
 }
 
-func UpdateSymbolMap(ctx context.Context) error {
-	symbols, err := restClient.Symbols(ctx)
-	if err != nil {
-		return errors.WithMessage(err, "fetch symbols fail")
+//InitWithClient load symbols with rc and make rc the default client which
+//is used by ParseSymbol. it's used to load symbols from a proxy or a local
+//simulator
+func InitWithClient(ctx context.Context, rc *RestClient) error {
+	if err := rc.LoadSymbols(ctx); err != nil {
+		return err
 	}
 
-	for _, s := range symbols {
-		symbolMap[s.String()] = s
-	}
+	defaultMu.Lock()
+	defaultClient = rc
+	defaultMu.Unlock()
 	return nil
+}
 
+//UpdateSymbolMap refresh symbol store of the default client
+func UpdateSymbolMap(ctx context.Context) error {
+	return getDefaultClient().LoadSymbols(ctx)
+}
+
+//SymbolStore return symbol store of the default client which is set by Init or InitTest
+func SymbolStore() *exchange.SymbolStore {
+	return getDefaultClient().SymbolStore()
 }
 
 func ParseSymbol(symbol string) (exchange.SwapSymbol, error) {
-	sym, ok := symbolMap[symbol]
-	if !ok {
+	return getDefaultClient().ParseSymbol(symbol)
+}
+
+//SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
+
+//LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
+}
+
+//ParseSymbol parse symbol with symbol store of rc. the default client store
+//is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(symbol string) (exchange.SwapSymbol, error) {
+	store := rc.store
+	if store.Updated().IsZero() {
+		store = getDefaultClient().store
+	}
+	sym, err := store.Lookup(symbol)
+	if err != nil {
 		return nil, errors.Errorf("unsupport symbol %s", symbol)
 	}
-	return sym, nil
+	return sym.(exchange.SwapSymbol), nil
+}
+
+func getDefaultClient() *RestClient {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultClient
+}
+
+//NewSymbolStore return a symbol store which fetch symbols with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		symbols, err := rc.Symbols(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]exchange.Symbol, len(symbols))
+		for i, s := range symbols {
+			ret[i] = s
+		}
+		return ret, nil
+	}, opts...)
 }
 
 func (rc *RestClient) ExchangeInfo(ctx context.Context) (*ExchangeInfo, error) {
@@ -0,0 +1,15 @@
+package swap
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ServerTimeEndPoint = "/fapi/v1/time"
+)
+
+//ServerTime return binance usdt swap server time, it can be used as clock.Source
+func (rc *RestClient) ServerTime(ctx context.Context) (time.Time, error) {
+	return rc.GetServerTime(ctx, ServerTimeEndPoint)
+}
@@ -45,7 +45,11 @@ func (rc *RestClient) UserTrades(ctx context.Context, symbol string, st int64, e
 }
 
 func (t *Trade) Parse() (*exchange.Trade, error) {
-	s, err := ParseSymbol(t.Symbol)
+	return t.parse(getDefaultClient())
+}
+
+func (t *Trade) parse(rc *RestClient) (*exchange.Trade, error) {
+	s, err := rc.ParseSymbol(t.Symbol)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +98,7 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 	ret := []exchange.Trade{}
 	for i := range trades {
 		trade := trades[i]
-		t, err := trade.Parse()
+		t, err := trade.parse(rc)
 		if err != nil {
 			return nil, err
 		}
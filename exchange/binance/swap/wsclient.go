@@ -15,8 +15,13 @@ const (
 )
 
 func NewWSClient(data chan interface{}) *WSClient {
+	return NewWSClientWithAddr(WSClientEndPoint, data)
+}
+
+//NewWSClientWithAddr create notify client which connect to addr
+func NewWSClientWithAddr(addr string, data chan interface{}) *WSClient {
 	ret := &WSClient{
-		NotifyClient: binance.NewNotifyClient(WSClientEndPoint, NewCodeC(), data, nil),
+		NotifyClient: binance.NewNotifyClient(addr, NewCodeC(), data, nil),
 	}
 	return ret
 }
@@ -0,0 +1,97 @@
+package swap
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+)
+
+func TestWSClient(t *testing.T) {
+	subs := make(chan []string, 1)
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Field("method", binance.MethodSubscibe), func(m *wstest.Message) []interface{} {
+				var req binance.SubscribeRequest
+				params := []string{}
+				req.Params = &params
+				m.Decode(&req)
+				subs <- params
+				return []interface{}{
+					map[string]interface{}{"result": nil, "id": m.Get("id")},
+					map[string]interface{}{
+						"e": "bookTicker", "u": 400900217, "E": 1568014460893, "T": 1568014460891, "s": "BTCUSDT",
+						"b": "25.35190000", "B": "31.21000000", "a": "25.36520000", "A": "40.66000000",
+					},
+				}
+			}),
+		)
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	data := make(chan interface{}, 1)
+	ws := &WSClient{
+		NotifyClient: binance.NewNotifyClient(srv.URL, NewCodeC(), data, nil),
+	}
+	if err := ws.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer ws.Close()
+
+	if err := ws.Subscribe(ctx, NewBookTickerChannel("BTCUSDT")); err != nil {
+		t.Fatalf("subscribe fail %s", err.Error())
+	}
+	select {
+	case s := <-subs:
+		if len(s) != 1 || s[0] != "btcusdt@bookTicker" {
+			t.Errorf("bad subscribe params %v", s)
+		}
+	case <-ctx.Done():
+		t.Fatalf("wait subscribe timeout")
+	}
+
+	select {
+	case d := <-data:
+		notify := d.(*exchange.WSNotify)
+		bt := notify.Data.(*BookTickerNotify)
+		if notify.Chan != "bookTicker" || bt.Symbol != "BTCUSDT" || bt.UpdateID != 400900217 || bt.Bid1Price != "25.35190000" {
+			t.Errorf("bad notify %+v", bt)
+		}
+	case <-ctx.Done():
+		t.Fatalf("wait book ticker timeout")
+	}
+}
+
+func TestWSClientSubscribeReject(t *testing.T) {
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Has("method"), func(m *wstest.Message) []interface{} {
+				return []interface{}{
+					map[string]interface{}{"error": map[string]interface{}{"code": 2, "msg": "Invalid request"}, "id": m.Get("id")},
+				}
+			}),
+		)
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	ws := &WSClient{
+		NotifyClient: binance.NewNotifyClient(srv.URL, NewCodeC(), make(chan interface{}, 1), nil),
+	}
+	if err := ws.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer ws.Close()
+
+	err := ws.Subscribe(ctx, NewBookTickerChannel("BTCUSDT"))
+	if err == nil || !strings.Contains(err.Error(), "Invalid request") {
+		t.Errorf("expect reject error got %v", err)
+	}
+}
@@ -25,15 +25,23 @@ type (
 		handler rpc.Handler
 		codec   rpc.Codec
 		client  ListenKeyClient
+		opts    []exchange.DialOption
 	}
 
+	//NotifyClient public wsclient which subscribe channels by SUBSCRIBE
+	//request
 	NotifyClient struct {
 		*exchange.WSClient
-		data chan interface{}
-		mu   sync.Mutex
+		*exchange.Delivery
+		mu sync.Mutex
 	}
 )
 
+const (
+	//AckTimeout max time waiting for reply of subscribe request
+	AckTimeout = time.Second * 10
+)
+
 func NewWSClient(codec rpc.Codec, handler rpc.Handler, client ListenKeyClient) *WSClient {
 	return &WSClient{
 		handler: handler,
@@ -71,21 +79,26 @@ func (ws *WSClient) Run(ctx context.Context) error {
 		}
 	}()
 
-	stream, err := rpc.NewWebsocketStream(addr, ws.codec)
+	stream, err := rpc.NewWebsocketStream(addr, ws.codec, ws.opts...)
 	if err != nil {
 		return errors.WithMessage(err, "create websocket stream fail")
 	}
 
-	conn := rpc.NewConn(stream)
+	conn := rpc.NewConnWithHeartbeat(stream, newHeartbeat())
 	ws.Conn = conn
 
 	go ws.Conn.Run(ctx, ws.handler)
 	return nil
 }
 
+//SetDialOptions append opts of the stream, it must be called before Run
+func (ws *WSClient) SetDialOptions(opts ...exchange.DialOption) {
+	ws.opts = append(ws.opts, opts...)
+}
+
 func NewNotifyClient(addr string, codec rpc.Codec, data chan interface{}, handler rpc.Handler) *NotifyClient {
 	ret := &NotifyClient{
-		data: data,
+		Delivery: exchange.NewDelivery(data, exchange.OverflowDropNewest),
 	}
 
 	if handler == nil {
@@ -93,19 +106,31 @@ func NewNotifyClient(addr string, codec rpc.Codec, data chan interface{}, handle
 	}
 
 	ret.WSClient = exchange.NewWSClient(addr, codec, handler)
+	ret.WSClient.SetHeartbeat(newHeartbeat())
 	return ret
 }
 
+//newHeartbeat ping with control frame, the pong is counted as activity so
+//quiet stream is not regarded as dead
+func newHeartbeat() *rpc.Heartbeat {
+	return &rpc.Heartbeat{
+		Interval:    time.Minute,
+		Ping:        rpc.PingControl,
+		IdleTimeout: time.Minute * 3,
+	}
+}
+
 func (nc *NotifyClient) Handle(ctx context.Context, notify *rpc.Notify) {
-	nc.Push(notify.Method, notify.Params)
+	nc.push(ctx, notify.Method, notify.Params)
 }
 
+//Push deliver data of channel ch according to the overflow policy
 func (nc *NotifyClient) Push(ch string, data interface{}) {
-	notify := &exchange.WSNotify{Exchange: Exchange, Chan: ch, Data: data}
-	select {
-	case nc.data <- notify:
-	default:
-	}
+	nc.push(context.Background(), ch, data)
+}
+
+func (nc *NotifyClient) push(ctx context.Context, ch string, data interface{}) {
+	nc.Deliver(ctx, &exchange.WSNotify{Exchange: Exchange, Chan: ch, Data: data})
 }
 
 func (wcl *NotifyClient) Subscribe(ctx context.Context, channels ...exchange.Channel) error {
@@ -117,7 +142,7 @@ func (wcl *NotifyClient) Subscribe(ctx context.Context, channels ...exchange.Cha
 		param = append(param, c.String())
 	}
 
-	if err := wcl.Call(ctx, "1", MethodSubscibe, param, nil); err != nil {
+	if err := wcl.call(ctx, MethodSubscibe, param); err != nil {
 		return errors.WithMessage(err, "subscribe error")
 	}
 	return nil
@@ -132,8 +157,18 @@ func (wcl *NotifyClient) UnSubscribe(ctx context.Context, channels ...exchange.C
 		param = append(param, c.String())
 	}
 
-	if err := wcl.Call(ctx, "1", MethodUnSubscribe, param, nil); err != nil {
-		return errors.WithMessage(err, "subscribe error")
+	if err := wcl.call(ctx, MethodUnSubscribe, param); err != nil {
+		return errors.WithMessage(err, "unsubscribe error")
 	}
 	return nil
 }
+
+//call send request with unique id and wait the ack of server, rejected
+//request is returned as error
+func (wcl *NotifyClient) call(ctx context.Context, method string, param []string) error {
+	ctx, cancel := context.WithTimeout(ctx, AckTimeout)
+	defer cancel()
+
+	var result interface{}
+	return wcl.Call(ctx, rpc.NextID(), method, param, &result)
+}
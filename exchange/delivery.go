@@ -0,0 +1,187 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+
+	"github.com/NadiaSama/ccexgo/misc/metrics"
+)
+
+type (
+	//OverflowPolicy decide what to do with notify if the data channel is full
+	OverflowPolicy int
+
+	//Delivery push notify of websocket clients to the data channel according
+	//to the overflow policy and count dropped notify per key. the key of a
+	//notify is its Chan unless SetKeyFunc is called.
+	//
+	//once a notify is dropped the next delivered notify of the same key is
+	//marked with WSNotify.Resync, incremental data such as orderbook must be
+	//resynced from a snapshot
+	Delivery struct {
+		data chan interface{}
+
+		mu      sync.Mutex
+		policy  OverflowPolicy
+		keyFn   func(*WSNotify) string
+		total   uint64
+		dropped map[string]uint64
+		resync  map[string]bool
+		//pending conflated notify in arrive order, see OverflowConflate
+		pending     map[string]*WSNotify
+		pendingKeys []string
+	}
+)
+
+const (
+	//OverflowDropNewest drop the notify which can not be pushed
+	OverflowDropNewest OverflowPolicy = iota
+	//OverflowBlock block until the notify is pushed or ctx is done
+	OverflowBlock
+	//OverflowDropOldest drop notify from the head of data channel until the
+	//new notify is pushed
+	OverflowDropOldest
+	//OverflowConflate keep the latest notify of each key which can not be
+	//pushed, the older one is dropped. the kept notify are pushed by the
+	//following Deliver call before the new notify
+	OverflowConflate
+)
+
+//NewDelivery create Delivery which push notify to data with policy
+func NewDelivery(data chan interface{}, policy OverflowPolicy) *Delivery {
+	return &Delivery{
+		data:    data,
+		policy:  policy,
+		keyFn:   func(n *WSNotify) string { return n.Chan },
+		dropped: make(map[string]uint64),
+		resync:  make(map[string]bool),
+		pending: make(map[string]*WSNotify),
+	}
+}
+
+//SetPolicy change the overflow policy, conflated notify are kept until next
+//Deliver
+func (d *Delivery) SetPolicy(policy OverflowPolicy) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.policy = policy
+}
+
+//SetKeyFunc change how notify are grouped for conflation, drop counters
+//and resync mark
+func (d *Delivery) SetKeyFunc(fn func(*WSNotify) string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.keyFn = fn
+}
+
+//Dropped return total count of dropped notify
+func (d *Delivery) Dropped() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.total
+}
+
+//DroppedOf return count of dropped notify of key
+func (d *Delivery) DroppedOf(key string) uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dropped[key]
+}
+
+//Deliver push notify to data channel and return whether notify is pushed.
+//for OverflowConflate true is returned if the notify is kept
+func (d *Delivery) Deliver(ctx context.Context, notify *WSNotify) bool {
+	metrics.Default().Add(metrics.WSMessages, 1, "exchange", notify.Exchange, "channel", notify.Chan)
+
+	d.mu.Lock()
+	key := d.keyFn(notify)
+	if d.policy == OverflowBlock {
+		notify.Resync = d.resync[key]
+		d.mu.Unlock()
+
+		select {
+		case d.data <- notify:
+			d.mu.Lock()
+			delete(d.resync, key)
+			d.mu.Unlock()
+			return true
+
+		case <-ctx.Done():
+			d.mu.Lock()
+			d.drop(notify, key)
+			d.mu.Unlock()
+			return false
+		}
+	}
+	defer d.mu.Unlock()
+
+	switch d.policy {
+	case OverflowDropOldest:
+		for {
+			if d.push(key, notify) {
+				return true
+			}
+			select {
+			case old := <-d.data:
+				n, ok := old.(*WSNotify)
+				if !ok {
+					n = &WSNotify{Data: old}
+				}
+				d.drop(n, d.keyFn(n))
+			default:
+			}
+		}
+
+	case OverflowConflate:
+		d.flush()
+		if _, ok := d.pending[key]; !ok && len(d.pendingKeys) == 0 && d.push(key, notify) {
+			return true
+		}
+		if old, ok := d.pending[key]; ok {
+			d.drop(old, key)
+		} else {
+			d.pendingKeys = append(d.pendingKeys, key)
+		}
+		d.pending[key] = notify
+		return true
+
+	default:
+		if d.push(key, notify) {
+			return true
+		}
+		d.drop(notify, key)
+		return false
+	}
+}
+
+//push try to send notify without blocking, the lock must be held
+func (d *Delivery) push(key string, notify *WSNotify) bool {
+	notify.Resync = d.resync[key]
+	select {
+	case d.data <- notify:
+		delete(d.resync, key)
+		return true
+	default:
+		return false
+	}
+}
+
+//flush push conflated notify in arrive order until data channel is full
+func (d *Delivery) flush() {
+	for len(d.pendingKeys) != 0 {
+		key := d.pendingKeys[0]
+		if !d.push(key, d.pending[key]) {
+			return
+		}
+		delete(d.pending, key)
+		d.pendingKeys = d.pendingKeys[1:]
+	}
+}
+
+func (d *Delivery) drop(notify *WSNotify, key string) {
+	metrics.Default().Add(metrics.WSDropped, 1, "exchange", notify.Exchange, "channel", notify.Chan)
+	d.total++
+	d.dropped[key]++
+	d.resync[key] = true
+}
@@ -0,0 +1,94 @@
+package exchange
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDelivery(t *testing.T) {
+	n := func(ch string, data interface{}) *WSNotify {
+		return &WSNotify{Exchange: "test", Chan: ch, Data: data}
+	}
+	recv := func(data chan interface{}) []*WSNotify {
+		var ret []*WSNotify
+		for {
+			select {
+			case msg := <-data:
+				ret = append(ret, msg.(*WSNotify))
+			default:
+				return ret
+			}
+		}
+	}
+	ctx := context.Background()
+
+	data := make(chan interface{}, 2)
+	d := NewDelivery(data, OverflowDropNewest)
+	for i := 0; i < 3; i++ {
+		d.Deliver(ctx, n("depth", i))
+	}
+	if msgs := recv(data); len(msgs) != 2 || msgs[1].Data != 1 || d.Dropped() != 1 || d.DroppedOf("depth") != 1 {
+		t.Errorf("bad drop newest %+v %d", msgs, d.Dropped())
+	}
+	d.Deliver(ctx, n("depth", 3))
+	d.Deliver(ctx, n("trade", 4))
+	if msgs := recv(data); !msgs[0].Resync || msgs[1].Resync {
+		t.Errorf("bad resync mark %+v %+v", msgs[0], msgs[1])
+	}
+
+	d.SetPolicy(OverflowDropOldest)
+	for i := 0; i < 3; i++ {
+		d.Deliver(ctx, n("depth", i))
+	}
+	if msgs := recv(data); len(msgs) != 2 || msgs[0].Data != 1 || msgs[0].Resync || !msgs[1].Resync || d.Dropped() != 2 {
+		t.Errorf("bad drop oldest %+v %d", msgs, d.Dropped())
+	}
+
+	d.SetPolicy(OverflowConflate)
+	d.Deliver(ctx, n("depth", 0))
+	d.Deliver(ctx, n("trade", 1))
+	d.Deliver(ctx, n("depth", 2))
+	d.Deliver(ctx, n("depth", 3))
+	d.Deliver(ctx, n("index", 4))
+	if msgs := recv(data); len(msgs) != 2 || msgs[1].Data != 1 || d.DroppedOf("depth") != 3 {
+		t.Errorf("bad conflate %+v %d", msgs, d.DroppedOf("depth"))
+	}
+	d.Deliver(ctx, n("trade", 5))
+	if msgs := recv(data); len(msgs) != 2 || msgs[0].Data != 3 || !msgs[0].Resync || msgs[1].Data != 4 {
+		t.Errorf("bad conflate flush %+v", msgs)
+	}
+	d.Deliver(ctx, n("depth", 6))
+	if msgs := recv(data); len(msgs) != 2 || msgs[0].Data != 5 || msgs[1].Data != 6 || msgs[1].Resync {
+		t.Errorf("bad conflate order %+v", msgs)
+	}
+
+	d.SetPolicy(OverflowBlock)
+	d.Deliver(ctx, n("depth", 0))
+	d.Deliver(ctx, n("depth", 1))
+	cctx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+	if d.Deliver(cctx, n("depth", 2)) || d.DroppedOf("depth") != 4 {
+		t.Errorf("bad block %d", d.DroppedOf("depth"))
+	}
+}
+
+func TestSubscriptionResync(t *testing.T) {
+	sym := &testSubSymbol{NewBaseSwapSymbol("BTCUSDT")}
+	sub := NewSubscription(1)
+	var (
+		books  []*OrderBook
+		resync int
+	)
+	sub.OnOrderBook(func(n *WSNotify, ob *OrderBook) {
+		books = append(books, ob)
+	}).OnResync(func(n *WSNotify) {
+		resync++
+	})
+
+	sub.Dispatch(&WSNotify{Data: &OrderBookNotify{Symbol: sym, Bids: []OrderElem{{1.0, 1.0}}}})
+	sub.Dispatch(&WSNotify{Resync: true, Data: &OrderBookNotify{Symbol: sym, Asks: []OrderElem{{2.0, 1.0}}}})
+	if resync != 1 || len(books) != 2 || len(books[1].Bids) != 0 || len(books[1].Asks) != 1 {
+		t.Errorf("bad resync %d %+v", resync, books)
+	}
+}
@@ -3,9 +3,7 @@ package deribit
 import (
 	"context"
 	"encoding/json"
-	"strconv"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
@@ -19,10 +17,10 @@ type (
 		tokenMu     sync.Mutex
 		accessToken string
 		expire      time.Time
-		seq         int64
 		key         string
 		secret      string
-		data        chan interface{}
+		store       *exchange.SymbolStore
+		*exchange.Delivery
 	}
 
 	//clientReq comment struct which used to build request param
@@ -42,29 +40,64 @@ func NewTestWSClient(key, secret string, data chan interface{}) *Client {
 func newWSClient(addr, key, secret string, data chan interface{}) *Client {
 	codec := &Codec{}
 	ret := &Client{
-		key:    key,
-		secret: secret,
-		data:   data,
+		key:      key,
+		secret:   secret,
+		Delivery: exchange.NewDelivery(data, exchange.OverflowDropNewest),
 	}
 	ret.WSClient = exchange.NewWSClient(addr, codec, ret)
+	ret.WSClient.SetHeartbeat(&rpc.Heartbeat{
+		Interval:    time.Second * 30,
+		Ping:        rpc.PingCall("0", "public/test", map[string]interface{}{}),
+		IdleTimeout: time.Second * 90,
+	})
 	return ret
 }
 
+//SetSymbolStore set store which is used to parse symbols of order responses,
+//the package default store is used if not set. it should be called before
+//sending requests
+func (c *Client) SetSymbolStore(store *exchange.SymbolStore) {
+	c.store = store
+}
+
+func (c *Client) symbols() *exchange.SymbolStore {
+	if c.store == nil {
+		return SymbolStore()
+	}
+	return c.store
+}
+
 func (c *Client) Exchange() string {
 	return "deribit"
 }
 
 func (c *Client) Handle(ctx context.Context, notify *rpc.Notify) {
+	if notify.Method == heartbeatMethod {
+		if notify.Params == testRequest {
+			go c.call(ctx, "public/test", map[string]interface{}{}, nil, false)
+		}
+		return
+	}
+
 	data := &exchange.WSNotify{
 		Exchange: c.Exchange(),
 		Chan:     notify.Method,
 		Data:     notify.Params,
 	}
-	select {
-	case c.data <- data:
-	default:
-		return
+	c.Deliver(ctx, data)
+}
+
+//SetServerHeartbeat ask server to send heartbeat every interval, the
+//test_request is answered in Handle. interval must be at least 10 seconds
+func (c *Client) SetServerHeartbeat(ctx context.Context, interval time.Duration) error {
+	params := map[string]interface{}{
+		"interval": int(interval / time.Second),
+	}
+	var result string
+	if err := c.call(ctx, "public/set_heartbeat", params, &result, false); err != nil {
+		return errors.WithMessage(err, "set heartbeat fail")
 	}
+	return nil
 }
 
 //Auth is done by client.call
@@ -96,8 +129,7 @@ func (c *Client) call(ctx context.Context, method string, params interface{}, de
 		}
 
 	}
-	id := atomic.AddInt64(&c.seq, 1)
-	err := c.Conn.Call(ctx, strconv.FormatInt(id, 10), method, params, dest)
+	err := c.Conn.Call(ctx, rpc.NextID(), method, params, dest)
 	return exchange.NewBadExResp(err)
 }
 
@@ -0,0 +1,132 @@
+package deribit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func TestWSClient(t *testing.T) {
+	reply := func(m *wstest.Message, result interface{}) []interface{} {
+		return []interface{}{map[string]interface{}{"jsonrpc": JsonRPCVersion, "id": m.Get("id"), "result": result}}
+	}
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Field("method", "public/auth"), func(m *wstest.Message) []interface{} {
+				var req struct {
+					Params AuthParam `json:"params"`
+				}
+				m.Decode(&req)
+				if req.Params.ClientID != "key" || req.Params.ClientSecret != "secret" {
+					return []interface{}{map[string]interface{}{
+						"jsonrpc": JsonRPCVersion, "id": m.Get("id"),
+						"error": map[string]interface{}{"code": 13004, "message": "invalid_credentials"},
+					}}
+				}
+				return reply(m, &AuthResult{AccessToken: "token", ExpiresIn: 900, TokenType: "bearer"})
+			}),
+			wstest.On(wstest.Field("method", PrivateGetOpenOrdersByCurrency), func(m *wstest.Message) []interface{} {
+				var req struct {
+					Params map[string]string `json:"params"`
+				}
+				m.Decode(&req)
+				if req.Params["access_token"] != "token" || req.Params["currency"] != "BTC" {
+					return []interface{}{map[string]interface{}{
+						"jsonrpc": JsonRPCVersion, "id": m.Get("id"),
+						"error": map[string]interface{}{"code": 13009, "message": "unauthorized"},
+					}}
+				}
+				return reply(m, []interface{}{})
+			}),
+			wstest.On(wstest.Field("method", "public/subscribe"), func(m *wstest.Message) []interface{} {
+				return append(reply(m, []string{"deribit_price_index.btc_usd"}), map[string]interface{}{
+					"jsonrpc": JsonRPCVersion,
+					"method":  subscriptionMethod,
+					"params": map[string]interface{}{
+						"channel": "deribit_price_index.btc_usd",
+						"data":    map[string]interface{}{"index_name": "btc_usd", "price": 40012.5, "timestamp": 1633072800000},
+					},
+				})
+			}),
+		)
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	data := make(chan interface{}, 1)
+
+	bad := newWSClient(srv.URL, "key", "bad", data)
+	if err := bad.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer bad.Close()
+	if _, err := bad.OpenOrdersByCurrency(ctx, NewOpenOrdersByCurrencyRequest("BTC")); !errors.Is(err, exchange.ErrAuthFailed) {
+		t.Errorf("expect auth fail got %v", err)
+	}
+
+	client := newWSClient(srv.URL, "key", "secret", data)
+	if err := client.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer client.Close()
+
+	orders, err := client.OpenOrdersByCurrency(ctx, NewOpenOrdersByCurrencyRequest("BTC"))
+	if err != nil || len(orders) != 0 {
+		t.Fatalf("fetch open orders fail %v %v", err, orders)
+	}
+
+	if err := client.Subscribe(ctx, NewIndexChannel("btc_usd")); err != nil {
+		t.Fatalf("subscribe fail %s", err.Error())
+	}
+	select {
+	case d := <-data:
+		in := d.(*exchange.WSNotify).Data.(*exchange.IndexNotify)
+		if in.Symbol.String() != "btc_usd" || !in.Price.Equal(decimal.RequireFromString("40012.5")) {
+			t.Errorf("bad index %+v", in)
+		}
+	case <-ctx.Done():
+		t.Fatalf("wait index timeout")
+	}
+}
+
+func TestServerHeartbeat(t *testing.T) {
+	tested := make(chan struct{}, 1)
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Field("method", "public/set_heartbeat"), func(m *wstest.Message) []interface{} {
+				return []interface{}{
+					map[string]interface{}{"jsonrpc": JsonRPCVersion, "id": m.Get("id"), "result": "ok"},
+					map[string]interface{}{"jsonrpc": JsonRPCVersion, "method": heartbeatMethod, "params": map[string]string{"type": testRequest}},
+				}
+			}),
+			wstest.On(wstest.Field("method", "public/test"), func(m *wstest.Message) []interface{} {
+				tested <- struct{}{}
+				return []interface{}{map[string]interface{}{"jsonrpc": JsonRPCVersion, "id": m.Get("id"), "result": map[string]string{"version": "1.2.26"}}}
+			}),
+		)
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	client := newWSClient(srv.URL, "key", "secret", make(chan interface{}, 1))
+	if err := client.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer client.Close()
+
+	if err := client.SetServerHeartbeat(ctx, time.Second*10); err != nil {
+		t.Fatalf("set heartbeat fail %s", err.Error())
+	}
+	select {
+	case <-tested:
+	case <-ctx.Done():
+		t.Fatalf("wait test_request answer timeout")
+	}
+}
@@ -2,6 +2,9 @@ package deribit
 
 import (
 	"fmt"
+	"strconv"
+
+	"github.com/NadiaSama/ccexgo/exchange"
 )
 
 type (
@@ -12,6 +15,21 @@ type (
 	}
 )
 
+var (
+	codeKinds = map[int]error{
+		10000: exchange.ErrAuthFailed,
+		10004: exchange.ErrOrderNotFound,
+		10009: exchange.ErrInsufficientBalance,
+		10020: exchange.ErrInvalidSymbol,
+		10028: exchange.ErrRateLimited,
+		10047: exchange.ErrOverloaded,
+		11054: exchange.ErrPostOnlyRejected,
+		13004: exchange.ErrAuthFailed,
+		13009: exchange.ErrAuthFailed,
+		13028: exchange.ErrOverloaded,
+	}
+)
+
 func NewError(code int, msg string) error {
 	return &JRPCError{
 		Code: code,
@@ -22,7 +40,18 @@ func (je *JRPCError) Error() string {
 	return fmt.Sprintf("json rpc error code: %d message: %s", je.Code, je.Msg)
 }
 
+//Kind return the normalized exchange error kind, nil if unclassified
+func (je *JRPCError) Kind() error {
+	return codeKinds[je.Code]
+}
+
 func (js *JRPCError) Is(target error) bool {
 	_, ok := target.(*JRPCError)
 	return ok
 }
+
+//Unwrap return the error as *exchange.ErrAPI so that errors.Is match the
+//normalized error kind
+func (je *JRPCError) Unwrap() error {
+	return exchange.NewAPIError(je.Kind(), strconv.Itoa(je.Code), je.Msg)
+}
@@ -0,0 +1,55 @@
+package deribit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureInstruments(t *testing.T) {
+	rc := NewRestClient("", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+
+	if symbols := rc.SymbolStore().Symbols(); len(symbols) != 3 {
+		t.Fatalf("bad symbols %v", symbols)
+	}
+
+	osym, err := rc.ParseOptionSymbol("BTC-31DEC21-60000-C")
+	if err != nil {
+		t.Fatalf("parse option symbol fail %s", err.Error())
+	}
+	if osym.Type() != exchange.OptionTypeCall || !osym.Strike().Equal(decimal.NewFromInt(60000)) ||
+		osym.SettleTime().Unix() != 1640937600 || !osym.PricePrecision().Equal(decimal.RequireFromString("0.0005")) {
+		t.Errorf("bad option symbol %v", osym)
+	}
+
+	sym, err := rc.ParseSymbol("BTC-PERPETUAL")
+	if err != nil {
+		t.Fatalf("parse swap symbol fail %s", err.Error())
+	}
+	swap, ok := sym.(exchange.SwapSymbol)
+	if !ok || !swap.ContractVal().Equal(decimal.NewFromInt(10)) {
+		t.Errorf("bad swap symbol %v", sym)
+	}
+
+	fsym, err := rc.ParseFutureSymbol("ETH-31DEC21")
+	if err != nil {
+		t.Fatalf("parse future symbol fail %s", err.Error())
+	}
+	if fsym.Index() != "ETH" || fsym.SettleTime().Unix() != 1640937600 {
+		t.Errorf("bad future symbol %v", fsym)
+	}
+
+	if _, err := rc.ParseSymbol("BTC_USDC"); err == nil {
+		t.Errorf("expect spot symbol skipped")
+	}
+
+	_, err = rc.Instruments(context.Background(), NewInstrumentsRequest("SOL"))
+	if !errors.Is(err, exchange.ErrInvalidSymbol) {
+		t.Errorf("expect invalid symbol got %v", err)
+	}
+}
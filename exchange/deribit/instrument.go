@@ -142,6 +142,7 @@ func (i *InstrumentResult) Symbol() (exchange.Symbol, error) {
 		PricePrecision:  i.TickSize,
 		AmountPrecision: i.MinTradeAmount,
 		ValueMin:        decimal.Zero,
+		Inverse:         i.Kind == KindFuture,
 	}
 	var st time.Time
 	if i.Kind != KindFuture || i.SettlementPeriod != SettlePeriodPerpetual {
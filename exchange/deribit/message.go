@@ -15,12 +15,17 @@ import (
 const (
 	JsonRPCVersion     = "2.0"
 	subscriptionMethod = "subscription"
+	//heartbeatMethod server heartbeat enabled by public/set_heartbeat
+	heartbeatMethod = "heartbeat"
+	testRequest     = "test_request"
 )
 
 type (
 	Notify struct {
 		Data    json.RawMessage `json:"data"`
 		Channel string          `json:"channel"`
+		//Type of heartbeat notify
+		Type string `json:"type"`
 	}
 
 	Error struct {
@@ -75,6 +80,13 @@ func (cc *Codec) Decode(raw []byte) (rpc.Response, error) {
 		return resp, nil
 	}
 
+	if resp.Method == heartbeatMethod {
+		return &rpc.Notify{
+			Method: heartbeatMethod,
+			Params: resp.Params.Type,
+		}, nil
+	}
+
 	var err error
 	if resp.Error.Code != 0 {
 		err = NewError(resp.Error.Code, resp.Error.Message)
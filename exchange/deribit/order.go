@@ -10,6 +10,8 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+var _ exchange.Trader = (*Client)(nil)
+
 type (
 	orderParam struct {
 		AuthToken
@@ -48,7 +50,8 @@ type (
 )
 
 const (
-	PrivateGetOpenOrdersByCurrency = "/private/get_open_orders_by_currency"
+	PrivateGetOpenOrdersByCurrency   = "/private/get_open_orders_by_currency"
+	PrivateGetOpenOrdersByInstrument = "/private/get_open_orders_by_instrument"
 )
 
 var (
@@ -107,6 +110,10 @@ func (obr *OpenOrdersByCurrencyRequest) MarshalJSON() ([]byte, error) {
 }
 
 func (c *Client) CreateOrder(ctx context.Context, req *exchange.OrderRequest, opts ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
 	var method string
 	if req.Side == exchange.OrderSideBuy {
 		method = "/private/buy"
@@ -139,7 +146,7 @@ func (c *Client) CreateOrder(ctx context.Context, req *exchange.OrderRequest, op
 			param.TimeInForce = val
 
 		default:
-			return nil, exchange.NewBadArg("unsupport option value", msg)
+			return nil, exchange.NewUnsupportOption(opt)
 		}
 	}
 
@@ -148,31 +155,31 @@ func (c *Client) CreateOrder(ctx context.Context, req *exchange.OrderRequest, op
 		return nil, err
 	}
 
-	return or.Order.transform()
+	return or.Order.transform(c.symbols())
 }
 
 func (c *Client) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
 	param := map[string]interface{}{
-		"order_id": order.ID,
+		"order_id": order.ID.String(),
 	}
 	var r Order
 	if err := c.call(ctx, "/private/get_order_state", param, &r, true); err != nil {
 		return nil, err
 	}
 
-	return r.transform()
+	return r.transform(c.symbols())
 }
 
 func (c *Client) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
 	param := map[string]interface{}{
-		"order_id": order.ID,
+		"order_id": order.ID.String(),
 	}
 
 	var r Order
 	if err := c.call(ctx, "/private/cancel", param, &r, true); err != nil {
 		return nil, err
 	}
-	return r.transform()
+	return r.transform(c.symbols())
 }
 
 func (c *Client) OpenOrdersByCurrency(ctx context.Context, req *OpenOrdersByCurrencyRequest) ([]Order, error) {
@@ -185,10 +192,32 @@ func (c *Client) OpenOrdersByCurrency(ctx context.Context, req *OpenOrdersByCurr
 	return resp, nil
 }
 
-func (order *Order) transform() (*exchange.Order, error) {
+//OpenOrders return open orders of the instrument
+func (c *Client) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	param := map[string]interface{}{
+		"instrument_name": symbol.String(),
+	}
+
+	var resp []Order
+	if err := c.call(ctx, PrivateGetOpenOrdersByInstrument, param, &resp, true); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, len(resp))
+	for i := range resp {
+		o, err := resp[i].transform(c.symbols())
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = o
+	}
+	return ret, nil
+}
+
+func (order *Order) transform(store *exchange.SymbolStore) (*exchange.Order, error) {
 	create := tconv.Milli2Time(order.CreationTimestamp)
 	update := tconv.Milli2Time(order.LastUpdatedTimestamp)
-	sym, err := ParseOptionSymbol(order.InstrumentName)
+	sym, err := parseOptionSymbol(store, order.InstrumentName)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "parse symbol %s fail", order.InstrumentName)
 	}
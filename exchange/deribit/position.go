@@ -1,6 +1,8 @@
 package deribit
 
 import (
+	"context"
+
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
@@ -64,8 +66,35 @@ func NewPositionRequest(instrument string) *PositionRequest {
 	}
 }
 
+//FetchPosition return positions of sym, symbols of positions are parsed with
+//the store of the client
+func (c *Client) FetchPosition(ctx context.Context, sym ...exchange.Symbol) ([]exchange.Position, error) {
+	if len(sym) == 0 {
+		return nil, errors.Errorf("at least 1 symbol is required")
+	}
+
+	ret := make([]exchange.Position, len(sym))
+	for i, s := range sym {
+		var pr PositionResult
+		if err := c.call(ctx, PrivateGetPosition, NewPositionRequest(s.String()), &pr, true); err != nil {
+			return nil, errors.WithMessagef(err, "fetch position %s fail", s.String())
+		}
+
+		p, err := pr.transfer(c.symbols())
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = *p
+	}
+	return ret, nil
+}
+
 func (pr *PositionResult) Transfer() (*exchange.Position, error) {
-	symbol, err := ParseSymbol(pr.InstrumentName)
+	return pr.transfer(SymbolStore())
+}
+
+func (pr *PositionResult) transfer(store *exchange.SymbolStore) (*exchange.Position, error) {
+	symbol, err := parseSymbol(store, pr.InstrumentName)
 	if err != nil {
 		return nil, errors.WithMessage(err, "parse symbol fail")
 	}
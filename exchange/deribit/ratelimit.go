@@ -0,0 +1,27 @@
+package deribit
+
+import (
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+)
+
+const (
+	BucketNonMatching = "non_matching"
+	BucketMatching    = "matching"
+)
+
+//NewRateLimiter return the default rate limiter. matching engine requests
+//(buy, sell, edit and cancel) and other requests use separated buckets
+func NewRateLimiter() *ratelimit.Limiter {
+	matching := ratelimit.Cost{Bucket: BucketMatching, Weight: 1}
+	ret := ratelimit.NewLimiter(ratelimit.PolicyBlock,
+		ratelimit.NewBucket(BucketNonMatching, 20, time.Second),
+		ratelimit.NewBucket(BucketMatching, 5, time.Second),
+	).SetDefault(ratelimit.Cost{Bucket: BucketNonMatching, Weight: 1})
+
+	for _, ep := range []string{"/private/buy", "/private/sell", "/private/edit", "/private/cancel", "/private/cancel_all"} {
+		ret.SetWeight("", ep, matching)
+	}
+	return ret
+}
@@ -0,0 +1,44 @@
+package deribit
+
+import (
+	"context"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+const (
+	ExchangeName = "deribit"
+)
+
+func init() {
+	exchange.Register(&exchange.Registration{
+		Name: ExchangeName,
+		NewRestClient: func(cfg *exchange.Config) (interface{}, error) {
+			if cfg.TestNet {
+				return NewTestRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...), nil
+			}
+			return NewRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...), nil
+		},
+		//order entry of deribit is done via websocket client which implement exchange.Trader
+		NewWSClient: func(cfg *exchange.Config, data chan interface{}) (exchange.WSConn, error) {
+			if cfg.TestNet {
+				return NewTestWSClient(cfg.Key, cfg.Secret, data), nil
+			}
+			return NewWSClient(cfg.Key, cfg.Secret, data), nil
+		},
+		LoadSymbols: func(ctx context.Context, cfg *exchange.Config) error {
+			return Init(ctx, cfg.TestNet)
+		},
+		ParseSymbol: func(symbol string) (exchange.Symbol, error) {
+			return ParseSymbol(symbol)
+		},
+		NewSymbolStore: func(cfg *exchange.Config) (*exchange.SymbolStore, error) {
+			if cfg.TestNet {
+				return NewTestRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...).NewSymbolStore(), nil
+			}
+			return NewRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...).NewSymbolStore(), nil
+		},
+		Capabilities: exchange.CapabilityPublicStream | exchange.CapabilityPrivateStream | exchange.CapabilityTestNet,
+	})
+}
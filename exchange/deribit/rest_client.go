@@ -9,31 +9,73 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
 
 type (
 	RestClient struct {
-		key    string
-		secret string
-		prefix string
+		key          string
+		secret       string
+		prefix       string
+		client       *http.Client
+		interceptors []request.Interceptor
+		limiter      *ratelimit.Limiter
+		retry        *request.RetryPolicy
+		store        *exchange.SymbolStore
 	}
 )
 
-func NewRestClient(key, secret string) *RestClient {
-	return newRestClientWithPrefix(key, secret, "https://www.deribit.com/api/v2")
+//NewRestClient create rest client. opts can specific the http client and
+//override the api base url
+func NewRestClient(key, secret string, opts ...request.Option) *RestClient {
+	return newRestClientWithPrefix(key, secret, "https://www.deribit.com/api/v2", opts...)
 }
 
-func NewTestRestClient(key, secret string) *RestClient {
-	return newRestClientWithPrefix(key, secret, "https://test.deribit.com/api/v2")
+func NewTestRestClient(key, secret string, opts ...request.Option) *RestClient {
+	return newRestClientWithPrefix(key, secret, "https://test.deribit.com/api/v2", opts...)
 }
 
-func newRestClientWithPrefix(key, secret, prefix string) *RestClient {
-	return &RestClient{
-		key:    key,
-		secret: secret,
-		prefix: prefix,
+func newRestClientWithPrefix(key, secret, prefix string, opts ...request.Option) *RestClient {
+	cfg := request.NewConfig(opts...)
+	ret := &RestClient{
+		key:          key,
+		secret:       secret,
+		prefix:       cfg.Prefix(prefix),
+		client:       cfg.Client,
+		interceptors: cfg.Interceptors,
+		limiter:      NewRateLimiter(),
 	}
+	ret.store = ret.NewSymbolStore()
+	return ret
+}
+
+//Use append interceptors which run around every request of the client.
+//Use is not concurrent safe and should be called before sending requests
+func (rc *RestClient) Use(interceptors ...request.Interceptor) {
+	rc.interceptors = append(rc.interceptors, interceptors...)
+}
+
+//SetRateLimiter replace the rate limiter of the client, nil disable rate limit
+func (rc *RestClient) SetRateLimiter(l *ratelimit.Limiter) {
+	rc.limiter = l
+}
+
+//RateLimiter return the rate limiter of the client
+func (rc *RestClient) RateLimiter() *ratelimit.Limiter {
+	return rc.limiter
+}
+
+//SetRetryPolicy replace the retry policy of the client, nil disable retry
+func (rc *RestClient) SetRetryPolicy(p *request.RetryPolicy) {
+	rc.retry = p
+}
+
+//RetryPolicy return the retry policy of the client
+func (rc *RestClient) RetryPolicy() *request.RetryPolicy {
+	return rc.retry
 }
 
 func (rc *RestClient) Request(ctx context.Context, method string, endPoint string, params url.Values, body io.Reader, signed bool, dst interface{}) error {
@@ -41,6 +83,21 @@ func (rc *RestClient) Request(ctx context.Context, method string, endPoint strin
 		return errors.Errorf("signed rest request is not support yet")
 	}
 
+	replay, err := request.ReplayBody(body)
+	if err != nil {
+		return errors.WithMessage(err, "read body fail")
+	}
+	ctx = request.WithEndpoint(ctx, endPoint)
+	return rc.retry.Retry(ctx, method, endPoint, func() error {
+		return rc.doRequest(ctx, method, endPoint, params, replay(), dst)
+	})
+}
+
+func (rc *RestClient) doRequest(ctx context.Context, method string, endPoint string, params url.Values, body io.Reader, dst interface{}) error {
+	if err := rc.limiter.Wait(ctx, method, endPoint); err != nil {
+		return err
+	}
+
 	url := fmt.Sprintf("%s%s", rc.prefix, endPoint)
 	if len(params) != 0 {
 		url = fmt.Sprintf("%s?%s", url, params.Encode())
@@ -50,10 +107,15 @@ func (rc *RestClient) Request(ctx context.Context, method string, endPoint strin
 		return errors.WithMessage(err, "build request fail")
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	hc := rc.client
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := request.Send(hc, req, rc.interceptors...)
 	if err != nil {
 		return errors.WithMessage(err, "do http request fail")
 	}
+	rc.limiter.Observe(resp.Header)
 
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
@@ -61,17 +123,21 @@ func (rc *RestClient) Request(ctx context.Context, method string, endPoint strin
 	}
 	defer resp.Body.Close()
 
+	var r Response
+	if err := json.Unmarshal(data, &r); err == nil && r.Error.Code != 0 {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return request.NewResponseError(NewError(r.Error.Code, r.Error.Message), resp)
+		}
+		return NewError(r.Error.Code, r.Error.Message)
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return errors.Errorf("invalid statusCode %d status %s", resp.StatusCode, resp.Status)
+		return request.NewResponseError(errors.Errorf("invalid statusCode %d status %s", resp.StatusCode, resp.Status), resp)
 	}
 
-	var r Response
 	if err := json.Unmarshal(data, &r); err != nil {
 		return errors.WithMessage(err, "unmarshal json error")
 	}
-	if r.Error.Code != 0 {
-		return errors.Errorf("response error code: %d message: %s", r.Error.Code, r.Error.Message)
-	}
 
 	if err := json.Unmarshal(r.Result, &dst); err != nil {
 		return errors.WithMessage(err, "unmarshal Result error")
@@ -52,63 +52,109 @@ var (
 		OptionTypePut:  exchange.OptionTypePut,
 	}
 
-	symbolMu  = sync.Mutex{}
-	symbolMap = map[string]exchange.Symbol{}
-	rc        *RestClient
+	symbolMu      = sync.Mutex{}
+	defaultClient = NewRestClient("", "")
 
 	Currencies = []string{"BTC", "ETH"}
 )
 
 func Init(ctx context.Context, testNet bool) error {
+	var rc *RestClient
 	if testNet {
 		rc = NewTestRestClient("", "")
 	} else {
 		rc = NewRestClient("", "")
 	}
-	if err := updateSymbolMap(ctx, rc); err != nil {
+
+	if err := rc.LoadSymbols(ctx); err != nil {
 		return err
 	}
+
+	symbolMu.Lock()
+	defaultClient = rc
+	symbolMu.Unlock()
 	return nil
 }
 
 // SymbolLoop start loop update symbol map periodly
 func SymbolLoop(ctx context.Context) {
-	ticker := time.NewTicker(time.Minute * 5)
-	for {
-		select {
-		case <-ctx.Done():
-			return
-
-		case <-ticker.C:
-			UpdateSymbolMap(ctx)
-		}
-	}
+	SymbolStore().Loop(ctx, time.Minute*5, nil)
 }
 
 // UpdateSymbolMap rebuild symbol map. the symbol map update is leave to user
 func UpdateSymbolMap(ctx context.Context) error {
-	return updateSymbolMap(ctx, rc)
+	return getDefaultClient().LoadSymbols(ctx)
+}
+
+// SymbolStore return symbol store of the default client which is set by Init
+func SymbolStore() *exchange.SymbolStore {
+	return getDefaultClient().SymbolStore()
+}
+
+// SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
+
+// LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
+}
+
+// ParseSymbol parse symbol with symbol store of rc. the default client store
+// is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(sym string) (exchange.Symbol, error) {
+	return parseSymbol(rc.symbols(), sym)
+}
+
+// ParseOptionSymbol parse option symbol with symbol store of rc
+func (rc *RestClient) ParseOptionSymbol(sym string) (exchange.OptionSymbol, error) {
+	return parseOptionSymbol(rc.symbols(), sym)
+}
+
+// ParseFutureSymbol parse future symbol with symbol store of rc
+func (rc *RestClient) ParseFutureSymbol(sym string) (exchange.FuturesSymbol, error) {
+	return parseFutureSymbol(rc.symbols(), sym)
+}
+
+func (rc *RestClient) symbols() *exchange.SymbolStore {
+	if rc.store.Updated().IsZero() {
+		return SymbolStore()
+	}
+	return rc.store
+}
+
+func getDefaultClient() *RestClient {
+	symbolMu.Lock()
+	defer symbolMu.Unlock()
+	return defaultClient
+}
+
+// NewSymbolStore return a symbol store which fetch symbols of Currencies with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		ret := []exchange.Symbol{}
+		for _, c := range Currencies {
+			symbols, err := rc.Symbols(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, symbols...)
+		}
+		return ret, nil
+	}, opts...)
 }
 
 // Symbols get all symbols from symbol map
 func Symbols() []exchange.Symbol {
-	ret := []exchange.Symbol{}
-	symbolMu.Lock()
-	m := symbolMap
-	symbolMu.Unlock()
-	for _, v := range m {
-		ret = append(ret, v)
-	}
-	return ret
+	return SymbolStore().Symbols()
 }
 
 // OptionSymbolWithIndex get all option symbol with specific index from symbol map
 func OptionSymbolsWithIndex(index string) []exchange.OptionSymbol {
 	ret := []exchange.OptionSymbol{}
-	symbolMu.Lock()
-	m := symbolMap
-	symbolMu.Unlock()
-	for _, v := range m {
+	for _, v := range Symbols() {
 		sym, ok := v.(exchange.OptionSymbol)
 		if !ok {
 			continue
@@ -142,7 +188,19 @@ func NewOptionSymbol(currency string, st time.Time, strike float64, typ exchange
 }
 
 func ParseOptionSymbol(sym string) (exchange.OptionSymbol, error) {
-	ret, err := getSymbol(sym, reflect.TypeOf((*exchange.OptionSymbol)(nil)).Elem())
+	return getDefaultClient().ParseOptionSymbol(sym)
+}
+
+func ParseFutureSymbol(sym string) (exchange.FuturesSymbol, error) {
+	return getDefaultClient().ParseFutureSymbol(sym)
+}
+
+func ParseSymbol(sym string) (exchange.Symbol, error) {
+	return getDefaultClient().ParseSymbol(sym)
+}
+
+func parseOptionSymbol(store *exchange.SymbolStore, sym string) (exchange.OptionSymbol, error) {
+	ret, err := getSymbol(store, sym, reflect.TypeOf((*exchange.OptionSymbol)(nil)).Elem())
 	if err != nil {
 		return nil, err
 	}
@@ -150,30 +208,26 @@ func ParseOptionSymbol(sym string) (exchange.OptionSymbol, error) {
 	return v, nil
 }
 
-func ParseFutureSymbol(sym string) (exchange.FuturesSymbol, error) {
-	ret, err := getSymbol(sym, reflect.TypeOf((*exchange.FuturesSymbol)(nil)).Elem())
+func parseFutureSymbol(store *exchange.SymbolStore, sym string) (exchange.FuturesSymbol, error) {
+	ret, err := getSymbol(store, sym, reflect.TypeOf((*exchange.FuturesSymbol)(nil)).Elem())
 	if err != nil {
 		return nil, err
 	}
 	return ret.(exchange.FuturesSymbol), nil
 }
 
-func ParseSymbol(sym string) (exchange.Symbol, error) {
-	symbolMu.Lock()
-	defer symbolMu.Unlock()
-	ret, ok := symbolMap[sym]
-	if !ok {
+func parseSymbol(store *exchange.SymbolStore, sym string) (exchange.Symbol, error) {
+	ret, err := store.Lookup(sym)
+	if err != nil {
 		return nil, errors.Errorf("bad symbol %s", sym)
 	}
 	return ret, nil
 }
 
-func getSymbol(sym string, exType reflect.Type) (exchange.Symbol, error) {
-	symbolMu.Lock()
-	defer symbolMu.Unlock()
-	ret, ok := symbolMap[sym]
-	if !ok {
-		return nil, errors.Errorf("bad symbol %s", sym)
+func getSymbol(store *exchange.SymbolStore, sym string, exType reflect.Type) (exchange.Symbol, error) {
+	ret, err := parseSymbol(store, sym)
+	if err != nil {
+		return nil, err
 	}
 
 	typ := reflect.TypeOf(ret)
@@ -183,25 +237,6 @@ func getSymbol(sym string, exType reflect.Type) (exchange.Symbol, error) {
 	return ret, nil
 }
 
-func updateSymbolMap(ctx context.Context, restClient *RestClient) error {
-	newMap := map[string]exchange.Symbol{}
-	for _, c := range Currencies {
-		symbols, err := restClient.Symbols(ctx, c)
-		if err != nil {
-			return err
-		}
-
-		for _, s := range symbols {
-			newMap[s.String()] = s
-		}
-	}
-
-	symbolMu.Lock()
-	defer symbolMu.Unlock()
-	symbolMap = newMap
-	return nil
-}
-
 func (sym *OptionSymbol) String() string {
 	typ := "P"
 	if sym.Type() == exchange.OptionTypeCall {
@@ -94,7 +94,11 @@ func parseNotifyTicker(resp *Notify) (*rpc.Notify, error) {
 }
 
 func (tr *TickerResult) Parse() (*exchange.Ticker, error) {
-	sym, err := ParseSymbol(tr.InstrumentName)
+	return tr.parse(SymbolStore())
+}
+
+func (tr *TickerResult) parse(store *exchange.SymbolStore) (*exchange.Ticker, error) {
+	sym, err := parseSymbol(store, tr.InstrumentName)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "parse instrument_name '%s'", tr.InstrumentName)
 	}
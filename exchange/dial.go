@@ -0,0 +1,65 @@
+package exchange
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/internal/rpc"
+)
+
+type (
+	//DialConfig config how websocket stream is dialed and how frames are
+	//read and written, zero fields use the gorilla websocket default
+	DialConfig = rpc.DialConfig
+
+	//DialOption config DialConfig, it's passed to SetDialOptions of the
+	//websocket clients
+	DialOption = rpc.DialOption
+)
+
+//WithHeader add header to the handshake request
+func WithHeader(header http.Header) DialOption {
+	return rpc.WithHeader(header)
+}
+
+//WithProxy dial via proxy returned by fn
+func WithProxy(fn func(*http.Request) (*url.URL, error)) DialOption {
+	return rpc.WithProxy(fn)
+}
+
+//WithProxyURL dial via proxy u such as http://127.0.0.1:1080
+func WithProxyURL(u *url.URL) DialOption {
+	return rpc.WithProxyURL(u)
+}
+
+//WithTLSConfig use cfg for wss connection
+func WithTLSConfig(cfg *tls.Config) DialOption {
+	return rpc.WithTLSConfig(cfg)
+}
+
+//WithHandshakeTimeout set timeout of the websocket handshake
+func WithHandshakeTimeout(d time.Duration) DialOption {
+	return rpc.WithHandshakeTimeout(d)
+}
+
+//WithCompression enable permessage-deflate compression
+func WithCompression(enable bool) DialOption {
+	return rpc.WithCompression(enable)
+}
+
+//WithReadLimit set max size of message read from server
+func WithReadLimit(limit int64) DialOption {
+	return rpc.WithReadLimit(limit)
+}
+
+//WithWriteTimeout set deadline of each write
+func WithWriteTimeout(d time.Duration) DialOption {
+	return rpc.WithWriteTimeout(d)
+}
+
+//NewDialConfig return DialConfig with opts applied
+func NewDialConfig(opts ...DialOption) *DialConfig {
+	return rpc.NewDialConfig(opts...)
+}
@@ -0,0 +1,55 @@
+package exchange_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/exchange/binance/swap"
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+)
+
+func TestDialOptions(t *testing.T) {
+	proxy, _ := url.Parse("http://127.0.0.1:1080")
+	cfg := exchange.NewDialConfig(
+		exchange.WithHeader(http.Header{"X-Test": []string{"1"}}),
+		exchange.WithProxyURL(proxy),
+		exchange.WithHandshakeTimeout(time.Second),
+		exchange.WithCompression(true),
+		exchange.WithReadLimit(1024),
+		exchange.WithWriteTimeout(time.Second*2),
+	)
+	if cfg.Header.Get("X-Test") != "1" || cfg.Proxy == nil || cfg.HandshakeTimeout != time.Second ||
+		!cfg.EnableCompression || cfg.ReadLimit != 1024 || cfg.WriteTimeout != time.Second*2 {
+		t.Errorf("bad dial config %+v", cfg)
+	}
+
+	headers := make(chan http.Header, 1)
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		headers <- c.Header()
+		c.Serve(context.Background())
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	client := binance.NewNotifyClient(srv.URL, swap.NewCodeC(), make(chan interface{}, 1), nil)
+	client.SetDialOptions(exchange.WithHeader(http.Header{"X-Test": []string{"1"}}))
+	if err := client.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer client.Close()
+
+	select {
+	case h := <-headers:
+		if h.Get("X-Test") != "1" {
+			t.Errorf("header is not sent %v", h)
+		}
+	case <-ctx.Done():
+		t.Fatalf("wait handshake timeout")
+	}
+}
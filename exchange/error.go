@@ -1,6 +1,7 @@
 package exchange
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -15,6 +16,38 @@ type (
 	ErrBadExResp struct {
 		Err error
 	}
+
+	//ErrAPI is an exchange api error which is classified to Kind. Kind is
+	//one of the ErrXXX kind vars or nil if the error is unclassified. error
+	//of adapters unwrap to ErrAPI so errors.As(err, &apiErr) work for all
+	//exchanges
+	ErrAPI struct {
+		Kind    error
+		Code    string
+		Message string
+	}
+)
+
+//normalized error kinds. adapters map native error codes to them so that
+//errors.Is(err, exchange.ErrRateLimited) work for all exchanges
+var (
+	ErrRateLimited         = errors.New("rate limited")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrPostOnlyRejected    = errors.New("post only rejected")
+	ErrAuthFailed          = errors.New("auth failed")
+	ErrInvalidSymbol       = errors.New("invalid symbol")
+	ErrOverloaded          = errors.New("exchange overloaded")
+
+	errorKinds = []error{
+		ErrRateLimited,
+		ErrInsufficientBalance,
+		ErrOrderNotFound,
+		ErrPostOnlyRejected,
+		ErrAuthFailed,
+		ErrInvalidSymbol,
+		ErrOverloaded,
+	}
 )
 
 func NewBadArg(msg string, arg interface{}) error {
@@ -48,3 +81,46 @@ func (ebe *ErrBadExResp) Is(target error) bool {
 	_, ok := target.(*ErrBadExResp)
 	return ok
 }
+
+//Unwrap make the exchange error kind of wrapped error visible to errors.Is
+func (ebe *ErrBadExResp) Unwrap() error {
+	return ebe.Err
+}
+
+//NewAPIError create an api error with kind. kind can be nil
+func NewAPIError(kind error, code string, message string) error {
+	return &ErrAPI{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+}
+
+func (ea *ErrAPI) Error() string {
+	if ea.Kind == nil {
+		return fmt.Sprintf("api error code=%s message='%s'", ea.Code, ea.Message)
+	}
+	return fmt.Sprintf("api error %s code=%s message='%s'", ea.Kind, ea.Code, ea.Message)
+}
+
+//Is match any *ErrAPI and the error kind
+func (ea *ErrAPI) Is(target error) bool {
+	if _, ok := target.(*ErrAPI); ok {
+		return true
+	}
+	return ea.Kind != nil && target == ea.Kind
+}
+
+//ErrorKind return the normalized kind of err, nil is returned if err is
+//not classified
+func ErrorKind(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestErrorKind(t *testing.T) {
+	err := pkgerrors.WithMessage(NewAPIError(ErrRateLimited, "-1003", "too many requests"), "request fail")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("error should be rate limited %s", err.Error())
+	}
+	if !errors.Is(err, &ErrAPI{}) {
+		t.Errorf("error should be ErrAPI %s", err.Error())
+	}
+	if errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("error should not be order not found %s", err.Error())
+	}
+	if kind := ErrorKind(err); kind != ErrRateLimited {
+		t.Errorf("bad error kind %v", kind)
+	}
+
+	if kind := ErrorKind(NewAPIError(nil, "1", "unknown")); kind != nil {
+		t.Errorf("unclassified error kind should be nil %v", kind)
+	}
+	if kind := ErrorKind(nil); kind != nil {
+		t.Errorf("nil error kind should be nil %v", kind)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
 
@@ -63,6 +64,7 @@ func (rc *RestClient) Candles(ctx context.Context, cr *CandleReq) ([]Candle, err
 	var ret []Candle
 
 	endPoint := fmt.Sprintf("/markets/%s/candles", cr.markName)
+	ctx = request.WithEndpoint(ctx, "/markets/{market_name}/candles")
 	values := url.Values{}
 	values.Add("resolution", fmt.Sprintf("%d", cr.resolution))
 	if cr.startTime != 0 {
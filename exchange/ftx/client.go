@@ -11,17 +11,25 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"time"
 
-	"github.com/pkg/errors"
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/clock"
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+	"github.com/NadiaSama/ccexgo/misc/request"
 )
 
 type (
 	RestClient struct {
-		key        string
-		secret     string
-		subAccount string
-		prefix     string
+		key          string
+		secret       string
+		subAccount   string
+		prefix       string
+		client       *http.Client
+		interceptors []request.Interceptor
+		limiter      *ratelimit.Limiter
+		retry        *request.RetryPolicy
+		clock        *clock.Clock
+		store        *exchange.SymbolStore
 	}
 
 	Wrap struct {
@@ -36,46 +44,110 @@ const (
 	ftxRSAddr   = "https://ftx.com/api"
 )
 
-func NewRestClient(key, secret string) *RestClient {
-	return &RestClient{
-		key:    key,
-		secret: secret,
-		prefix: ftxRSAddr,
-	}
+//NewRestClient create rest client. opts can specific the http client and
+//override the api base url
+func NewRestClient(key, secret string, opts ...request.Option) *RestClient {
+	return NewClientWithSubAccount(key, secret, "", opts...)
 }
 
-func NewClientWithSubAccount(key, secret, subAccount string) *RestClient {
-	return &RestClient{
-		key:        key,
-		secret:     secret,
-		subAccount: subAccount,
-		prefix:     ftxRSAddr,
+func NewClientWithSubAccount(key, secret, subAccount string, opts ...request.Option) *RestClient {
+	cfg := request.NewConfig(opts...)
+	ret := &RestClient{
+		key:          key,
+		secret:       secret,
+		subAccount:   subAccount,
+		prefix:       cfg.Prefix(ftxRSAddr),
+		client:       cfg.Client,
+		interceptors: cfg.Interceptors,
+		limiter:      NewRateLimiter(),
 	}
+	ret.store = ret.NewSymbolStore()
+	return ret
+}
+
+//Use append interceptors which run around every request of the client.
+//Use is not concurrent safe and should be called before sending requests
+func (rc *RestClient) Use(interceptors ...request.Interceptor) {
+	rc.interceptors = append(rc.interceptors, interceptors...)
+}
+
+//SetRateLimiter replace the rate limiter of the client, nil disable rate limit
+func (rc *RestClient) SetRateLimiter(l *ratelimit.Limiter) {
+	rc.limiter = l
+}
+
+//RateLimiter return the rate limiter of the client
+func (rc *RestClient) RateLimiter() *ratelimit.Limiter {
+	return rc.limiter
+}
+
+//SetRetryPolicy replace the retry policy of the client, nil disable retry
+func (rc *RestClient) SetRetryPolicy(p *request.RetryPolicy) {
+	rc.retry = p
+}
+
+//RetryPolicy return the retry policy of the client
+func (rc *RestClient) RetryPolicy() *request.RetryPolicy {
+	return rc.retry
+}
+
+//SetClock set the clock which provide timestamp of signed requests, nil use
+//local time
+func (rc *RestClient) SetClock(c *clock.Clock) {
+	rc.clock = c
+}
+
+//Clock return the clock of the client
+func (rc *RestClient) Clock() *clock.Clock {
+	return rc.clock
 }
 
 func (rc *RestClient) request(ctx context.Context, method string, endPoint string, params url.Values, body io.Reader, sign bool, dst interface{}) error {
-	req, err := rc.buildRequest(ctx, method, endPoint, params, body, sign)
+	replay, err := request.ReplayBody(body)
 	if err != nil {
 		return err
 	}
+	ctx = request.WithEndpoint(ctx, endPoint)
+	return rc.retry.Retry(ctx, method, endPoint, func() error {
+		return rc.doRequest(ctx, method, endPoint, params, replay(), sign, dst)
+	})
+}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
+func (rc *RestClient) doRequest(ctx context.Context, method string, endPoint string, params url.Values, body io.Reader, sign bool, dst interface{}) error {
+	if err := rc.limiter.Wait(ctx, method, endPoint); err != nil {
 		return err
 	}
-
-	data, err := ioutil.ReadAll(resp.Body)
+	req, err := rc.buildRequest(ctx, method, endPoint, params, body, sign)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
+	return request.DoWithClient(rc.client, req, func(resp *http.Response, ierr error) error {
+		if ierr != nil {
+			return ierr
+		}
+		rc.limiter.Observe(resp.Header)
+
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		return decodeResponse(resp, data, dst)
+	}, rc.interceptors...)
+}
+
+func decodeResponse(resp *http.Response, data []byte, dst interface{}) error {
 	var r Wrap
 	if err := json.Unmarshal(data, &r); err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return request.NewResponseError(&APIError{Message: string(data), Status: resp.StatusCode}, resp)
+		}
 		return err
 	}
 	if !r.Success {
-		return errors.Errorf("response error %v", r)
+		return request.NewResponseError(&APIError{Message: r.Error, Status: resp.StatusCode}, resp)
 	}
 
 	if err := json.Unmarshal(r.Result, &dst); err != nil {
@@ -101,7 +173,7 @@ func (rc *RestClient) buildRequest(ctx context.Context, method string, endPoint
 	}
 
 	if sign {
-		ts := time.Now().UnixNano() / 1e6
+		ts := rc.clock.Now().UnixNano() / 1e6
 		encStr := fmt.Sprintf("%d%s%s", ts, method, u.Path)
 		if u.RawQuery != "" {
 			encStr += "?" + u.RawQuery
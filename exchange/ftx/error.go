@@ -0,0 +1,69 @@
+package ftx
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
+type (
+	//APIError error message of unsuccessful response
+	APIError struct {
+		Message string
+		Status  int
+	}
+)
+
+var (
+	//ftx do not have error code, the error is classified by message prefix
+	messageKinds = []struct {
+		prefix string
+		kind   error
+	}{
+		{"Not enough balances", exchange.ErrInsufficientBalance},
+		{"Order not found", exchange.ErrOrderNotFound},
+		{"Order already closed", exchange.ErrOrderNotFound},
+		{"Do not send more than", exchange.ErrRateLimited},
+		{"Please retry request", exchange.ErrOverloaded},
+		{"Not logged in", exchange.ErrAuthFailed},
+		{"No such market", exchange.ErrInvalidSymbol},
+		{"Post only order", exchange.ErrPostOnlyRejected},
+	}
+)
+
+func (ae *APIError) Error() string {
+	return fmt.Sprintf("api error status:%d message:'%s'", ae.Status, ae.Message)
+}
+
+//Kind return the normalized exchange error kind, nil if unclassified
+func (ae *APIError) Kind() error {
+	for _, mk := range messageKinds {
+		if strings.HasPrefix(ae.Message, mk.prefix) {
+			return mk.kind
+		}
+	}
+
+	switch ae.Status {
+	case http.StatusTooManyRequests:
+		return exchange.ErrRateLimited
+	case http.StatusUnauthorized:
+		return exchange.ErrAuthFailed
+	case http.StatusServiceUnavailable:
+		return exchange.ErrOverloaded
+	}
+	return nil
+}
+
+//Is match any *APIError
+func (ae *APIError) Is(target error) bool {
+	_, ok := target.(*APIError)
+	return ok
+}
+
+//Unwrap return the error as *exchange.ErrAPI so that errors.Is match the
+//normalized error kind. ftx do not have error code, the code is empty
+func (ae *APIError) Unwrap() error {
+	return exchange.NewAPIError(ae.Kind(), "", ae.Message)
+}
@@ -0,0 +1,35 @@
+package ftx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestAPIError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.Deactivate()
+
+	httpmock.RegisterResponder(http.MethodGet, "https://ftx.com/api/orders/1",
+		httpmock.NewStringResponder(404, `{"success": false, "error": "Order not found"}`))
+
+	client := NewRestClient("", "")
+	_, err := client.OrderFetch(context.Background(), &exchange.Order{ID: exchange.NewIntID(1)})
+	if !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("expect order not found got %v", err)
+	}
+
+	var ae *APIError
+	if !errors.As(err, &ae) || ae.Status != 404 {
+		t.Errorf("expect APIError got %v", err)
+	}
+
+	var apiErr *exchange.ErrAPI
+	if !errors.As(err, &apiErr) || apiErr.Kind != exchange.ErrOrderNotFound || apiErr.Code != "" {
+		t.Errorf("expect exchange.ErrAPI got %v", err)
+	}
+}
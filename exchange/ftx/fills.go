@@ -53,6 +53,22 @@ func (fc *FillChannel) String() string {
 	return fc.symbol.String()
 }
 
+//Normalize implement exchange.Normalizer, the fill is converted to private
+//trade whose Fee is negative if fee is paid
+func (f *Fill) Normalize() (exchange.DataKind, interface{}, error) {
+	return exchange.DataKindFills, &exchange.Trade{
+		ID:      f.TradeID.String(),
+		OrderID: f.OrderID.String(),
+		Symbol:  f.Symbol,
+		Price:   f.Price,
+		Amount:  f.Size,
+		Fee:     f.Fee.Neg(),
+		Time:    f.Time,
+		Side:    f.Side,
+		Raw:     f,
+	}, nil
+}
+
 func parseFillInternal(notify *FillNotify) (*Fill, error) {
 	side, ok := sideMap[notify.Side]
 	if !ok {
@@ -0,0 +1,55 @@
+package ftx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureMarket(t *testing.T) {
+	opts, err := fixture.Options("testdata/fixture")
+	if err != nil {
+		t.Fatalf("load fixture fail %s", err.Error())
+	}
+	rc := NewRestClient("", "", opts...)
+	ctx := context.Background()
+
+	markets, err := rc.Markets(ctx)
+	if err != nil {
+		t.Fatalf("fetch markets fail %s", err.Error())
+	}
+	if len(markets) != 1 {
+		t.Fatalf("bad markets %v", markets)
+	}
+	m := markets[0]
+	if m.Name != "BTC/USD" || m.Type != "spot" || m.PriceIncrement != 1.0 || m.SizeIncrement != 0.0001 {
+		t.Errorf("bad market %+v", m)
+	}
+	sym, err := m.ToSymbol()
+	if err != nil {
+		t.Fatalf("convert market fail %s", err.Error())
+	}
+	if !sym.AmountMin().Equal(decimal.RequireFromString("0.0001")) {
+		t.Errorf("bad amount min %s", sym.AmountMin())
+	}
+
+	futures, err := rc.Futures(ctx)
+	if err != nil {
+		t.Fatalf("fetch futures fail %s", err.Error())
+	}
+	if len(futures) != 1 {
+		t.Fatalf("bad futures %v", futures)
+	}
+	f := futures[0]
+	if f.Name != "BTC-PERP" || !f.Perpetual || f.Underlying != "BTC" || f.Mark != 40011.5 {
+		t.Errorf("bad future %+v", f)
+	}
+
+	if _, err := rc.Balances(ctx); !errors.Is(err, exchange.ErrAuthFailed) {
+		t.Errorf("expect auth fail got %v", err)
+	}
+}
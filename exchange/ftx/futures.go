@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/NadiaSama/ccexgo/misc/request"
 )
 
 type (
@@ -39,6 +41,7 @@ type (
 func (rc *RestClient) Future(ctx context.Context, sym string) (*FutureInfo, error) {
 	var info FutureInfo
 	path := fmt.Sprintf("/futures/%s", sym)
+	ctx = request.WithEndpoint(ctx, "/futures/{future_name}")
 	if err := rc.request(ctx, http.MethodGet, path, nil, nil, false, &info); err != nil {
 		return nil, err
 	}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
 )
 
 type (
@@ -69,6 +70,7 @@ func (rc *RestClient) Books(ctx context.Context, req *BookReq) (*Depth, error) {
 	}
 
 	uri := fmt.Sprintf("/markets/%s/orderbook", req.Market)
+	ctx = request.WithEndpoint(ctx, "/markets/{market_name}/orderbook")
 	if err := rc.request(ctx, http.MethodGet, uri, values, nil, false, &ret); err != nil {
 		return nil, err
 	}
@@ -194,7 +194,7 @@ func (cc *CodeC) parseOrder(raw []byte) (*exchange.Order, error) {
 	if err := json.Unmarshal(raw, &order); err != nil {
 		return nil, err
 	}
-	return parseOrderInternal(&order)
+	return parseOrderInternal(defaultClient, &order)
 }
 
 func (cc *CodeC) parseFills(raw []byte) (*Fill, error) {
@@ -9,8 +9,7 @@ import (
 )
 
 func TestMessageDecode(t *testing.T) {
-	symbolMap = make(map[string]exchange.Symbol)
-	symbolMap["ADA-PERP"] = newSwapSymbol("ADA")
+	SymbolStore().Set([]exchange.Symbol{newSwapSymbol("ADA")})
 	cc := NewCodeC()
 
 	e := []byte(`{"channel": "", "market": "", "type": "error", "code": 1001, "msg": "not login"}`)
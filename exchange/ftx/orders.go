@@ -11,10 +11,13 @@ import (
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 )
 
+var _ exchange.Trader = (*RestClient)(nil)
+
 type (
 	Order struct {
 		CreatedAt     string          `json:"createdAt"`
@@ -42,6 +45,8 @@ type (
 		Type     string  `json:"type"`
 		Size     float64 `json:"size"`
 		ClientID string  `json:"clientId,omitempty"`
+		IOC      bool    `json:"ioc,omitempty"`
+		PostOnly bool    `json:"postOnly,omitempty"`
 	}
 
 	OrdersHistoryReq struct {
@@ -99,6 +104,10 @@ func (oc *OrderChannel) String() string {
 }
 
 func (rc *RestClient) OrderNew(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
 	side, ok := sideRMap[req.Side]
 	if !ok {
 		return nil, errors.Errorf("unkown orderside '%d'", req.Side)
@@ -122,6 +131,26 @@ func (rc *RestClient) OrderNew(ctx context.Context, req *exchange.OrderRequest,
 		Type:     typ,
 		ClientID: cid,
 	}
+
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			or.PostOnly = t.PostOnly
+
+		case *exchange.TimeInForceOption:
+			switch t.Flag {
+			case exchange.TimeInForceGTC:
+			case exchange.TimeInForceIOC:
+				or.IOC = true
+			default:
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
+			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
 	b, _ := json.Marshal(or)
 	buf := bytes.NewBuffer(b)
 
@@ -133,9 +162,9 @@ func (rc *RestClient) OrderNew(ctx context.Context, req *exchange.OrderRequest,
 }
 
 func (rc *RestClient) parseOrder(o *Order) (*exchange.Order, error) {
-	return parseOrderInternal(o)
+	return parseOrderInternal(rc, o)
 }
-func parseOrderInternal(o *Order) (*exchange.Order, error) {
+func parseOrderInternal(rc *RestClient, o *Order) (*exchange.Order, error) {
 	ct, err := time.Parse("2006-01-02T15:04:05.000000Z07:00", o.CreatedAt)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "bad create time '%s'", o.CreatedAt)
@@ -151,7 +180,7 @@ func parseOrderInternal(o *Order) (*exchange.Order, error) {
 		}
 	}
 
-	symbol, err := ParseSymbol(o.Market)
+	symbol, err := rc.ParseSymbol(o.Market)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "parse symbol fail")
 	}
@@ -186,6 +215,7 @@ func parseOrderInternal(o *Order) (*exchange.Order, error) {
 //OrderCancel only ID field is required
 func (rc *RestClient) OrderCancel(ctx context.Context, order *exchange.Order) error {
 	endPoint := fmt.Sprintf("%s/%s", orderEndPoint, order.ID.String())
+	ctx = request.WithEndpoint(ctx, "/orders/{order_id}")
 
 	if err := rc.request(ctx, http.MethodDelete, endPoint, nil, nil, true, nil); err != nil {
 		return err
@@ -196,6 +226,7 @@ func (rc *RestClient) OrderCancel(ctx context.Context, order *exchange.Order) er
 //OrderFetch only ID field is required
 func (rc *RestClient) OrderFetch(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
 	endPoint := fmt.Sprintf("%s/%s", orderEndPoint, order.ID.String())
+	ctx = request.WithEndpoint(ctx, "/orders/{order_id}")
 
 	var resp Order
 	if err := rc.request(ctx, http.MethodGet, endPoint, nil, nil, true, &resp); err != nil {
@@ -228,6 +259,29 @@ func (rc *RestClient) Orders(ctx context.Context, symbol exchange.Symbol) ([]*ex
 	return ret, nil
 }
 
+//CreateOrder implement exchange.Trader interface via OrderNew
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	return rc.OrderNew(ctx, req, options...)
+}
+
+//CancelOrder cancel the order and return the latest order info
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	if err := rc.OrderCancel(ctx, order); err != nil {
+		return nil, err
+	}
+	return rc.OrderFetch(ctx, order)
+}
+
+//FetchOrder implement exchange.Trader interface via OrderFetch
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	return rc.OrderFetch(ctx, order)
+}
+
+//OpenOrders implement exchange.Trader interface via Orders
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	return rc.Orders(ctx, symbol)
+}
+
 func parseTime(ts string) (time.Time, error) {
 	ct, err := time.Parse("2006-01-02T15:04:05.000000Z07:00", ts)
 	if err != nil {
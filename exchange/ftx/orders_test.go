@@ -58,7 +58,7 @@ func TestOrdersNew(t *testing.T) {
 	ctx := context.Background()
 	client := NewRestClient("", "")
 	xrpS := newSwapSymbol("XRP")
-	symbolMap["XRP-PERP"] = xrpS
+	client.SymbolStore().Set([]exchange.Symbol{xrpS})
 	req := exchange.OrderRequest{
 		Symbol: xrpS,
 		Amount: decimal.NewFromFloat(10.1234),
@@ -116,7 +116,7 @@ func TestOrderFetch(t *testing.T) {
 	ctx := context.Background()
 	client := NewRestClient("", "")
 	xrpS := newSwapSymbol("XRP")
-	symbolMap["XRP-PERP"] = xrpS
+	client.SymbolStore().Set([]exchange.Symbol{xrpS})
 
 	order, err := client.OrderFetch(ctx, &exchange.Order{
 		ID: exchange.NewIntID(9596912),
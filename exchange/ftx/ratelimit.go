@@ -0,0 +1,19 @@
+package ftx
+
+import (
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+)
+
+const (
+	BucketDefault = "default"
+)
+
+//NewRateLimiter return the default rate limiter which allow 30 requests
+//every second
+func NewRateLimiter() *ratelimit.Limiter {
+	return ratelimit.NewLimiter(ratelimit.PolicyBlock,
+		ratelimit.NewBucket(BucketDefault, 30, time.Second),
+	).SetDefault(ratelimit.Cost{Bucket: BucketDefault, Weight: 1})
+}
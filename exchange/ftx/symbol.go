@@ -3,7 +3,6 @@ package ftx
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
@@ -33,12 +32,11 @@ const (
 )
 
 var (
-	mu        sync.Mutex
-	symbolMap map[string]exchange.Symbol
+	defaultClient = NewRestClient("", "")
 )
 
 func Init(ctx context.Context) error {
-	if err := initSymbols(ctx); err != nil {
+	if err := defaultClient.LoadSymbols(ctx); err != nil {
 		return err
 	}
 
@@ -47,45 +45,69 @@ func Init(ctx context.Context) error {
 			next := time.Now().Add(time.Hour)
 			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour(), 0, 5, 0, next.Location())
 			time.Sleep(time.Until(next))
-			initSymbols(ctx)
+			defaultClient.LoadSymbols(ctx)
 		}
 	}()
 	return nil
 }
 
+//SymbolStore return symbol store of the default client which is used by ParseSymbol
+func SymbolStore() *exchange.SymbolStore {
+	return defaultClient.SymbolStore()
+}
+
 func ParseSymbol(symbol string) (exchange.Symbol, error) {
-	mu.Lock()
-	defer mu.Unlock()
-	s, ok := symbolMap[symbol]
-	if !ok {
+	return defaultClient.ParseSymbol(symbol)
+}
+
+//SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
+
+//LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
+}
+
+//ParseSymbol parse symbol with symbol store of rc. the default client store
+//is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(symbol string) (exchange.Symbol, error) {
+	store := rc.store
+	if store.Updated().IsZero() {
+		store = defaultClient.store
+	}
+	s, err := store.Lookup(symbol)
+	if err != nil {
 		return nil, errors.Errorf("bad %s", symbol)
 	}
 	return s, nil
 }
 
-func initSymbols(ctx context.Context) error {
-	d := make(map[string]exchange.Symbol, 0)
-	if err := initSpotSymbols(ctx, d); err != nil {
-		return err
-	}
-
-	if err := initFutureSymbol(ctx, d); err != nil {
-		return err
-	}
+//NewSymbolStore return a symbol store which fetch spot and futures symbols with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		spots, err := rc.spotSymbols(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	mu.Lock()
-	defer mu.Unlock()
-	symbolMap = d
-	return nil
+		futures, err := rc.futureSymbols(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return append(spots, futures...), nil
+	}, opts...)
 }
 
-func initSpotSymbols(ctx context.Context, dst map[string]exchange.Symbol) error {
-	rc := NewRestClient("", "")
+func (rc *RestClient) spotSymbols(ctx context.Context) ([]exchange.Symbol, error) {
 	markets, err := rc.Markets(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var ret []exchange.Symbol
 	for i := range markets {
 		m := markets[i]
 
@@ -95,20 +117,20 @@ func initSpotSymbols(ctx context.Context, dst map[string]exchange.Symbol) error
 
 		s, err := m.ToSymbol()
 		if err != nil {
-			return errors.WithMessagef(err, "parse market %s fail", m.Name)
+			return nil, errors.WithMessagef(err, "parse market %s fail", m.Name)
 		}
-		dst[s.String()] = s
+		ret = append(ret, s)
 	}
-	return nil
+	return ret, nil
 }
 
-func initFutureSymbol(ctx context.Context, dst map[string]exchange.Symbol) error {
-	rc := NewRestClient("", "")
+func (rc *RestClient) futureSymbols(ctx context.Context) ([]exchange.Symbol, error) {
 	futures, err := rc.Futures(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var ret []exchange.Symbol
 	for i := range futures {
 		info := futures[i]
 		symbol, err := info.ToSymbol()
@@ -117,18 +139,20 @@ func initFutureSymbol(ctx context.Context, dst map[string]exchange.Symbol) error
 		}
 
 		if err != nil {
-			return errors.WithMessagef(err, "parse futures %s fail", info.Name)
+			return nil, errors.WithMessagef(err, "parse futures %s fail", info.Name)
 		}
-		dst[symbol.String()] = symbol
+		ret = append(ret, symbol)
 	}
-	return nil
+	return ret, nil
 }
 
+//ToSymbol convert market to symbol. the min order size of ftx is the size
+//increment, minProvideSize only apply to post only orders
 func (m *Market) ToSymbol() (exchange.Symbol, error) {
 	cfg := exchange.SymbolConfig{
 		PricePrecision:  decimal.NewFromFloat(m.PriceIncrement),
 		AmountPrecision: decimal.NewFromFloat(m.SizeIncrement),
-		AmountMin:       decimal.NewFromFloat(m.MinProvideSize),
+		AmountMin:       decimal.NewFromFloat(m.SizeIncrement),
 	}
 	switch m.Type {
 	case typeSpot:
@@ -152,6 +176,7 @@ func (ss *SpotSymbol) String() string {
 func (info *FutureInfo) ToSymbol() (exchange.Symbol, error) {
 	cfg := exchange.SymbolConfig{
 		AmountPrecision: decimal.NewFromFloat(info.SizeIncrement),
+		AmountMin:       decimal.NewFromFloat(info.SizeIncrement),
 		PricePrecision:  decimal.NewFromFloat(info.PriceIncrement),
 	}
 	if info.Type == typeFuture || info.Type == typeMove {
@@ -0,0 +1,26 @@
+package ftx
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	serverTimeEndPoint = "/time"
+)
+
+//ServerTime return ftx server time, it can be used as clock.Source
+func (rc *RestClient) ServerTime(ctx context.Context) (time.Time, error) {
+	var ts string
+	if err := rc.request(ctx, http.MethodGet, serverTimeEndPoint, nil, nil, false, &ts); err != nil {
+		return time.Time{}, err
+	}
+	ret, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, errors.WithMessagef(err, "bad server time '%s'", ts)
+	}
+	return ret, nil
+}
@@ -7,15 +7,17 @@ import (
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/internal/rpc"
+	"github.com/NadiaSama/ccexgo/misc/clock"
 	"github.com/pkg/errors"
 )
 
 type (
 	WSClient struct {
 		*exchange.WSClient
-		data   chan interface{}
+		*exchange.Delivery
 		key    string
 		secret string
+		clock  *clock.Clock
 	}
 
 	subscribeResult struct {
@@ -35,38 +37,22 @@ func NewWSClient(key, secret string, data chan interface{}) *WSClient {
 		secret: secret,
 	}
 	ret.WSClient = exchange.NewWSClient(ftxWSAddr, NewCodeC(), ret)
-	ret.data = data
+	ret.WSClient.SetHeartbeat(&rpc.Heartbeat{
+		Interval:    time.Second * 15,
+		Ping:        rpc.PingCall("", "ping", &callParam{OP: "ping"}),
+		IdleTimeout: time.Minute,
+	})
+	ret.Delivery = exchange.NewDelivery(data, exchange.OverflowBlock)
 	return ret
 }
 
-func (ws *WSClient) Run(ctx context.Context) error {
-	if err := ws.WSClient.Run(ctx); err != nil {
-		return err
-	}
-
-	go func() {
-		ticker := time.NewTicker(time.Second * 15)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-
-			case <-ws.Done():
-				return
-
-			case <-ticker.C:
-				param := &callParam{
-					OP: "ping",
-				}
-				ws.Call(ctx, "", "ping", &param, nil)
-			}
-		}
-	}()
-	return nil
+//SetClock set the clock which provide timestamp of login, nil use local time
+func (ws *WSClient) SetClock(c *clock.Clock) {
+	ws.clock = c
 }
 
 func (ws *WSClient) Auth(ctx context.Context) error {
-	ts := time.Now().UnixNano() / 1e6
+	ts := ws.clock.Now().UnixNano() / 1e6
 	es := fmt.Sprintf("%dwebsocket_login", ts)
 	param := authParam{
 		OP: "login",
@@ -235,9 +221,9 @@ func (ws *WSClient) Handle(ctx context.Context, notify *rpc.Notify) {
 	// 	return
 	// }
 
-	ws.data <- &exchange.WSNotify{
+	ws.Deliver(ctx, &exchange.WSNotify{
 		Exchange: ftxExchange,
 		Chan:     notify.Method,
 		Data:     notify.Params,
-	}
+	})
 }
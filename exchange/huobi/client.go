@@ -11,10 +11,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/clock"
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
 	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
@@ -30,76 +33,163 @@ const (
 
 type (
 	RestClient struct {
-		key     string
-		secret  string
-		apiHost string
+		key          string
+		secret       string
+		apiHost      string
+		scheme       string
+		client       *http.Client
+		limiter      *ratelimit.Limiter
+		interceptors []request.Interceptor
+		retry        *request.RetryPolicy
+		clock        *clock.Clock
 	}
 
 	RestResponse struct {
-		Status string      `json:"status"`
-		Code   int         `json:"code"`
-		Data   interface{} `json:"data"`
+		Status          string      `json:"status"`
+		Code            int         `json:"code"`
+		Data            interface{} `json:"data"`
+		ErrCode         string      `json:"err-code"`
+		ErrMsg          string      `json:"err-msg"`
+		ContractErrCode int         `json:"err_code"`
+		ContractErrMsg  string      `json:"err_msg"`
 	}
 )
 
-func NewRestClient(key, secret, host string) *RestClient {
+//NewRestClient create rest client which send requests to host. opts can
+//specific the http client and override the host
+func NewRestClient(key, secret, host string, opts ...request.Option) *RestClient {
+	cfg := request.NewConfig(opts...)
+	scheme, host := cfg.SchemeHost(scheme, host)
 	return &RestClient{
-		key:     key,
-		secret:  secret,
-		apiHost: host,
+		key:          key,
+		secret:       secret,
+		apiHost:      host,
+		scheme:       scheme,
+		client:       cfg.Client,
+		interceptors: cfg.Interceptors,
 	}
 }
 
+//Use append interceptors which run around every request of the client.
+//Use is not concurrent safe and should be called before sending requests
+func (rc *RestClient) Use(interceptors ...request.Interceptor) {
+	rc.interceptors = append(rc.interceptors, interceptors...)
+}
+
+//SetRateLimiter replace the rate limiter of the client, nil disable rate limit
+func (rc *RestClient) SetRateLimiter(l *ratelimit.Limiter) {
+	rc.limiter = l
+}
+
+//RateLimiter return the rate limiter of the client
+func (rc *RestClient) RateLimiter() *ratelimit.Limiter {
+	return rc.limiter
+}
+
+//SetRetryPolicy replace the retry policy of the client, nil disable retry
+func (rc *RestClient) SetRetryPolicy(p *request.RetryPolicy) {
+	rc.retry = p
+}
+
+//RetryPolicy return the retry policy of the client
+func (rc *RestClient) RetryPolicy() *request.RetryPolicy {
+	return rc.retry
+}
+
+//SetClock set the clock which provide timestamp of signed requests, nil use
+//local time
+func (rc *RestClient) SetClock(c *clock.Clock) {
+	rc.clock = c
+}
+
+//Clock return the clock of the client
+func (rc *RestClient) Clock() *clock.Clock {
+	return rc.clock
+}
+
 func (rc *RestClient) RequestWithRawResp(ctx context.Context, method string, endPoint string, param url.Values, body io.Reader, sign bool, dst interface{}) error {
-	req, err := rc.buildRequest(ctx, method, rc.apiHost, endPoint, param, body, sign)
+	return rc.request(ctx, method, endPoint, param, body, sign, true, dst)
+}
+
+func (rc *RestClient) Request(ctx context.Context, method string, endPoint string, param url.Values, body io.Reader, sign bool, dst interface{}) error {
+	return rc.request(ctx, method, endPoint, param, body, sign, false, dst)
+}
+
+//request send the request with retry policy. if raw is true the response is
+//decoded into dst directly otherwise it's decoded into RestResponse.Data
+func (rc *RestClient) request(ctx context.Context, method string, endPoint string, param url.Values, body io.Reader, sign bool, raw bool, dst interface{}) error {
+	replay, err := request.ReplayBody(body)
 	if err != nil {
 		return err
 	}
-	return request.DoReqWithCtx(req, func(resp *http.Response, ierr error) error {
-		if ierr != nil {
-			return ierr
-		}
-		content, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-
-		if err := json.Unmarshal(content, dst); err != nil {
-			return errors.WithMessagef(err, "unmarshal %s fail", string(content))
-		}
-
-		return nil
+	ctx = request.WithEndpoint(ctx, endPoint)
+	return rc.retry.Retry(ctx, method, endPoint, func() error {
+		return rc.doRequest(ctx, method, endPoint, param, replay(), sign, raw, dst)
 	})
 }
 
-func (rc *RestClient) Request(ctx context.Context, method string, endPoint string, param url.Values, body io.Reader, sign bool, dst interface{}) error {
+func (rc *RestClient) doRequest(ctx context.Context, method string, endPoint string, param url.Values, body io.Reader, sign bool, raw bool, dst interface{}) error {
+	if err := rc.limiter.Wait(ctx, method, endPoint); err != nil {
+		return err
+	}
 	req, err := rc.buildRequest(ctx, method, rc.apiHost, endPoint, param, body, sign)
 	if err != nil {
 		return err
 	}
-	return request.DoReqWithCtx(req, func(resp *http.Response, ierr error) error {
+	return request.DoWithClient(rc.client, req, func(resp *http.Response, ierr error) error {
 		if ierr != nil {
 			return ierr
 		}
+		rc.limiter.Observe(resp.Header)
 		content, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			return err
 		}
 		defer resp.Body.Close()
 
-		rr := RestResponse{
-			Data: dst,
-		}
-		if err := json.Unmarshal(content, &rr); err != nil {
-			return errors.WithMessagef(err, "unmarshal %s fail", string(content))
+		err = decodeResponse(content, raw, dst)
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			if err == nil {
+				err = NewError(fmt.Sprintf("rest return status %d %s", resp.StatusCode, string(content)))
+			}
+			return request.NewResponseError(err, resp)
 		}
+		return err
+	}, rc.interceptors...)
+}
 
-		if (rr.Status != "" && rr.Status != "ok") || (rr.Code != 0 && rr.Code != 200) {
-			return errors.Errorf("rest return error %s", string(content))
+func decodeResponse(content []byte, raw bool, dst interface{}) error {
+	if raw {
+		if err := json.Unmarshal(content, dst); err != nil {
+			return errors.WithMessagef(err, "unmarshal %s fail", string(content))
 		}
 		return nil
-	})
+	}
+
+	rr := RestResponse{
+		Data: dst,
+	}
+	if err := json.Unmarshal(content, &rr); err != nil {
+		return errors.WithMessagef(err, "unmarshal %s fail", string(content))
+	}
+
+	if (rr.Status != "" && rr.Status != "ok") || (rr.Code != 0 && rr.Code != 200) {
+		return rr.error(string(content))
+	}
+	return nil
+}
+
+func (rr *RestResponse) error(content string) error {
+	switch {
+	case rr.ErrCode != "":
+		return NewCodeError(rr.ErrCode, rr.ErrMsg)
+	case rr.ContractErrCode != 0:
+		return NewCodeError(strconv.Itoa(rr.ContractErrCode), rr.ContractErrMsg)
+	case rr.Code != 0 && rr.Code != CodeOK:
+		return NewCodeError(strconv.Itoa(rr.Code), content)
+	default:
+		return NewError(fmt.Sprintf("rest return error %s", content))
+	}
 }
 
 func (rc *RestClient) Property() exchange.Property {
@@ -115,10 +205,13 @@ func (rc *RestClient) Property() exchange.Property {
 func (rc *RestClient) buildRequest(ctx context.Context, method, host string, endPoint string, values url.Values, body io.Reader, sign bool) (*http.Request, error) {
 	var query string
 	if sign {
-		if values == nil {
-			values = url.Values{}
+		//copy values, the request may be signed again when retry
+		signValues := url.Values{}
+		for k, v := range values {
+			signValues[k] = append([]string{}, v...)
 		}
-		ts := time.Now().UTC()
+		values = signValues
+		ts := rc.clock.Now().UTC()
 		values.Add("AccessKeyId", rc.key)
 		values.Add("SignatureMethod", signatureMethod)
 		values.Add("SignatureVersion", signatureVersion)
@@ -129,7 +222,7 @@ func (rc *RestClient) buildRequest(ctx context.Context, method, host string, end
 	} else {
 		query = values.Encode()
 	}
-	u := url.URL{Scheme: scheme, Path: endPoint, RawQuery: query, Host: host}
+	u := url.URL{Scheme: rc.scheme, Path: endPoint, RawQuery: query, Host: host}
 
 	if method == http.MethodPost || method == http.MethodPut {
 		req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
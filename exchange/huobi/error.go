@@ -1,20 +1,74 @@
 package huobi
 
+import (
+	"fmt"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
 type (
 	Error struct {
-		msg string
+		msg  string
+		code string
+	}
+)
+
+var (
+	//spot api use string err-code, contract api use integer err_code
+	codeKinds = map[string]error{
+		"api-signature-not-valid":    exchange.ErrAuthFailed,
+		"api-signature-check-failed": exchange.ErrAuthFailed,
+		"login-required":             exchange.ErrAuthFailed,
+		"order-accountbalance-error": exchange.ErrInsufficientBalance,
+		"base-record-invalid":        exchange.ErrOrderNotFound,
+		"base-symbol-error":          exchange.ErrInvalidSymbol,
+		"403":                        exchange.ErrAuthFailed,
+		"1004":                       exchange.ErrOverloaded,
+		"1014":                       exchange.ErrInvalidSymbol,
+		"1032":                       exchange.ErrRateLimited,
+		"1047":                       exchange.ErrInsufficientBalance,
+		"1048":                       exchange.ErrInsufficientBalance,
+		"1061":                       exchange.ErrOrderNotFound,
 	}
 )
 
 func NewError(msg string) error {
-	return &Error{msg}
+	return &Error{msg: msg}
+}
+
+//NewCodeError create error with huobi err-code (spot) or err_code (contract)
+func NewCodeError(code string, msg string) error {
+	return &Error{
+		msg:  msg,
+		code: code,
+	}
 }
 
 func (e *Error) Error() string {
-	return e.msg
+	if e.code == "" {
+		return e.msg
+	}
+	return fmt.Sprintf("huobi error code=%s message='%s'", e.code, e.msg)
+}
+
+//Code return huobi error code, empty if unknown
+func (e *Error) Code() string {
+	return e.code
 }
 
-func (e *Error) Is(target interface{}) bool {
+//Kind return the normalized exchange error kind, nil if unclassified
+func (e *Error) Kind() error {
+	return codeKinds[e.code]
+}
+
+//Is match any *Error
+func (e *Error) Is(target error) bool {
 	_, ok := target.(*Error)
 	return ok
 }
+
+//Unwrap return the error as *exchange.ErrAPI so that errors.Is match the
+//normalized error kind
+func (e *Error) Unwrap() error {
+	return exchange.NewAPIError(e.Kind(), e.code, e.msg)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/NadiaSama/ccexgo/exchange/huobi"
+	"github.com/NadiaSama/ccexgo/misc/request"
 )
 
 type (
@@ -17,11 +18,13 @@ const (
 	FutureHost = "api.hbdm.com"
 )
 
-func NewRestClient(key, secret string) *RestClient {
-	return &RestClient{
-		RestClient: huobi.NewRestClient(key, secret, FutureHost),
+func NewRestClient(key, secret string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		RestClient: huobi.NewRestClient(key, secret, FutureHost, opts...),
 		futureSymbolMap: make(map[string]*FutureSymbol),
 	}
+	ret.SetRateLimiter(NewRateLimiter())
+	return ret
 }
 
 func (rc *RestClient) Init(ctx context.Context) error {
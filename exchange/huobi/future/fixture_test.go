@@ -0,0 +1,43 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureOrders(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.Init)
+
+	symbols := rc.GetFutureSymbols("BTC")
+	if len(symbols) != 1 {
+		t.Fatalf("bad symbols %v", symbols)
+	}
+	sym := symbols[0]
+	if sym.String() != "BTC20211231" || sym.Type() != exchange.FutureTypeCQ || sym.WSSub() != "BTC_CQ" {
+		t.Errorf("bad symbol %v", sym)
+	}
+
+	o, err := rc.FetchOrder(ctx, &exchange.Order{ID: exchange.NewIntID(881396089397522432), Symbol: sym})
+	if err != nil {
+		t.Fatalf("fetch order fail %s", err.Error())
+	}
+	if o.Symbol != sym || o.Side != exchange.OrderSideCloseLong || o.Type != exchange.OrderTypeLimit ||
+		o.Status != exchange.OrderStatusDone || !o.Fee.Equal(decimal.RequireFromString("0.00000465")) ||
+		!o.Filled.Equal(decimal.NewFromInt(10)) || o.Created.Unix() != 1633072800 {
+		t.Errorf("bad order %+v", o)
+	}
+
+	req := exchange.NewDecimalOrderRequest(sym, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit,
+		decimal.NewFromInt(43000), decimal.NewFromInt(1))
+	_, err = rc.CreateOrder(ctx, req, NewLeverRateOption(5))
+	if !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("expect insufficient balance got %v", err)
+	}
+}
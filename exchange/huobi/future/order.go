@@ -0,0 +1,375 @@
+package future
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/huobi"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var _ exchange.Trader = (*RestClient)(nil)
+
+type (
+	OrderResp struct {
+		OrderID    int64  `json:"order_id"`
+		OrderIDStr string `json:"order_id_str"`
+	}
+
+	CancelError struct {
+		OrderID string `json:"order_id"`
+		ErrCode int    `json:"err_code"`
+		ErrMsg  string `json:"err_msg"`
+	}
+
+	CancelResp struct {
+		Errors    []CancelError `json:"errors"`
+		Successes string        `json:"successes"`
+	}
+
+	OrderInfo struct {
+		Symbol         string      `json:"symbol"`
+		ContractCode   string      `json:"contract_code"`
+		ContractType   string      `json:"contract_type"`
+		Volume         float64     `json:"volume"`
+		Price          float64     `json:"price"`
+		OrderPriceType string      `json:"order_price_type"`
+		OrderType      int         `json:"order_type"`
+		Direction      string      `json:"direction"`
+		Offset         string      `json:"offset"`
+		LeverRate      int         `json:"lever_rate"`
+		OrderID        int64       `json:"order_id"`
+		OrderIDStr     string      `json:"order_id_str"`
+		ClientOrderID  interface{} `json:"client_order_id"`
+		CreatedAt      int64       `json:"created_at"`
+		CanceledAt     int64       `json:"canceled_at"`
+		TradeVolume    float64     `json:"trade_volume"`
+		TradeTurnover  float64     `json:"trade_turnover"`
+		Fee            float64     `json:"fee"`
+		FeeAsset       string      `json:"fee_asset"`
+		TradeAvgPrice  float64     `json:"trade_avg_price"`
+		MarginFrozen   float64     `json:"margin_frozen"`
+		Profit         float64     `json:"profit"`
+		Status         int         `json:"status"`
+	}
+
+	OpenOrdersResp struct {
+		Orders      []OrderInfo `json:"orders"`
+		TotalPage   int         `json:"total_page"`
+		CurrentPage int         `json:"current_page"`
+		TotalSize   int         `json:"total_size"`
+	}
+
+	//LeverRateOption specific lever_rate of future order. default lever rate is 1
+	LeverRateOption struct {
+		LeverRate int
+	}
+)
+
+const (
+	ContractOrderEndPoint      = "/api/v1/contract_order"
+	ContractCancelEndPoint     = "/api/v1/contract_cancel"
+	ContractOrderInfoEndPoint  = "/api/v1/contract_order_info"
+	ContractOpenOrdersEndPoint = "/api/v1/contract_openorders"
+
+	OrderDirectionBuy  = "buy"
+	OrderDirectionSell = "sell"
+	OrderOffsetOpen    = "open"
+	OrderOffsetClose   = "close"
+
+	OrderPriceLimit    = "limit"
+	OrderPriceMarket   = "opponent"
+	OrderPriceOptimal5 = "optimal_5"
+	OrderPricePostOnly = "post_only"
+	OrderPriceIOC      = "ioc"
+	OrderPriceFOK      = "fok"
+
+	//OpenOrdersPageSize max page size of contract_openorders request
+	OpenOrdersPageSize = 50
+)
+
+var (
+	statusMap = map[int]exchange.OrderStatus{
+		1:  exchange.OrderStatusOpen,
+		2:  exchange.OrderStatusOpen,
+		3:  exchange.OrderStatusOpen,
+		4:  exchange.OrderStatusOpen,
+		5:  exchange.OrderStatusOpen,
+		6:  exchange.OrderStatusDone,
+		7:  exchange.OrderStatusCancel,
+		11: exchange.OrderStatusOpen,
+	}
+
+	typeMap = map[string]exchange.OrderType{
+		OrderPriceLimit:    exchange.OrderTypeLimit,
+		OrderPriceMarket:   exchange.OrderTypeMarket,
+		OrderPriceOptimal5: exchange.OrderTypeMarket,
+		OrderPricePostOnly: exchange.OrderTypeLimit,
+		OrderPriceIOC:      exchange.OrderTypeLimit,
+		OrderPriceFOK:      exchange.OrderTypeLimit,
+	}
+)
+
+func NewLeverRateOption(lever int) exchange.OrderReqOption {
+	return &LeverRateOption{
+		LeverRate: lever,
+	}
+}
+
+//CreateOrder create future order. the amount of req is contract volume. the
+//client order id must be an integer if specific. LeverRateOption is supported
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	var direction, offset string
+	switch req.Side {
+	case exchange.OrderSideBuy:
+		direction, offset = OrderDirectionBuy, OrderOffsetOpen
+	case exchange.OrderSideSell:
+		direction, offset = OrderDirectionSell, OrderOffsetOpen
+	case exchange.OrderSideCloseLong:
+		direction, offset = OrderDirectionSell, OrderOffsetClose
+	case exchange.OrderSideCloseShort:
+		direction, offset = OrderDirectionBuy, OrderOffsetClose
+	default:
+		return nil, exchange.NewBadArg("unsupport order side", req.Side)
+	}
+
+	var priceType string
+	switch req.Type {
+	case exchange.OrderTypeLimit:
+		priceType = OrderPriceLimit
+	case exchange.OrderTypeMarket:
+		priceType = OrderPriceMarket
+	default:
+		return nil, exchange.NewBadArg("unsupport order type", req.Type)
+	}
+
+	lever := 1
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *LeverRateOption:
+			lever = t.LeverRate
+
+		case *exchange.PostOnlyOption:
+			if req.Type != exchange.OrderTypeLimit {
+				return nil, exchange.NewBadArg("post only option only support limit order", t)
+			}
+			if t.PostOnly {
+				priceType = OrderPricePostOnly
+			}
+
+		case *exchange.TimeInForceOption:
+			if req.Type != exchange.OrderTypeLimit {
+				return nil, exchange.NewBadArg("time in force option only support limit order", t)
+			}
+			switch t.Flag {
+			case exchange.TimeInForceGTC:
+			case exchange.TimeInForceIOC:
+				priceType = OrderPriceIOC
+			case exchange.TimeInForceFOK:
+				priceType = OrderPriceFOK
+			default:
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
+			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
+	param := map[string]interface{}{
+		"contract_code":    req.Symbol.String(),
+		"volume":           req.Amount.IntPart(),
+		"direction":        direction,
+		"offset":           offset,
+		"lever_rate":       lever,
+		"order_price_type": priceType,
+	}
+	if req.Type == exchange.OrderTypeLimit {
+		param["price"] = req.Price
+	}
+	if req.ClientID != nil {
+		cid, err := strconv.ParseInt(req.ClientID.String(), 10, 64)
+		if err != nil {
+			return nil, exchange.NewBadArg("client order id must be integer", req.ClientID)
+		}
+		param["client_order_id"] = cid
+	}
+
+	var resp OrderResp
+	if err := rc.privatePostReq(ctx, ContractOrderEndPoint, param, &resp); err != nil {
+		return nil, err
+	}
+
+	return &exchange.Order{
+		ID:       exchange.NewIntID(resp.OrderID),
+		ClientID: req.ClientID,
+		Symbol:   req.Symbol,
+		Status:   exchange.OrderStatusOpen,
+		Raw:      &resp,
+	}, nil
+}
+
+//CancelOrder cancel the order and return the latest order info
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	fs, err := rc.parseSymbol(order.Symbol.String())
+	if err != nil {
+		return nil, err
+	}
+
+	param := map[string]interface{}{
+		"symbol":   fs.Index(),
+		"order_id": order.ID.String(),
+	}
+	var resp CancelResp
+	if err := rc.privatePostReq(ctx, ContractCancelEndPoint, param, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Errors) != 0 {
+		e := resp.Errors[0]
+		return nil, errors.WithMessagef(huobi.NewCodeError(strconv.Itoa(e.ErrCode), e.ErrMsg), "cancel order fail order_id=%s", e.OrderID)
+	}
+	return rc.FetchOrder(ctx, order)
+}
+
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	fs, err := rc.parseSymbol(order.Symbol.String())
+	if err != nil {
+		return nil, err
+	}
+
+	param := map[string]interface{}{
+		"symbol":   fs.Index(),
+		"order_id": order.ID.String(),
+	}
+	var resp []OrderInfo
+	if err := rc.privatePostReq(ctx, ContractOrderInfoEndPoint, param, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp) == 0 {
+		return nil, errors.Errorf("order not found id=%s", order.ID.String())
+	}
+	return rc.transform(&resp[0])
+}
+
+//OpenOrders return all unfinished orders of the symbol
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	fs, err := rc.parseSymbol(symbol.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*exchange.Order
+	for page := 1; ; page++ {
+		param := map[string]interface{}{
+			"symbol":     fs.Index(),
+			"page_index": page,
+			"page_size":  OpenOrdersPageSize,
+		}
+		var resp OpenOrdersResp
+		if err := rc.privatePostReq(ctx, ContractOpenOrdersEndPoint, param, &resp); err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Orders {
+			if resp.Orders[i].ContractCode != symbol.String() {
+				continue
+			}
+			o, err := rc.transform(&resp.Orders[i])
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, o)
+		}
+
+		if page >= resp.TotalPage {
+			break
+		}
+	}
+	return ret, nil
+}
+
+func (rc *RestClient) parseSymbol(code string) (*FutureSymbol, error) {
+	fs, ok := rc.futureSymbolMap[code]
+	if !ok {
+		return nil, errors.Errorf("unkown symbol '%s'", code)
+	}
+	return fs, nil
+}
+
+func (rc *RestClient) transform(info *OrderInfo) (*exchange.Order, error) {
+	symbol, err := rc.parseSymbol(info.ContractCode)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &exchange.Order{
+		ID:          exchange.NewIntID(info.OrderID),
+		Symbol:      symbol,
+		Amount:      decimal.NewFromFloat(info.Volume),
+		Filled:      decimal.NewFromFloat(info.TradeVolume),
+		Price:       decimal.NewFromFloat(info.Price),
+		AvgPrice:    decimal.NewFromFloat(info.TradeAvgPrice),
+		Fee:         decimal.NewFromFloat(info.Fee).Abs(),
+		FeeCurrency: info.FeeAsset,
+		Created:     huobi.ParseTS(info.CreatedAt),
+		Updated:     huobi.ParseTS(info.CreatedAt),
+		Raw:         info,
+	}
+
+	if info.CanceledAt != 0 {
+		ret.Updated = huobi.ParseTS(info.CanceledAt)
+	}
+
+	if info.Direction == OrderDirectionBuy {
+		if info.Offset == OrderOffsetOpen {
+			ret.Side = exchange.OrderSideBuy
+		} else if info.Offset == OrderOffsetClose {
+			ret.Side = exchange.OrderSideCloseShort
+		} else {
+			return nil, errors.Errorf("unkown order offset '%s'", info.Offset)
+		}
+	} else if info.Direction == OrderDirectionSell {
+		if info.Offset == OrderOffsetOpen {
+			ret.Side = exchange.OrderSideSell
+		} else if info.Offset == OrderOffsetClose {
+			ret.Side = exchange.OrderSideCloseLong
+		} else {
+			return nil, errors.Errorf("unkown order offset '%s'", info.Offset)
+		}
+	} else {
+		return nil, errors.Errorf("unkown order direction '%s'", info.Direction)
+	}
+
+	st, ok := statusMap[info.Status]
+	if !ok {
+		return nil, errors.Errorf("unkown order status %d", info.Status)
+	}
+
+	typ, ok := typeMap[info.OrderPriceType]
+	if !ok {
+		return nil, errors.Errorf("unkown order type %s", info.OrderPriceType)
+	}
+	ret.Status = st
+	ret.Type = typ
+
+	return ret, nil
+}
+
+func (rc *RestClient) privatePostReq(ctx context.Context, endPoint string, param interface{}, dst interface{}) error {
+	raw, err := json.Marshal(param)
+	if err != nil {
+		return errors.WithMessage(err, "marshal param fail")
+	}
+	return rc.Request(ctx, http.MethodPost, endPoint, nil, bytes.NewBuffer(raw), true, dst)
+}
@@ -0,0 +1,21 @@
+package future
+
+import (
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+const (
+	ExchangeName = "huobi.future"
+)
+
+func init() {
+	exchange.Register(&exchange.Registration{
+		Name: ExchangeName,
+		//future symbols is bound to RestClient which is loaded via RestClient.Init
+		NewRestClient: func(cfg *exchange.Config) (interface{}, error) {
+			return NewRestClient(cfg.Key, cfg.Secret, request.ConfigOptions(cfg)...), nil
+		},
+		Capabilities: exchange.CapabilityTrader,
+	})
+}
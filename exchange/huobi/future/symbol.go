@@ -39,7 +39,7 @@ const (
 
 func (rc *RestClient) initFutureSymbol(ctx context.Context) error {
 	var resp FutureSymbolResp
-	if err := rc.Request(ctx, http.MethodGet, "/api/v1/contract_contract_info",
+	if err := rc.RequestWithRawResp(ctx, http.MethodGet, "/api/v1/contract_contract_info",
 		nil, nil, false, &resp); err != nil {
 		return err
 	}
@@ -81,6 +81,8 @@ func (rc *RestClient) initFutureSymbol(ctx context.Context) error {
 		sym := newFutureSymbol(fsym.Symbol, st, typ)
 		rc.futureSymbolMap[fmt.Sprintf("%s%s", fsym.Symbol, fsym.DeliveryDate)] = sym
 		rc.futureSymbolMap[fmt.Sprintf("%s%s", fsym.Symbol, suffix)] = sym
+		//order responses carry contract_code such as BTC211231
+		rc.futureSymbolMap[fsym.ContractCode] = sym
 	}
 
 	return nil
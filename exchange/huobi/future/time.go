@@ -0,0 +1,34 @@
+package future
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/tconv"
+	"github.com/pkg/errors"
+)
+
+type (
+	//ServerTimeResp server time of huobi derivative api
+	ServerTimeResp struct {
+		Status string `json:"status"`
+		TS     int64  `json:"ts"`
+	}
+)
+
+const (
+	ServerTimeEndPoint = "/api/v1/timestamp"
+)
+
+//ServerTime return huobi future server time, it can be used as clock.Source
+func (rc *RestClient) ServerTime(ctx context.Context) (time.Time, error) {
+	var resp ServerTimeResp
+	if err := rc.RequestWithRawResp(ctx, http.MethodGet, ServerTimeEndPoint, nil, nil, false, &resp); err != nil {
+		return time.Time{}, err
+	}
+	if resp.Status != "ok" {
+		return time.Time{}, errors.Errorf("bad server time status %s", resp.Status)
+	}
+	return tconv.Milli2Time(resp.TS), nil
+}
@@ -58,9 +58,9 @@ func (ws *WSClient) Subscribe(ctx context.Context, typ exchange.SubType, syms ..
 
 func (ws *WSClientDeriv) DoSubscribe(ctx context.Context, channels []string) error {
 	for _, ch := range channels {
-		//huobi ws future/swap subscribe do not send response, so just write one subid.
+		//huobi ws future/swap subscribe do not send response, the id is not waited
 		cp := &huobi.CallParam{
-			ID:  "s1",
+			ID:  rpc.NextID(),
 			Sub: ch,
 		}
 
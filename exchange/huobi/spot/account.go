@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 )
@@ -120,6 +121,7 @@ func (rc *RestClient) Accounts(ctx context.Context) ([]Account, error) {
 
 func (rc *RestClient) Balance(ctx context.Context, req *BalanceReq) (*BalanceResp, error) {
 	endPoint := fmt.Sprintf("%s/%d/balance", AccountsEndPoint, req.AccountID)
+	ctx = request.WithEndpoint(ctx, "/v1/account/accounts/{account-id}/balance")
 
 	var ret BalanceResp
 	if err := rc.RestClient.Request(ctx, http.MethodGet, endPoint, nil, nil, true, &ret); err != nil {
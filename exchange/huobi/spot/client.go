@@ -1,13 +1,16 @@
 package spot
 
 import (
+	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/huobi"
+	"github.com/NadiaSama/ccexgo/misc/request"
 )
 
 type (
 	RestClient struct {
 		*huobi.RestClient
 		spotAccountID int
+		store         *exchange.SymbolStore
 	}
 )
 
@@ -15,8 +18,11 @@ const (
 	SpotHost = "api.huobi.pro"
 )
 
-func NewRestClient(key, secret string) *RestClient {
-	return &RestClient{
-		RestClient: huobi.NewRestClient(key, secret, SpotHost),
+func NewRestClient(key, secret string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		RestClient: huobi.NewRestClient(key, secret, SpotHost, opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	ret.SetRateLimiter(NewRateLimiter())
+	return ret
 }
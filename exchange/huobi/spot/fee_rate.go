@@ -53,7 +53,7 @@ func (rc *RestClient) FeeRate(ctx context.Context, symbols []exchange.Symbol) ([
 
 	var ret []*exchange.TradeFee
 	for _, tfr := range tfrs {
-		r, err := tfr.Parse()
+		r, err := tfr.parse(rc)
 		if err != nil {
 			return nil, errors.WithMessage(err, "parse trade fee fail")
 		}
@@ -63,7 +63,11 @@ func (rc *RestClient) FeeRate(ctx context.Context, symbols []exchange.Symbol) ([
 }
 
 func (tfr *TransactFeeRate) Parse() (*exchange.TradeFee, error) {
-	s, err := ParseSymbol(tfr.Symbol)
+	return tfr.parse(defaultClient)
+}
+
+func (tfr *TransactFeeRate) parse(rc *RestClient) (*exchange.TradeFee, error) {
+	s, err := rc.ParseSymbol(tfr.Symbol)
 	if err != nil {
 		return nil, err
 	}
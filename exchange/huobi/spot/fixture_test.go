@@ -0,0 +1,58 @@
+package spot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureOrders(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+
+	sym, err := rc.ParseSymbol("btcusdt")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	if sym.Base() != "btc" || sym.Quote() != "usdt" || !sym.PricePrecision().Equal(decimal.RequireFromString("0.01")) ||
+		!sym.AmountPrecision().Equal(decimal.RequireFromString("0.000001")) {
+		t.Errorf("bad symbol %v", sym)
+	}
+
+	trades, err := rc.Trades(ctx, &exchange.TradeReqParam{Symbol: sym})
+	if err != nil {
+		t.Fatalf("fetch trades fail %s", err.Error())
+	}
+	if len(trades) != 1 {
+		t.Fatalf("bad trades %v", trades)
+	}
+	tr := trades[0]
+	if tr.ID != "29553" || tr.OrderID != "59378" || tr.Side != exchange.OrderSideBuy || !tr.IsMaker ||
+		!tr.Fee.Equal(decimal.RequireFromString("-0.00002")) || tr.FeeCurrency != "btc" {
+		t.Errorf("bad trade %+v", tr)
+	}
+
+	o, err := rc.FetchOrder(ctx, &exchange.Order{ID: exchange.NewIntID(59378), Symbol: sym})
+	if err != nil {
+		t.Fatalf("fetch order fail %s", err.Error())
+	}
+	if o.Status != exchange.OrderStatusDone || o.Side != exchange.OrderSideBuy || o.Type != exchange.OrderTypeLimit ||
+		!o.AvgPrice.Equal(decimal.NewFromInt(43000)) || o.Created.Unix() != 1633072800 {
+		t.Errorf("bad order %+v", o)
+	}
+
+	if err := rc.Init(ctx); err != nil {
+		t.Fatalf("init account fail %s", err.Error())
+	}
+	req := exchange.NewDecimalOrderRequest(sym, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit,
+		decimal.NewFromInt(43000), decimal.RequireFromString("0.01"))
+	_, err = rc.CreateOrder(ctx, req)
+	if !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("expect insufficient balance got %v", err)
+	}
+}
@@ -6,14 +6,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/huobi"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 )
 
+var _ exchange.Trader = (*RestClient)(nil)
+
 type (
 	PlaceReq struct {
 		data map[string]string
@@ -58,6 +63,7 @@ type (
 
 const (
 	PlaceOrderEndPoint = "/v1/order/orders/place"
+	OpenOrdersEndPoint = "/v1/order/openOrders"
 )
 
 func NewOrdersReq(orderID string) *OrdersReq {
@@ -136,7 +142,7 @@ func (rc *RestClient) Place(ctx context.Context, req *PlaceReq) (*PlaceResp, err
 	}
 
 	if resp.Status != "ok" {
-		return nil, errors.Errorf("place order error %+v", resp)
+		return nil, errors.WithMessage(huobi.NewCodeError(resp.ErrCode, resp.ErrMsg), "place order error")
 	}
 
 	return &resp, nil
@@ -144,6 +150,7 @@ func (rc *RestClient) Place(ctx context.Context, req *PlaceReq) (*PlaceResp, err
 
 func (rc *RestClient) Orders(ctx context.Context, req *OrdersReq) (*OrdersResp, error) {
 	url := fmt.Sprintf("/v1/order/orders/%s", req.OrderID)
+	ctx = request.WithEndpoint(ctx, "/v1/order/orders/{order-id}")
 
 	var resp OrdersResp
 	if err := rc.RequestWithRawResp(ctx, http.MethodGet, url, nil, nil, true, &resp); err != nil {
@@ -155,6 +162,7 @@ func (rc *RestClient) Orders(ctx context.Context, req *OrdersReq) (*OrdersResp,
 
 func (rc *RestClient) SubmitCancel(ctx context.Context, req *SubmitCancelReq) (*PlaceResp, error) {
 	url := fmt.Sprintf("/v1/order/orders/%s/submitcancel", req.OrderID)
+	ctx = request.WithEndpoint(ctx, "/v1/order/orders/{order-id}/submitcancel")
 
 	var resp PlaceResp
 	if err := rc.RequestWithRawResp(ctx, http.MethodPost, url, nil, nil, true, &resp); err != nil {
@@ -162,7 +170,7 @@ func (rc *RestClient) SubmitCancel(ctx context.Context, req *SubmitCancelReq) (*
 	}
 
 	if resp.Status != "ok" {
-		return nil, errors.Errorf("cancel order fail %+v", resp)
+		return nil, errors.WithMessage(huobi.NewCodeError(resp.ErrCode, resp.ErrMsg), "cancel order fail")
 	}
 
 	return &resp, nil
@@ -170,6 +178,7 @@ func (rc *RestClient) SubmitCancel(ctx context.Context, req *SubmitCancelReq) (*
 
 func (rc *RestClient) MatchResult(ctx context.Context, req *MatchResultReq) ([]MatchResult, error) {
 	endPoint := fmt.Sprintf("/v1/order/orders/%s/matchresults", req.OrderID)
+	ctx = request.WithEndpoint(ctx, "/v1/order/orders/{order-id}/matchresults")
 	var resp []MatchResult
 
 	if err := rc.Request(ctx, http.MethodGet, endPoint, nil, nil, true, &resp); err != nil {
@@ -185,57 +194,194 @@ func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*e
 	if err != nil {
 		return nil, err
 	}
-	return resp.Transform()
+	return resp.transform(rc)
+}
+
+//CreateOrder create spot order. Init must be called before CreateOrder.
+//the amount of market buy order is quote currency amount which is
+//calculated via req.Price * req.Amount
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	if rc.spotAccountID == 0 {
+		return nil, errors.Errorf("spot account id is not init")
+	}
+
+	var side string
+	switch req.Side {
+	case exchange.OrderSideBuy:
+		side = "buy"
+	case exchange.OrderSideSell:
+		side = "sell"
+	default:
+		return nil, exchange.NewBadArg("unsupport order side", req.Side)
+	}
+
+	var typ string
+	switch req.Type {
+	case exchange.OrderTypeLimit:
+		typ = "limit"
+	case exchange.OrderTypeMarket:
+		typ = "market"
+	default:
+		return nil, exchange.NewBadArg("unsupport order type", req.Type)
+	}
+
+	for _, opt := range options {
+		if req.Type != exchange.OrderTypeLimit {
+			return nil, exchange.NewBadArg("option only support limit order", opt)
+		}
+
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			if t.PostOnly {
+				typ = "limit-maker"
+			}
+
+		case *exchange.TimeInForceOption:
+			switch t.Flag {
+			case exchange.TimeInForceGTC:
+			case exchange.TimeInForceIOC:
+				typ = "ioc"
+			case exchange.TimeInForceFOK:
+				typ = "limit-fok"
+			default:
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
+			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
+	amount := req.Amount
+	if req.Type == exchange.OrderTypeMarket && req.Side == exchange.OrderSideBuy {
+		amount = req.Price.Mul(req.Amount)
+	}
+
+	pr := NewPlaceReq(strconv.Itoa(rc.spotAccountID), req.Symbol.String(), fmt.Sprintf("%s-%s", side, typ), amount.String())
+	if req.Type == exchange.OrderTypeLimit {
+		pr.Price(req.Price.String())
+	}
+	if req.ClientID != nil {
+		pr.ClientOrderID(req.ClientID.String())
+	}
+
+	resp, err := rc.Place(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exchange.Order{
+		ID:       exchange.NewStrID(resp.Data),
+		ClientID: req.ClientID,
+		Symbol:   req.Symbol,
+		Status:   exchange.OrderStatusOpen,
+		Raw:      resp,
+	}, nil
+}
+
+//CancelOrder submit cancel request and return the latest order info
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	if _, err := rc.SubmitCancel(ctx, NewSubmitCancelReq(order.ID.String())); err != nil {
+		return nil, err
+	}
+	return rc.FetchOrder(ctx, order)
+}
+
+//OpenOrders return unfinished orders of the symbol. Init must be called before OpenOrders
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	if rc.spotAccountID == 0 {
+		return nil, errors.Errorf("spot account id is not init")
+	}
+
+	values := url.Values{}
+	values.Add("account-id", strconv.Itoa(rc.spotAccountID))
+	values.Add("symbol", symbol.String())
+
+	var resp []OrdersRespDetail
+	if err := rc.Request(ctx, http.MethodGet, OpenOrdersEndPoint, values, nil, true, &resp); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, len(resp))
+	for i := range resp {
+		o, err := resp[i].transform(rc)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = o
+	}
+	return ret, nil
 }
 
 func (r *OrdersResp) Transform() (*exchange.Order, error) {
-	symbol, err := ParseSymbol(r.Data.Symbol)
+	return r.transform(defaultClient)
+}
+
+func (r *OrdersResp) transform(rc *RestClient) (*exchange.Order, error) {
+	ret, err := r.Data.transform(rc)
+	if err != nil {
+		return nil, err
+	}
+	ret.Raw = r
+	return ret, nil
+}
+
+func (d *OrdersRespDetail) Transform() (*exchange.Order, error) {
+	return d.transform(defaultClient)
+}
+
+func (d *OrdersRespDetail) transform(rc *RestClient) (*exchange.Order, error) {
+	symbol, err := rc.ParseSymbol(d.Symbol)
 	if err != nil {
 		return nil, err
 	}
 
-	amount, err := parseStringToDecimal(r.Data.Amount)
+	amount, err := parseStringToDecimal(d.Amount)
 	if err != nil {
 		return nil, err
 	}
-	price, err := parseStringToDecimal(r.Data.Price)
+	price, err := parseStringToDecimal(d.Price)
 	if err != nil {
 		return nil, err
 	}
-	filled, err := parseStringToDecimal(r.Data.FilledAmount)
+	filled, err := parseStringToDecimal(d.FilledAmount)
 	if err != nil {
 		return nil, err
 	}
-	fees, err := parseStringToDecimal(r.Data.FilledFees)
+	fees, err := parseStringToDecimal(d.FilledFees)
 	if err != nil {
 		return nil, err
 	}
-	cost, err := parseStringToDecimal(r.Data.FilledCashAmount)
+	cost, err := parseStringToDecimal(d.FilledCashAmount)
 	var avgPrice decimal.Decimal
 	if !filled.IsZero() {
-		avgPrice = cost.Div(amount)
+		avgPrice = cost.Div(filled)
 	}
 
 	var ut time.Time
-	ct := huobi.ParseTS(r.Data.CreatedAt)
-	if r.Data.CanceledAt != 0 {
-		ut = huobi.ParseTS(r.Data.CanceledAt)
+	ct := huobi.ParseTS(d.CreatedAt)
+	if d.CanceledAt != 0 {
+		ut = huobi.ParseTS(d.CanceledAt)
 	} else {
-		ut = huobi.ParseTS(r.Data.FinishedAt)
+		ut = huobi.ParseTS(d.FinishedAt)
 	}
 
-	status, err := ParseOrderStatus(r.Data.State)
+	status, err := ParseOrderStatus(d.State)
 	if err != nil {
 		return nil, err
 	}
 
-	side, typ, err := ParseOrderType(r.Data.Type)
+	side, typ, err := ParseOrderType(d.Type)
 	if err != nil {
 		return nil, err
 	}
 
 	return &exchange.Order{
-		ID:       exchange.NewIntID(r.Data.ID),
+		ID:       exchange.NewIntID(d.ID),
 		Symbol:   symbol,
 		Price:    price,
 		Amount:   amount,
@@ -247,7 +393,7 @@ func (r *OrdersResp) Transform() (*exchange.Order, error) {
 		Side:     side,
 		Status:   status,
 		Type:     typ,
-		Raw:      r,
+		Raw:      d,
 	}, nil
 }
 
@@ -166,7 +166,7 @@ func ParseOrderType(oType string) (exchange.OrderSide, exchange.OrderType, error
 		return side, typ, errors.Errorf("parse order side fail unkown order type '%s'", oType)
 	}
 
-	if strings.HasPrefix(fields[1], "limit") {
+	if strings.HasPrefix(fields[1], "limit") || fields[1] == "ioc" {
 		typ = exchange.OrderTypeLimit
 	} else if strings.HasPrefix(fields[1], "market") {
 		typ = exchange.OrderTypeMarket
@@ -9,6 +9,7 @@ import (
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/huobi"
 	"github.com/NadiaSama/ccexgo/internal/rpc"
+	"github.com/NadiaSama/ccexgo/misc/clock"
 	"github.com/pkg/errors"
 )
 
@@ -17,7 +18,8 @@ type (
 		key    string
 		secret string
 		*exchange.WSClient
-		data chan interface{}
+		*exchange.Delivery
+		clock *clock.Clock
 	}
 )
 
@@ -27,12 +29,14 @@ const (
 
 func NewPrivateWSClient(key, secret string, data chan interface{}) *PrivateWSClient {
 	ret := &PrivateWSClient{
-		key:    key,
-		secret: secret,
-		data:   data,
+		key:      key,
+		secret:   secret,
+		Delivery: exchange.NewDelivery(data, exchange.OverflowDropNewest),
 	}
 
 	ret.WSClient = exchange.NewWSClient(PrivateWSClientAddr, NewPrivateCodeC(), ret)
+	//server send ping which is answered in Handle
+	ret.WSClient.SetHeartbeat(&rpc.Heartbeat{IdleTimeout: time.Minute})
 	return ret
 }
 
@@ -78,14 +82,16 @@ func (pws *PrivateWSClient) Handle(ctx context.Context, n *rpc.Notify) {
 		Chan:     n.Method,
 		Data:     n.Params,
 	}
-	select {
-	case pws.data <- &en:
-	default:
-	}
+	pws.Deliver(ctx, &en)
+}
+
+//SetClock set the clock which provide timestamp of auth, nil use local time
+func (pws *PrivateWSClient) SetClock(c *clock.Clock) {
+	pws.clock = c
 }
 
 func (pws *PrivateWSClient) genSignatureParmas() map[string]string {
-	ts := time.Now().UTC()
+	ts := pws.clock.Now().UTC()
 	ret := map[string]string{
 		"accessKey":        pws.key,
 		"signatureMethod":  "HmacSHA256",
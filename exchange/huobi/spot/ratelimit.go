@@ -0,0 +1,19 @@
+package spot
+
+import (
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+)
+
+const (
+	BucketDefault = "default"
+)
+
+//NewRateLimiter return the default spot rate limiter which allow 100
+//requests every 10 seconds per account
+func NewRateLimiter() *ratelimit.Limiter {
+	return ratelimit.NewLimiter(ratelimit.PolicyBlock,
+		ratelimit.NewBucket(BucketDefault, 100, 10*time.Second),
+	).SetDefault(ratelimit.Cost{Bucket: BucketDefault, Weight: 1})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
 
@@ -76,6 +77,7 @@ func (rc *RestClient) SubUserAccount(ctx context.Context, req *SubUserAccountReq
 	var ret []BalanceResp
 
 	uri := fmt.Sprintf("/v1/account/accounts/%d", req.uid)
+	ctx = request.WithEndpoint(ctx, "/v1/account/accounts/{sub-uid}")
 	if err := rc.RestClient.Request(ctx, http.MethodGet, uri, nil, nil, true, &ret); err != nil {
 		return nil, errors.WithMessage(err, "request account fail")
 	}
@@ -35,27 +35,61 @@ type (
 )
 
 var (
-	symbolMap map[string]exchange.SpotSymbol = map[string]exchange.SpotSymbol{}
+	defaultClient = NewRestClient("", "")
 )
 
 func Init(ctx context.Context) error {
-	client := NewRestClient("", "")
-	symbols, err := client.Symbols(ctx)
-	if err != nil {
-		return err
-	}
-	for _, s := range symbols {
-		symbolMap[s.String()] = s
-	}
-	return nil
+	return defaultClient.LoadSymbols(ctx)
+}
+
+//SymbolStore return symbol store of the default client which is used by ParseSymbol
+func SymbolStore() *exchange.SymbolStore {
+	return defaultClient.SymbolStore()
 }
 
 func ParseSymbol(symbol string) (exchange.SpotSymbol, error) {
-	ret, ok := symbolMap[symbol]
-	if !ok {
+	return defaultClient.ParseSymbol(symbol)
+}
+
+//SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
+
+//LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
+}
+
+//ParseSymbol parse symbol with symbol store of rc. the default client store
+//is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(symbol string) (exchange.SpotSymbol, error) {
+	store := rc.store
+	if store.Updated().IsZero() {
+		store = defaultClient.store
+	}
+	ret, err := store.Lookup(symbol)
+	if err != nil {
 		return nil, errors.Errorf("unsupport symbol %s", symbol)
 	}
-	return ret, nil
+	return ret.(exchange.SpotSymbol), nil
+}
+
+//NewSymbolStore return a symbol store which fetch symbols with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		symbols, err := rc.Symbols(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]exchange.Symbol, len(symbols))
+		for i, s := range symbols {
+			ret[i] = s
+		}
+		return ret, nil
+	}, opts...)
 }
 
 func (rc *RestClient) FetchSymbols(ctx context.Context) ([]Symbol, error) {
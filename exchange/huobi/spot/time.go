@@ -0,0 +1,22 @@
+package spot
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/tconv"
+)
+
+const (
+	ServerTimeEndPoint = "/v1/common/timestamp"
+)
+
+//ServerTime return huobi spot server time, it can be used as clock.Source
+func (rc *RestClient) ServerTime(ctx context.Context) (time.Time, error) {
+	var ts int64
+	if err := rc.Request(ctx, http.MethodGet, ServerTimeEndPoint, nil, nil, false, &ts); err != nil {
+		return time.Time{}, err
+	}
+	return tconv.Milli2Time(ts), nil
+}
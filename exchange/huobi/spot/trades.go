@@ -102,7 +102,7 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 
 	ret := []exchange.Trade{}
 	for _, m := range mr {
-		t, err := m.Parse()
+		t, err := m.parse(rc)
 		if err != nil {
 			return nil, errors.WithMessage(err, "parse match result fail")
 		}
@@ -112,7 +112,11 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 }
 
 func (mr *MatchResult) Parse() (*exchange.Trade, error) {
-	s, err := ParseSymbol(mr.Symbol)
+	return mr.parse(defaultClient)
+}
+
+func (mr *MatchResult) parse(rc *RestClient) (*exchange.Trade, error) {
+	s, err := rc.ParseSymbol(mr.Symbol)
 	if err != nil {
 		return nil, err
 	}
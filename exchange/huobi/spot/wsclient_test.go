@@ -0,0 +1,61 @@
+package spot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange/huobi"
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+)
+
+func TestWSClient(t *testing.T) {
+	pong := make(chan string, 1)
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Write(map[string]interface{}{"ping": 1492420473027})
+		c.Serve(context.Background(),
+			wstest.On(wstest.Has("pong"), func(m *wstest.Message) []interface{} {
+				pong <- m.String("pong")
+				return nil
+			}),
+			wstest.On(wstest.Has("sub"), func(m *wstest.Message) []interface{} {
+				return []interface{}{map[string]interface{}{
+					"id": m.Get("id"), "status": "ok", "subbed": m.Get("sub"), "ts": 1489474081631,
+				}}
+			}),
+			wstest.On(wstest.Field("unsub", "market.btcusdt.trade.detail"), func(m *wstest.Message) []interface{} {
+				return []interface{}{map[string]interface{}{
+					"id": m.Get("id"), "status": "error", "err-code": "bad-request", "err-msg": "invalid topic",
+				}}
+			}),
+		)
+	}, wstest.WithCompression(wstest.Gzip))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	ws := &WSClient{
+		WSClient: huobi.NewWSClient(srv.URL, NewCodeC(), make(chan interface{}, 1)),
+	}
+	if err := ws.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer ws.Close()
+
+	select {
+	case p := <-pong:
+		if p != "1492420473027" {
+			t.Errorf("bad pong %s", p)
+		}
+	case <-ctx.Done():
+		t.Fatalf("wait pong timeout")
+	}
+
+	ch := NewTradeDetailChannel("BTCUSDT")
+	if err := ws.Subscribe(ctx, ch); err != nil {
+		t.Errorf("subscribe fail %s", err.Error())
+	}
+	if err := ws.UnSubscribe(ctx, ch); err == nil {
+		t.Errorf("expect unsubscribe fail")
+	}
+}
@@ -6,13 +6,16 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/huobi"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
 
 type (
 	RestClient struct {
 		*huobi.RestClient
+		store *exchange.SymbolStore
 	}
 
 	Serializer interface {
@@ -25,14 +28,17 @@ const (
 	SwapProHost = "api.huobi.pro"
 )
 
-func NewRestClient(key string, secret string) *RestClient {
-	return NewRestClientWithHost(key, secret, SwapHost)
+func NewRestClient(key string, secret string, opts ...request.Option) *RestClient {
+	return NewRestClientWithHost(key, secret, SwapHost, opts...)
 }
 
-func NewRestClientWithHost(key, secret, host string) *RestClient {
-	return &RestClient{
-		RestClient: huobi.NewRestClient(key, secret, host),
+func NewRestClientWithHost(key, secret, host string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		RestClient: huobi.NewRestClient(key, secret, host, opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	ret.SetRateLimiter(NewRateLimiter())
+	return ret
 }
 
 //PrivatePostReq send post request to huobi swap api. the request body is generate from req param
@@ -26,7 +26,7 @@ func (rc *RestClient) Finance(ctx context.Context, params *exchange.FinanceReqPa
 
 	var ret []exchange.Finance
 	for _, r := range records.Data {
-		rec, err := r.Transform()
+		rec, err := r.transform(rc)
 		if err != nil {
 			return nil, errors.WithMessage(err, "parse financial_record fail")
 		}
@@ -133,7 +133,11 @@ func (cl *RestClient) FinancialRecord(ctx context.Context, req *FinancialRecordR
 
 // Transform financeRecord to finacial currently only funding type is support
 func (fr *FinancialRecord) Transform() (*exchange.Finance, error) {
-	symbol, err := ParseSymbol(fr.ContractCode)
+	return fr.transform(defaultClient)
+}
+
+func (fr *FinancialRecord) transform(rc *RestClient) (*exchange.Finance, error) {
+	symbol, err := rc.ParseSymbol(fr.ContractCode)
 	if err != nil {
 		return nil, err
 	}
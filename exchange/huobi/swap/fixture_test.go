@@ -0,0 +1,59 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureOrders(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+
+	sym, err := rc.ParseSymbol("BTC-USD")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	if !sym.ContractVal().Equal(decimal.NewFromInt(100)) || !sym.PricePrecision().Equal(decimal.RequireFromString("0.1")) {
+		t.Errorf("bad symbol %v", sym)
+	}
+
+	fr, err := rc.FetchFundingRate(ctx, sym)
+	if err != nil {
+		t.Fatalf("fetch funding rate fail %s", err.Error())
+	}
+	if !fr.FundingRate.Equal(decimal.RequireFromString("0.000075")) || fr.NextFundingTime.Unix() != 1633104000 ||
+		fr.Time.Unix() != 1633075200 {
+		t.Errorf("bad funding rate %+v", fr)
+	}
+
+	o, err := rc.FetchOrder(ctx, &exchange.Order{ID: exchange.NewIntID(773719079), Symbol: sym})
+	if err != nil {
+		t.Fatalf("fetch order fail %s", err.Error())
+	}
+	if o.Side != exchange.OrderSideCloseShort || o.Type != exchange.OrderTypeLimit || o.Status != exchange.OrderStatusDone ||
+		!o.Filled.Equal(decimal.NewFromInt(2)) || o.Created.Unix() != 1633072800 || o.Updated.Unix() != 1633072801 {
+		t.Errorf("bad order %+v", o)
+	}
+
+	positions, err := rc.FetchPosition(ctx, sym)
+	if err != nil {
+		t.Fatalf("fetch position fail %s", err.Error())
+	}
+	if len(positions) != 1 || positions[0].Symbol.String() != "BTC-USD" || positions[0].Side != exchange.PositionSideShort ||
+		!positions[0].Position.Equal(decimal.NewFromInt(3)) || !positions[0].Leverage.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("bad positions %+v", positions)
+	}
+
+	req := exchange.NewDecimalOrderRequest(sym, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit,
+		decimal.NewFromInt(43000), decimal.NewFromInt(1))
+	_, err = rc.CreateOrder(ctx, req)
+	if !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("expect insufficient balance got %v", err)
+	}
+}
@@ -57,11 +57,15 @@ func (rc *RestClient) FetchFundingRate(ctx context.Context, symbol exchange.Symb
 		return nil, err
 	}
 
-	return resp.Transfer()
+	return resp.transfer(rc)
 }
 
 func (tr *FundingRateResp) Transfer() (*exchange.FundingRate, error) {
-	symbol, err := ParseSymbol(tr.ContractCode)
+	return tr.transfer(defaultClient)
+}
+
+func (tr *FundingRateResp) transfer(rc *RestClient) (*exchange.FundingRate, error) {
+	symbol, err := rc.ParseSymbol(tr.ContractCode)
 	if err != nil {
 		return nil, errors.WithMessage(err, "parse symbol fail")
 	}
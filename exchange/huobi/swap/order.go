@@ -12,6 +12,8 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+var _ exchange.Trader = (*RestClient)(nil)
+
 type (
 	OrderReq struct {
 		data map[string]interface{}
@@ -75,12 +77,29 @@ type (
 		IsTpsl          interface{}        `json:"is_tpsl"`
 		RealProfit      float64            `json:"real_profit"`
 	}
+
+	SwapOpenOrdersReq struct {
+		data map[string]interface{}
+	}
+
+	SwapOpenOrdersResp struct {
+		Orders      []SwapOrderDetailResp `json:"orders"`
+		TotalPage   int                   `json:"total_page"`
+		CurrentPage int                   `json:"current_page"`
+		TotalSize   int                   `json:"total_size"`
+	}
+
+	//LeverRateOption specific lever_rate of swap order. default lever rate is 1
+	LeverRateOption struct {
+		LeverRate int
+	}
 )
 
 const (
 	SwapOrderEndPoint       = "/swap-api/v1/swap_order"
 	SwapCancelEndPoint      = "/swap-api/v1/swap_cancel"
 	SwapOrderDetailEndPoint = "/swap-api/v1/swap_order_detail"
+	SwapOpenOrdersEndPoint  = "/swap-api/v1/swap_openorders"
 
 	OrderDirectionBuy  = "buy"
 	OrderDirectionSell = "sell"
@@ -90,6 +109,12 @@ const (
 	OrderPriceLimit    = "limit"
 	OrderPriceMarket   = "opponent"
 	OrderPriceOptimal5 = "optimal_5"
+	OrderPricePostOnly = "post_only"
+	OrderPriceIOC      = "ioc"
+	OrderPriceFOK      = "fok"
+
+	//SwapOpenOrdersPageSize max page size of swap_openorders request
+	SwapOpenOrdersPageSize = 50
 )
 
 func NewOrderReq(contractCode string, volume int, direction string, offset string, lever int, orderPriceType string) *OrderReq {
@@ -111,6 +136,11 @@ func (or *OrderReq) Price(price float64) *OrderReq {
 	return or
 }
 
+func (or *OrderReq) ClientOrderID(id int64) *OrderReq {
+	or.data["client_order_id"] = id
+	return or
+}
+
 func (or *OrderReq) Serialize() ([]byte, error) {
 	return json.Marshal(or.data)
 }
@@ -184,6 +214,34 @@ func (sdr *SwapOrderDetailReq) Serialize() ([]byte, error) {
 	return json.Marshal(sdr.data)
 }
 
+func NewSwapOpenOrdersReq(cc string) *SwapOpenOrdersReq {
+	return &SwapOpenOrdersReq{
+		data: map[string]interface{}{
+			"contract_code": cc,
+		},
+	}
+}
+
+func (sor *SwapOpenOrdersReq) PageIndex(pi int) *SwapOpenOrdersReq {
+	sor.data["page_index"] = pi
+	return sor
+}
+
+func (sor *SwapOpenOrdersReq) PageSize(ps int) *SwapOpenOrdersReq {
+	sor.data["page_size"] = ps
+	return sor
+}
+
+func (sor *SwapOpenOrdersReq) Serialize() ([]byte, error) {
+	return json.Marshal(sor.data)
+}
+
+func NewLeverRateOption(lever int) exchange.OrderReqOption {
+	return &LeverRateOption{
+		LeverRate: lever,
+	}
+}
+
 func (rc *RestClient) SwapOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
 	var resp OrderResp
 	if err := rc.PrivatePostReq(ctx, SwapOrderEndPoint, req, &resp); err != nil {
@@ -210,6 +268,145 @@ func (rc *RestClient) SwapOrderDetail(ctx context.Context, req *SwapOrderDetailR
 	return &ret, nil
 }
 
+func (rc *RestClient) SwapOpenOrders(ctx context.Context, req *SwapOpenOrdersReq) (*SwapOpenOrdersResp, error) {
+	var ret SwapOpenOrdersResp
+	if err := rc.PrivatePostReq(ctx, SwapOpenOrdersEndPoint, req, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+//CreateOrder create swap order. the amount of req is contract volume. the
+//client order id must be an integer if specific. LeverRateOption is supported
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	var direction, offset string
+	switch req.Side {
+	case exchange.OrderSideBuy:
+		direction, offset = OrderDirectionBuy, OrderOffsetOpen
+	case exchange.OrderSideSell:
+		direction, offset = OrderDirectionSell, OrderOffsetOpen
+	case exchange.OrderSideCloseLong:
+		direction, offset = OrderDirectionSell, OrderOffsetClose
+	case exchange.OrderSideCloseShort:
+		direction, offset = OrderDirectionBuy, OrderOffsetClose
+	default:
+		return nil, exchange.NewBadArg("unsupport order side", req.Side)
+	}
+
+	var priceType string
+	switch req.Type {
+	case exchange.OrderTypeLimit:
+		priceType = OrderPriceLimit
+	case exchange.OrderTypeMarket:
+		priceType = OrderPriceMarket
+	default:
+		return nil, exchange.NewBadArg("unsupport order type", req.Type)
+	}
+
+	lever := 1
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *LeverRateOption:
+			lever = t.LeverRate
+
+		case *exchange.PostOnlyOption:
+			if req.Type != exchange.OrderTypeLimit {
+				return nil, exchange.NewBadArg("post only option only support limit order", t)
+			}
+			if t.PostOnly {
+				priceType = OrderPricePostOnly
+			}
+
+		case *exchange.TimeInForceOption:
+			if req.Type != exchange.OrderTypeLimit {
+				return nil, exchange.NewBadArg("time in force option only support limit order", t)
+			}
+			switch t.Flag {
+			case exchange.TimeInForceGTC:
+			case exchange.TimeInForceIOC:
+				priceType = OrderPriceIOC
+			case exchange.TimeInForceFOK:
+				priceType = OrderPriceFOK
+			default:
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
+			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
+	or := NewOrderReq(req.Symbol.String(), int(req.Amount.IntPart()), direction, offset, lever, priceType)
+	if req.Type == exchange.OrderTypeLimit {
+		p, _ := req.Price.Float64()
+		or.Price(p)
+	}
+	if req.ClientID != nil {
+		cid, err := strconv.ParseInt(req.ClientID.String(), 10, 64)
+		if err != nil {
+			return nil, exchange.NewBadArg("client order id must be integer", req.ClientID)
+		}
+		or.ClientOrderID(cid)
+	}
+
+	resp, err := rc.SwapOrder(ctx, or)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exchange.Order{
+		ID:       exchange.NewIntID(resp.OrderID),
+		ClientID: req.ClientID,
+		Symbol:   req.Symbol,
+		Status:   exchange.OrderStatusOpen,
+		Raw:      resp,
+	}, nil
+}
+
+//CancelOrder cancel the order and return the latest order info
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	resp, err := rc.SwapCancel(ctx, NewSwapCancelReq(order.Symbol.String()).Orders(order.ID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Errors) != 0 {
+		e := resp.Errors[0]
+		return nil, errors.WithMessagef(huobi.NewCodeError(strconv.Itoa(e.ErrCode), e.ErrMsg), "cancel order fail order_id=%s", e.OrderID)
+	}
+	return rc.FetchOrder(ctx, order)
+}
+
+//OpenOrders return all unfinished orders of the symbol
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	var ret []*exchange.Order
+	for page := 1; ; page++ {
+		req := NewSwapOpenOrdersReq(symbol.String()).PageIndex(page).PageSize(SwapOpenOrdersPageSize)
+		resp, err := rc.SwapOpenOrders(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Orders {
+			o, err := resp.Orders[i].transform(rc)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, o)
+		}
+
+		if page >= resp.TotalPage {
+			break
+		}
+	}
+	return ret, nil
+}
+
 func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
 	id, err := strconv.ParseInt(order.ID.String(), 10, 64)
 	if err != nil {
@@ -221,11 +418,15 @@ func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*e
 		return nil, err
 	}
 
-	return resp.Transform()
+	return resp.transform(rc)
 }
 
 func (r *SwapOrderDetailResp) Transform() (*exchange.Order, error) {
-	symbol, err := ParseSymbol(r.ContractCode)
+	return r.transform(defaultClient)
+}
+
+func (r *SwapOrderDetailResp) transform(rc *RestClient) (*exchange.Order, error) {
+	symbol, err := rc.ParseSymbol(r.ContractCode)
 	if err != nil {
 		return nil, err
 	}
@@ -60,8 +60,40 @@ func (rc *RestClient) PositionInfo(ctx context.Context, req *PositionInfoRequest
 	return ret, nil
 }
 
+//FetchPosition return positions of sym, all positions are returned if sym
+//is not specific
+func (rc *RestClient) FetchPosition(ctx context.Context, sym ...exchange.Symbol) ([]exchange.Position, error) {
+	if len(sym) > 1 {
+		return nil, errors.Errorf("at most 1 symbol is support")
+	}
+
+	req := NewPositionInfoRequest("")
+	if len(sym) != 0 {
+		req = NewPositionInfoRequest(sym[0].String())
+	}
+
+	pos, err := rc.PositionInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]exchange.Position, len(pos))
+	for i := range pos {
+		p, err := pos[i].transfer(rc)
+		if err != nil {
+			return nil, errors.WithMessage(err, "parse position fail")
+		}
+		ret[i] = *p
+	}
+	return ret, nil
+}
+
 func (p *Position) Transfer() (*ccexgo.Position, error) {
-	sym, err := ParseSymbol(p.ContractCode)
+	return p.transfer(defaultClient)
+}
+
+func (p *Position) transfer(rc *RestClient) (*ccexgo.Position, error) {
+	sym, err := rc.ParseSymbol(p.ContractCode)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +75,9 @@ var (
 		"limit":            exchange.OrderTypeLimit,
 		"opponent":         exchange.OrderTypeMarket,
 		OrderPriceOptimal5: exchange.OrderTypeMarket,
+		OrderPricePostOnly: exchange.OrderTypeLimit,
+		OrderPriceIOC:      exchange.OrderTypeLimit,
+		OrderPriceFOK:      exchange.OrderTypeLimit,
 	}
 )
 
@@ -112,7 +115,7 @@ func ParseOrder(raw []byte) (*exchange.Order, error) {
 
 	st, ok := statusMap[resp.Status]
 	if !ok {
-		return nil, errors.Errorf("unkown orderstatus %s", resp.Status)
+		return nil, errors.Errorf("unkown orderstatus %d", resp.Status)
 	}
 	typ, ok := typeMap[resp.OrderPriceType]
 	if !ok {
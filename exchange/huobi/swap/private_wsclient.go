@@ -10,6 +10,7 @@ import (
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/huobi"
 	"github.com/NadiaSama/ccexgo/internal/rpc"
+	"github.com/NadiaSama/ccexgo/misc/clock"
 	"github.com/pkg/errors"
 )
 
@@ -22,7 +23,8 @@ type (
 		key    string
 		secret string
 		*exchange.WSClient
-		data chan interface{}
+		*exchange.Delivery
+		clock *clock.Clock
 	}
 
 	Response struct {
@@ -121,12 +123,14 @@ func (pcc *PrivateCodeC) Decode(raw []byte) (rpc.Response, error) {
 
 func NewPrivateWSClient(key, secret string, data chan interface{}) *PrivateWSClient {
 	ret := &PrivateWSClient{
-		key:    key,
-		secret: secret,
-		data:   data,
+		key:      key,
+		secret:   secret,
+		Delivery: exchange.NewDelivery(data, exchange.OverflowDropNewest),
 	}
 
 	ret.WSClient = exchange.NewWSClient(SwapPrivateAddr, NewPrivateCodeC(), ret)
+	//server send ping which is answered in Handle
+	ret.WSClient.SetHeartbeat(&rpc.Heartbeat{IdleTimeout: time.Minute})
 	return ret
 }
 
@@ -177,14 +181,16 @@ func (ws *PrivateWSClient) Handle(ctx context.Context, notify *rpc.Notify) {
 		Chan:     notify.Method,
 		Data:     notify.Params,
 	}
-	select {
-	case ws.data <- &d:
-	default:
-	}
+	ws.Deliver(ctx, &d)
+}
+
+//SetClock set the clock which provide timestamp of auth, nil use local time
+func (pws *PrivateWSClient) SetClock(c *clock.Clock) {
+	pws.clock = c
 }
 
 func (pws *PrivateWSClient) genSignatureParmas() map[string]string {
-	ts := time.Now().UTC()
+	ts := pws.clock.Now().UTC()
 	ret := map[string]string{
 		"AccessKeyId":      pws.key,
 		"SignatureMethod":  "HmacSHA256",
@@ -0,0 +1,21 @@
+package swap
+
+import (
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+)
+
+const (
+	BucketDefault = "default"
+)
+
+//NewRateLimiter return the default swap rate limiter which allow 72 requests
+//every 3 seconds per account. the bucket is synced with Ratelimit-Remaining
+//header
+func NewRateLimiter() *ratelimit.Limiter {
+	return ratelimit.NewLimiter(ratelimit.PolicyBlock,
+		ratelimit.NewBucket(BucketDefault, 72, 3*time.Second),
+	).SetDefault(ratelimit.Cost{Bucket: BucketDefault, Weight: 1}).
+		SetRemainHeader("Ratelimit-Remaining", BucketDefault)
+}
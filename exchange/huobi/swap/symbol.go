@@ -37,7 +37,7 @@ const (
 )
 
 var (
-	contractMap = map[string]*Symbol{}
+	defaultClient = NewRestClient("", "")
 )
 
 func (s *Symbol) String() string {
@@ -45,17 +45,56 @@ func (s *Symbol) String() string {
 }
 
 func Init(ctx context.Context) error {
-	rc := NewRestClient("", "")
+	return defaultClient.LoadSymbols(ctx)
+}
+
+//SymbolStore return symbol store of the default client which is used by ParseSymbol
+func SymbolStore() *exchange.SymbolStore {
+	return defaultClient.SymbolStore()
+}
+
+func ParseSymbol(sym string) (exchange.SwapSymbol, error) {
+	return defaultClient.ParseSymbol(sym)
+}
+
+//SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
+
+//LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
+}
+
+//ParseSymbol parse symbol with symbol store of rc. the default client store
+//is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(sym string) (exchange.SwapSymbol, error) {
+	store := rc.store
+	if store.Updated().IsZero() {
+		store = defaultClient.store
+	}
+	s, err := store.Lookup(sym)
+	if err != nil {
+		return nil, errors.Errorf("unsupport symbol %s", sym)
+	}
 
+	return s.(exchange.SwapSymbol), nil
+}
+
+//Symbols return all swap contracts
+func (rc *RestClient) Symbols(ctx context.Context) ([]exchange.SwapSymbol, error) {
 	var ci ContractInfo
 	if err := rc.RequestWithRawResp(ctx, http.MethodGet, ContractEndPoint, nil, nil, false, &ci); err != nil {
-		return errors.WithMessagef(err, "get contract info fail")
+		return nil, errors.WithMessagef(err, "get contract info fail")
 	}
 
 	if ci.Status != huobi.StatusOK {
-		return errors.Errorf("got huobi contract info fail")
+		return nil, errors.Errorf("got huobi contract info fail")
 	}
 
+	ret := make([]exchange.SwapSymbol, 0, len(ci.Data))
 	for i := range ci.Data {
 		data := ci.Data[i]
 		cv := decimal.NewFromFloat(data.ContractSize)
@@ -65,20 +104,27 @@ func Init(ctx context.Context) error {
 				PricePrecision:  decimal.NewFromFloat(data.PriceTick),
 				AmountMin:       decimal.NewFromInt(1),
 				AmountMax:       decimal.Zero,
+				Inverse:         true,
 			}, &data),
 		}
 
-		contractMap[data.ContractCode] = symbol
+		ret = append(ret, symbol)
 	}
-
-	return nil
+	return ret, nil
 }
 
-func ParseSymbol(sym string) (exchange.SwapSymbol, error) {
-	s, ok := contractMap[sym]
-	if !ok {
-		return nil, errors.Errorf("unsupport symbol %s", sym)
-	}
+//NewSymbolStore return a symbol store which fetch symbols with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		symbols, err := rc.Symbols(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	return s, nil
+		ret := make([]exchange.Symbol, len(symbols))
+		for i, s := range symbols {
+			ret[i] = s
+		}
+		return ret, nil
+	}, opts...)
 }
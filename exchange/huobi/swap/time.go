@@ -0,0 +1,23 @@
+package swap
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange/huobi/future"
+	"github.com/NadiaSama/ccexgo/misc/tconv"
+	"github.com/pkg/errors"
+)
+
+//ServerTime return huobi swap server time, it can be used as clock.Source
+func (rc *RestClient) ServerTime(ctx context.Context) (time.Time, error) {
+	var resp future.ServerTimeResp
+	if err := rc.RequestWithRawResp(ctx, http.MethodGet, future.ServerTimeEndPoint, nil, nil, false, &resp); err != nil {
+		return time.Time{}, err
+	}
+	if resp.Status != "ok" {
+		return time.Time{}, errors.Errorf("bad server time status %s", resp.Status)
+	}
+	return tconv.Milli2Time(resp.TS), nil
+}
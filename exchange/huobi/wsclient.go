@@ -2,7 +2,7 @@ package huobi
 
 import (
 	"context"
-	"strconv"
+	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/internal/rpc"
@@ -13,7 +13,7 @@ type (
 	//WSClient with auto response ping support
 	WSClient struct {
 		*exchange.WSClient
-		data chan interface{}
+		*exchange.Delivery
 	}
 
 	//CallParam carry params which used by huobi websocket sub and pong
@@ -27,9 +27,11 @@ type (
 
 func NewWSClient(addr string, codec rpc.Codec, data chan interface{}) *WSClient {
 	ret := &WSClient{
-		data: data,
+		Delivery: exchange.NewDelivery(data, exchange.OverflowBlock),
 	}
 	wc := exchange.NewWSClient(addr, codec, ret)
+	//server send ping every 5 seconds which is answered in Handle
+	wc.SetHeartbeat(&rpc.Heartbeat{IdleTimeout: time.Second * 30})
 
 	ret.WSClient = wc
 	return ret
@@ -39,9 +41,9 @@ func (ws *WSClient) Subscribe(ctx context.Context, channels ...exchange.Channel)
 	if len(channels) != 1 {
 		return errors.Errorf("only one channel subscribe support")
 	}
-	for i, ch := range channels {
+	for _, ch := range channels {
 		param := CallParam{
-			ID:  strconv.Itoa(i),
+			ID:  rpc.NextID(),
 			Sub: ch.String(),
 		}
 
@@ -62,9 +64,9 @@ func (ws *WSClient) UnSubscribe(ctx context.Context, channels ...exchange.Channe
 	if len(channels) != 1 {
 		return errors.Errorf("only one channel subscribe support")
 	}
-	for i, ch := range channels {
+	for _, ch := range channels {
 		param := CallParam{
-			ID:    strconv.Itoa(i),
+			ID:    rpc.NextID(),
 			UnSub: ch.String(),
 		}
 
@@ -88,9 +90,9 @@ func (ws *WSClient) Handle(ctx context.Context, notify *rpc.Notify) {
 		return
 	}
 
-	ws.data <- &exchange.WSNotify{
+	ws.Deliver(ctx, &exchange.WSNotify{
 		Exchange: Huobi,
 		Chan:     notify.Method,
 		Data:     notify.Params,
-	}
+	})
 }
@@ -15,17 +15,32 @@ import (
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/clock"
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
 	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
 
 type (
 	RestClient struct {
-		key        string
-		secret     string
-		passPhrase string
-		apiHost    string
-		test       bool
+		key          string
+		secret       string
+		passPhrase   string
+		apiHost      string
+		scheme       string
+		client       *http.Client
+		interceptors []request.Interceptor
+		test         bool
+		limiter      *ratelimit.Limiter
+		retry        *request.RetryPolicy
+		clock        *clock.Clock
+	}
+
+	//APIResult is implemented by response envelope which carry api error in
+	//http 200 response such as v5 api. the error is checked in each attempt
+	//so the retryable error codes are retried
+	APIResult interface {
+		APIError() error
 	}
 )
 
@@ -33,26 +48,39 @@ const (
 	okexRestHost = "www.okx.com"
 )
 
-func NewRestClient(key, secret, passPhrase string) *RestClient {
-	return &RestClient{
-		key:        key,
-		secret:     secret,
-		passPhrase: passPhrase,
-		apiHost:    okexRestHost,
-		test:       false,
-	}
+//NewRestClient create rest client. opts can specific the http client and
+//override the api host
+func NewRestClient(key, secret, passPhrase string, opts ...request.Option) *RestClient {
+	return newRestClient(key, secret, passPhrase, false, opts...)
 }
 
-func NewTESTRestClient(key, secret, passPhrase string) *RestClient {
+//NewTESTRestClient create rest client which trade in simulated trading mode
+func NewTESTRestClient(key, secret, passPhrase string, opts ...request.Option) *RestClient {
+	return newRestClient(key, secret, passPhrase, true, opts...)
+}
+
+func newRestClient(key, secret, passPhrase string, test bool, opts ...request.Option) *RestClient {
+	cfg := request.NewConfig(opts...)
+	scheme, host := cfg.SchemeHost("https", okexRestHost)
 	return &RestClient{
-		key:        key,
-		secret:     secret,
-		passPhrase: passPhrase,
-		apiHost:    okexRestHost,
-		test:       true,
+		key:          key,
+		secret:       secret,
+		passPhrase:   passPhrase,
+		apiHost:      host,
+		scheme:       scheme,
+		client:       cfg.Client,
+		interceptors: cfg.Interceptors,
+		test:         test,
+		limiter:      NewRateLimiter(),
 	}
 }
 
+//Use append interceptors which run around every request of the client.
+//Use is not concurrent safe and should be called before sending requests
+func (rc *RestClient) Use(interceptors ...request.Interceptor) {
+	rc.interceptors = append(rc.interceptors, interceptors...)
+}
+
 func (rc *RestClient) Request(ctx context.Context, method, endPoint string, params url.Values, body io.Reader, sign bool, dst interface{}) error {
 	p := map[string]string{}
 	for k, v := range params {
@@ -64,6 +92,37 @@ func (rc *RestClient) Request(ctx context.Context, method, endPoint string, para
 	return rc.request(ctx, method, endPoint, p, body, sign, dst)
 }
 
+//SetRateLimiter replace the rate limiter of the client, nil disable rate limit
+func (rc *RestClient) SetRateLimiter(l *ratelimit.Limiter) {
+	rc.limiter = l
+}
+
+//RateLimiter return the rate limiter of the client
+func (rc *RestClient) RateLimiter() *ratelimit.Limiter {
+	return rc.limiter
+}
+
+//SetRetryPolicy replace the retry policy of the client, nil disable retry
+func (rc *RestClient) SetRetryPolicy(p *request.RetryPolicy) {
+	rc.retry = p
+}
+
+//RetryPolicy return the retry policy of the client
+func (rc *RestClient) RetryPolicy() *request.RetryPolicy {
+	return rc.retry
+}
+
+//SetClock set the clock which provide timestamp of signed requests, nil use
+//local time
+func (rc *RestClient) SetClock(c *clock.Clock) {
+	rc.clock = c
+}
+
+//Clock return the clock of the client
+func (rc *RestClient) Clock() *clock.Clock {
+	return rc.clock
+}
+
 func (rc *RestClient) Property() exchange.Property {
 	return exchange.Property{
 		Trades: &exchange.TradesProp{
@@ -77,15 +136,30 @@ func (rc *RestClient) Property() exchange.Property {
 	}
 }
 func (rc *RestClient) request(ctx context.Context, method, endPoint string, param map[string]string, body io.Reader, sign bool, dst interface{}) error {
+	replay, err := request.ReplayBody(body)
+	if err != nil {
+		return errors.WithMessage(err, "read body fail")
+	}
+	ctx = request.WithEndpoint(ctx, endPoint)
+	return rc.retry.Retry(ctx, method, endPoint, func() error {
+		return rc.doRequest(ctx, method, endPoint, param, replay(), sign, dst)
+	})
+}
+
+func (rc *RestClient) doRequest(ctx context.Context, method, endPoint string, param map[string]string, body io.Reader, sign bool, dst interface{}) error {
+	if err := rc.limiter.Wait(ctx, method, endPoint); err != nil {
+		return err
+	}
 	req, err := rc.buildRequest(ctx, method, endPoint, param, body, sign)
 	if err != nil {
 		return err
 	}
 
-	return request.DoReqWithCtx(req, func(resp *http.Response, ierr error) error {
+	return request.DoWithClient(rc.client, req, func(resp *http.Response, ierr error) error {
 		if ierr != nil {
 			return ierr
 		}
+		rc.limiter.Observe(resp.Header)
 		body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			return err
@@ -93,13 +167,16 @@ func (rc *RestClient) request(ctx context.Context, method, endPoint string, para
 		defer resp.Body.Close()
 
 		if resp.StatusCode != 200 {
-			return errors.Errorf("bad response %s", string(body))
+			return request.NewResponseError(ParseAPIError(resp.StatusCode, body), resp)
 		}
 		if err := json.Unmarshal(body, dst); err != nil {
 			return errors.WithMessage(err, "unmarshal response fail")
 		}
+		if ar, ok := dst.(APIResult); ok {
+			return ar.APIError()
+		}
 		return nil
-	})
+	}, rc.interceptors...)
 }
 
 func (rc *RestClient) buildRequest(ctx context.Context, method, endPoint string, param map[string]string, data io.Reader, sign bool) (*http.Request, error) {
@@ -113,11 +190,14 @@ func (rc *RestClient) buildRequest(ctx context.Context, method, endPoint string,
 	for k, v := range param {
 		values.Add(k, v)
 	}
-	u := url.URL{Scheme: "https", Host: rc.apiHost, Path: endPoint, RawQuery: values.Encode()}
+	u := url.URL{Scheme: rc.scheme, Host: rc.apiHost, Path: endPoint, RawQuery: values.Encode()}
 	if method == http.MethodPost {
-		b, e := ioutil.ReadAll(data)
-		if e != nil {
-			return nil, errors.WithMessagef(err, "build request fail")
+		var b []byte
+		if data != nil {
+			b, err = ioutil.ReadAll(data)
+			if err != nil {
+				return nil, errors.WithMessage(err, "build request fail")
+			}
 		}
 		body = string(b)
 		req, err = http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(b))
@@ -139,7 +219,7 @@ func (rc *RestClient) buildRequest(ctx context.Context, method, endPoint string,
 		if u.RawQuery != "" {
 			p = fmt.Sprintf("%s?%s", u.Path, u.RawQuery)
 		}
-		ts := time.Now().UTC().Format(time.RFC3339)
+		ts := rc.clock.Now().UTC().Format(time.RFC3339)
 		raw := fmt.Sprintf("%s%s%s%s", ts, method, p, body)
 		h := hmac.New(sha256.New, []byte(rc.secret))
 		h.Write([]byte(raw))
@@ -0,0 +1,116 @@
+package okex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
+type (
+	//APIError error message of okex v3 and v5 api
+	APIError struct {
+		Code    string
+		Message string
+		Status  int
+	}
+
+	errorResp struct {
+		Code         json.RawMessage `json:"code"`
+		Msg          string          `json:"msg"`
+		ErrorCode    string          `json:"error_code"`
+		ErrorMessage string          `json:"error_message"`
+	}
+)
+
+var (
+	codeKinds = map[string]error{
+		//v3 api
+		"30004": exchange.ErrAuthFailed,
+		"30006": exchange.ErrAuthFailed,
+		"30012": exchange.ErrAuthFailed,
+		"30013": exchange.ErrAuthFailed,
+		"30015": exchange.ErrAuthFailed,
+		"30026": exchange.ErrRateLimited,
+		"30030": exchange.ErrOverloaded,
+		"32004": exchange.ErrOrderNotFound,
+		"33014": exchange.ErrOrderNotFound,
+		"33017": exchange.ErrInsufficientBalance,
+		"35029": exchange.ErrOrderNotFound,
+		//v5 api
+		"50001": exchange.ErrOverloaded,
+		"50011": exchange.ErrRateLimited,
+		"50013": exchange.ErrOverloaded,
+		"50111": exchange.ErrAuthFailed,
+		"50113": exchange.ErrAuthFailed,
+		"51001": exchange.ErrInvalidSymbol,
+		"51008": exchange.ErrInsufficientBalance,
+		"51511": exchange.ErrPostOnlyRejected,
+		"51603": exchange.ErrOrderNotFound,
+	}
+)
+
+//NewAPIError create an api error with okex error code and message
+func NewAPIError(code string, msg string) error {
+	return &APIError{
+		Code:    code,
+		Message: msg,
+	}
+}
+
+//ParseAPIError parse v3 or v5 error response body
+func ParseAPIError(status int, body []byte) error {
+	ret := &APIError{
+		Message: string(body),
+		Status:  status,
+	}
+
+	var er errorResp
+	if err := json.Unmarshal(body, &er); err != nil {
+		return ret
+	}
+
+	if er.ErrorCode != "" {
+		ret.Code = er.ErrorCode
+		ret.Message = er.ErrorMessage
+	} else if len(er.Code) != 0 {
+		ret.Code = strings.Trim(string(er.Code), "\"")
+		ret.Message = er.Msg
+	}
+	return ret
+}
+
+func (ae *APIError) Error() string {
+	return fmt.Sprintf("api error code:%s message:'%s'", ae.Code, ae.Message)
+}
+
+//Kind return the normalized exchange error kind, nil if unclassified
+func (ae *APIError) Kind() error {
+	if kind, ok := codeKinds[ae.Code]; ok {
+		return kind
+	}
+
+	switch ae.Status {
+	case http.StatusTooManyRequests:
+		return exchange.ErrRateLimited
+	case http.StatusUnauthorized:
+		return exchange.ErrAuthFailed
+	case http.StatusServiceUnavailable:
+		return exchange.ErrOverloaded
+	}
+	return nil
+}
+
+//Is match any *APIError
+func (ae *APIError) Is(target error) bool {
+	_, ok := target.(*APIError)
+	return ok
+}
+
+//Unwrap return the error as *exchange.ErrAPI so that errors.Is match the
+//normalized error kind
+func (ae *APIError) Unwrap() error {
+	return exchange.NewAPIError(ae.Kind(), ae.Code, ae.Message)
+}
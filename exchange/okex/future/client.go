@@ -1,15 +1,22 @@
 package future
 
-import "github.com/NadiaSama/ccexgo/exchange/okex"
+import (
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
 
 type (
 	RestClient struct {
 		*okex.RestClient
+		store *exchange.SymbolStore
 	}
 )
 
-func NewRestClient(key, secret, password string) *RestClient {
-	return &RestClient{
-		okex.NewRestClient(key, secret, password),
+func NewRestClient(key, secret, password string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		RestClient: okex.NewRestClient(key, secret, password, opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	return ret
 }
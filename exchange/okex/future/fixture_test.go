@@ -0,0 +1,49 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureOrders(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+
+	sym, err := rc.ParseSymbol("BTC-USD-211231")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	if sym.Type() != exchange.FutureTypeCQ || sym.SettleTime().Unix() != 1640937600 {
+		t.Errorf("bad symbol %v", sym)
+	}
+	if _, err := rc.ParseSymbol("BTC-USD-211008"); err != nil {
+		t.Errorf("parse next week symbol fail %s", err.Error())
+	}
+
+	orders, err := rc.OpenOrders(ctx, sym)
+	if err != nil {
+		t.Fatalf("fetch open orders fail %s", err.Error())
+	}
+	if len(orders) != 1 {
+		t.Fatalf("bad orders %v", orders)
+	}
+	o := orders[0]
+	if o.ID.String() != "7667210341484545" || o.Side != exchange.OrderSideSell || o.Type != exchange.OrderTypeLimit ||
+		o.Status != exchange.OrderStatusOpen || !o.Filled.Equal(decimal.NewFromInt(3)) ||
+		!o.Fee.Equal(decimal.RequireFromString("0.00000139")) {
+		t.Errorf("bad order %+v", o)
+	}
+
+	req := exchange.NewDecimalOrderRequest(sym, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit,
+		decimal.NewFromInt(45000), decimal.NewFromInt(1))
+	_, err = rc.CreateOrder(ctx, req)
+	if !errors.Is(err, exchange.ErrAuthFailed) {
+		t.Errorf("expect auth failed got %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+package future
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/request"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	orderEndPoint  = "/api/futures/v3/order"
+	ordersEndPoint = "/api/futures/v3/orders"
+
+	orderTypeNormal = "0"
+	orderTypeMaker  = "1"
+	orderTypeFOK    = "2"
+	orderTypeIOC    = "3"
+	orderTypeMarket = "4"
+
+	//orderStateUnfinished query open and partial filled orders
+	orderStateUnfinished = "6"
+)
+
+var _ exchange.Trader = (*RestClient)(nil)
+
+type (
+	Order struct {
+		InstrumentID string          `json:"instrument_id"`
+		Size         decimal.Decimal `json:"size"`
+		Timestamp    string          `json:"timestamp"`
+		FilledQty    decimal.Decimal `json:"filled_qty"`
+		Fee          decimal.Decimal `json:"fee"`
+		OrderID      string          `json:"order_id"`
+		ClientOID    string          `json:"client_oid"`
+		Price        decimal.Decimal `json:"price"`
+		PriceAvg     decimal.Decimal `json:"price_avg"`
+		Type         string          `json:"type"`
+		ContractVal  decimal.Decimal `json:"contract_val"`
+		Leverage     decimal.Decimal `json:"leverage"`
+		OrderType    string          `json:"order_type"`
+		State        string          `json:"state"`
+	}
+
+	orderRequest struct {
+		ClientOID    string `json:"client_oid,omitempty"`
+		Size         string `json:"size"`
+		Type         string `json:"type"`
+		OrderType    string `json:"order_type"`
+		MatchPrice   string `json:"match_price"`
+		Price        string `json:"price,omitempty"`
+		InstrumentID string `json:"instrument_id"`
+	}
+
+	orderResponse struct {
+		OrderID      string `json:"order_id"`
+		ClientOID    string `json:"client_oid"`
+		ErrorCode    string `json:"error_code"`
+		ErrorMessage string `json:"error_message"`
+		Result       bool   `json:"result"`
+	}
+
+	ordersResponse struct {
+		OrderInfo []Order `json:"order_info"`
+		Result    bool    `json:"result"`
+	}
+)
+
+var (
+	sideMap map[string]exchange.OrderSide = map[string]exchange.OrderSide{
+		"1": exchange.OrderSideBuy,
+		"2": exchange.OrderSideSell,
+		"3": exchange.OrderSideCloseLong,
+		"4": exchange.OrderSideCloseShort,
+	}
+
+	rSideMap map[exchange.OrderSide]string = map[exchange.OrderSide]string{
+		exchange.OrderSideBuy:        "1",
+		exchange.OrderSideSell:       "2",
+		exchange.OrderSideCloseLong:  "3",
+		exchange.OrderSideCloseShort: "4",
+	}
+
+	statusMap map[string]exchange.OrderStatus = map[string]exchange.OrderStatus{
+		"-2": exchange.OrderStatusFailed,
+		"-1": exchange.OrderStatusCancel,
+		"0":  exchange.OrderStatusOpen,
+		"1":  exchange.OrderStatusOpen,
+		"2":  exchange.OrderStatusDone,
+		"3":  exchange.OrderStatusOpen,
+		"4":  exchange.OrderStatusOpen,
+	}
+)
+
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	side, ok := rSideMap[req.Side]
+	if !ok {
+		return nil, exchange.NewBadArg("unsupport order side", req.Side)
+	}
+
+	oReq := orderRequest{
+		Size:         req.Amount.String(),
+		InstrumentID: req.Symbol.String(),
+		MatchPrice:   "0",
+		Type:         side,
+		OrderType:    orderTypeNormal,
+	}
+
+	if req.ClientID != nil {
+		oReq.ClientOID = req.ClientID.String()
+	}
+
+	if req.Type == exchange.OrderTypeMarket {
+		oReq.OrderType = orderTypeMarket
+	} else if req.Type == exchange.OrderTypeLimit {
+		oReq.Price = req.Price.String()
+	} else {
+		return nil, exchange.NewBadArg("unsupport order type", req.Type)
+	}
+
+	if len(options) > 1 {
+		return nil, errors.Errorf("okex create order only one option is support")
+	}
+
+	if oReq.OrderType != orderTypeNormal && len(options) != 0 {
+		return nil, errors.Errorf("okex cannot creat order with type=%s and options", oReq.OrderType)
+	}
+
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			if t.PostOnly {
+				oReq.OrderType = orderTypeMaker
+			}
+
+		case *exchange.TimeInForceOption:
+			if t.Flag == exchange.TimeInForceFOK {
+				oReq.OrderType = orderTypeFOK
+			} else if t.Flag == exchange.TimeInForceIOC {
+				oReq.OrderType = orderTypeIOC
+			} else if t.Flag != exchange.TimeInForceGTC {
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
+			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
+	b, _ := json.Marshal(&oReq)
+	body := bytes.NewBuffer(b)
+	resp := orderResponse{}
+	if err := rc.Request(ctx, http.MethodPost, orderEndPoint, nil, body, true, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.Error(); err != nil {
+		return nil, err
+	}
+
+	return &exchange.Order{
+		ID:       exchange.NewStrID(resp.OrderID),
+		ClientID: exchange.NewStrID(resp.ClientOID),
+		Symbol:   req.Symbol,
+		Status:   exchange.OrderStatusOpen,
+		Raw:      &resp,
+	}, nil
+}
+
+//CancelOrder cancel the order and return the latest order info
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	endPoint := fmt.Sprintf("/api/futures/v3/cancel_order/%s/%s", order.Symbol.String(), order.ID.String())
+	ctx = request.WithEndpoint(ctx, "/api/futures/v3/cancel_order/{instrument_id}/{order_id}")
+	var resp orderResponse
+	if err := rc.Request(ctx, http.MethodPost, endPoint, nil, bytes.NewBuffer([]byte{}), true, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.Error(); err != nil {
+		return nil, err
+	}
+	return rc.FetchOrder(ctx, order)
+}
+
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	endPoint := fmt.Sprintf("%s/%s/%s", ordersEndPoint, order.Symbol.String(), order.ID.String())
+	ctx = request.WithEndpoint(ctx, "/api/futures/v3/orders/{instrument_id}/{order_id}")
+	var resp Order
+	if err := rc.Request(ctx, http.MethodGet, endPoint, nil, nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return resp.transform(rc)
+}
+
+//OpenOrders return unfinished orders of the symbol
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	endPoint := fmt.Sprintf("%s/%s", ordersEndPoint, symbol.String())
+	ctx = request.WithEndpoint(ctx, "/api/futures/v3/orders/{instrument_id}")
+	params := url.Values{}
+	params.Add("state", orderStateUnfinished)
+
+	var resp ordersResponse
+	if err := rc.Request(ctx, http.MethodGet, endPoint, params, nil, true, &resp); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, len(resp.OrderInfo))
+	for i := range resp.OrderInfo {
+		o, err := resp.OrderInfo[i].transform(rc)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = o
+	}
+	return ret, nil
+}
+
+func (o *Order) Transform() (*exchange.Order, error) {
+	return o.transform(defaultClient)
+}
+
+func (o *Order) transform(rc *RestClient) (*exchange.Order, error) {
+	sym, err := rc.ParseSymbol(o.InstrumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	side, ok := sideMap[o.Type]
+	if !ok {
+		return nil, errors.Errorf("unkown order side '%s'", o.Type)
+	}
+
+	status, ok := statusMap[o.State]
+	if !ok {
+		return nil, errors.Errorf("unkown order state '%s'", o.State)
+	}
+
+	ts, err := okex.ParseTime(o.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := exchange.OrderTypeLimit
+	if o.OrderType == orderTypeMarket {
+		typ = exchange.OrderTypeMarket
+	}
+
+	return &exchange.Order{
+		Symbol:   sym,
+		Side:     side,
+		Type:     typ,
+		ID:       exchange.NewStrID(o.OrderID),
+		ClientID: exchange.NewStrID(o.ClientOID),
+		Status:   status,
+		Amount:   o.Size,
+		Filled:   o.FilledQty,
+		Price:    o.Price,
+		AvgPrice: o.PriceAvg,
+		Fee:      o.Fee.Abs(),
+		Created:  ts,
+		Updated:  ts,
+		Raw:      o,
+	}, nil
+}
+
+func (or *orderResponse) Error() error {
+	if !or.Result {
+		return okex.NewAPIError(or.ErrorCode, or.ErrorMessage)
+	}
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
-	"sync"
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
@@ -50,8 +49,7 @@ var (
 		"bi_quarter": exchange.FutureTypeNQ,
 	}
 
-	symbolMapMu                    = sync.Mutex{}
-	symbolMap   map[string]*Symbol = map[string]*Symbol{}
+	defaultClient = NewRestClient("", "", "")
 )
 
 func (rc *RestClient) Symbols(ctx context.Context) ([]exchange.FuturesSymbol, error) {
@@ -102,72 +100,78 @@ func (s *Symbol) String() string {
 	return fmt.Sprintf("%s-%s", s.Index(), st.Format("060102"))
 }
 
+//NewSymbolStore return a symbol store which fetch symbols with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		symbols, err := rc.Symbols(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]exchange.Symbol, len(symbols))
+		for i, s := range symbols {
+			ret[i] = s
+		}
+		return ret, nil
+	}, opts...)
+}
+
 //Init start a background goroutine which used to update symbol map
 func Init(ctx context.Context) error {
-	if err := updateSymbolMap(ctx); err != nil {
+	if err := defaultClient.LoadSymbols(ctx); err != nil {
 		return err
 	}
 
-	timer := time.NewTicker(time.Minute)
-
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-
-			case <-timer.C:
-				updateSymbolMap(ctx)
-			}
-		}
-	}()
+	go defaultClient.SymbolStore().Loop(ctx, time.Minute, nil)
 	return nil
 }
 
-func ParseSymbol(symbol string) (exchange.FuturesSymbol, error) {
-	symbolMapMu.Lock()
-	defer symbolMapMu.Unlock()
-	sym, ok := symbolMap[symbol]
-	if !ok {
-		return nil, errors.Errorf("unkown symbol '%s'", symbol)
-	}
+//SymbolStore return symbol store of the default client which is used by ParseSymbol
+func SymbolStore() *exchange.SymbolStore {
+	return defaultClient.SymbolStore()
+}
 
-	return sym, nil
+func ParseSymbol(symbol string) (exchange.FuturesSymbol, error) {
+	return defaultClient.ParseSymbol(symbol)
 }
 
-func FetchSymbolByIndex(index string) []exchange.FuturesSymbol {
-	var ret []exchange.FuturesSymbol
-	symbolMapMu.Lock()
-	defer symbolMapMu.Unlock()
+//SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
 
-	for _, s := range symbolMap {
-		if s.Index() == index {
-			ret = append(ret, s)
-		}
-	}
-	return ret
+//LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
 }
 
-func updateSymbolMap(ctx context.Context) error {
-	client := NewRestClient("", "", "")
-	symbols, err := client.Symbols(ctx)
+//ParseSymbol parse symbol with symbol store of rc. the default client store
+//is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(symbol string) (exchange.FuturesSymbol, error) {
+	store := rc.store
+	if store.Updated().IsZero() {
+		store = defaultClient.store
+	}
+	ret, err := store.Lookup(symbol)
 	if err != nil {
-		return err
+		return nil, errors.Errorf("unkown symbol '%s'", symbol)
 	}
+	return ret.(exchange.FuturesSymbol), nil
+}
 
-	sort.Slice(symbols, func(i, j int) bool {
-		ti := symbols[i].SettleTime()
-		tj := symbols[j].SettleTime()
-		return ti.Before(tj)
-	})
-
-	m := map[string]*Symbol{}
-	for _, s := range symbols {
-		m[s.String()] = s.(*Symbol)
+//FetchSymbolByIndex return symbols of index sorted by settle time
+func FetchSymbolByIndex(index string) []exchange.FuturesSymbol {
+	var ret []exchange.FuturesSymbol
+	for _, s := range SymbolStore().Symbols() {
+		fs := s.(exchange.FuturesSymbol)
+		if fs.Index() == index {
+			ret = append(ret, fs)
+		}
 	}
-	symbolMapMu.Lock()
-	defer symbolMapMu.Unlock()
-	symbolMap = m
 
-	return nil
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].SettleTime().Before(ret[j].SettleTime())
+	})
+	return ret
 }
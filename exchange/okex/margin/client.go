@@ -1,6 +1,9 @@
 package margin
 
-import "github.com/NadiaSama/ccexgo/exchange/okex"
+import (
+	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
 
 type (
 	RestClient struct {
@@ -8,8 +11,8 @@ type (
 	}
 )
 
-func NewRestClient(key, secret, pass string) *RestClient {
+func NewRestClient(key, secret, pass string, opts ...request.Option) *RestClient {
 	return &RestClient{
-		okex.NewRestClient(key, secret, pass),
+		okex.NewRestClient(key, secret, pass, opts...),
 	}
 }
@@ -8,11 +8,13 @@ import (
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/okex"
 	"github.com/NadiaSama/ccexgo/exchange/okex/spot"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
 
 func (rc *RestClient) Ledgers(ctx context.Context, instrumentID string, before, after, limit, typ string) ([]okex.Ledger, error) {
 	endPoint := fmt.Sprintf("/api/margin/v3/accounts/%s/ledger", instrumentID)
+	ctx = request.WithEndpoint(ctx, "/api/margin/v3/accounts/{instrument_id}/ledger")
 
 	return okex.FetchLedgers(ctx, rc, endPoint, before, after, limit, typ)
 }
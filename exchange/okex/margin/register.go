@@ -0,0 +1,20 @@
+package margin
+
+import (
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+const (
+	ExchangeName = "okex.margin"
+)
+
+func init() {
+	exchange.Register(&exchange.Registration{
+		Name: ExchangeName,
+		NewRestClient: func(cfg *exchange.Config) (interface{}, error) {
+			return NewRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...), nil
+		},
+		Capabilities: exchange.CapabilityFinance,
+	})
+}
@@ -13,7 +13,7 @@ import (
 
 type (
 	CodeC struct {
-		LastSUBID string //okex op fail do not return operate type. we have to record
+		LastSUBID string //okex op fail do not return operate type and channel. we have to record the call id
 	}
 
 	callParam struct {
@@ -24,6 +24,7 @@ type (
 	response struct {
 		Event     string          `json:"event"`
 		Table     string          `json:"table"`
+		Channel   string          `json:"channel"`
 		Action    string          `json:"action"`
 		Data      json.RawMessage `json:"data"`
 		Message   string          `json:"message"`
@@ -56,9 +57,8 @@ func NewCodeC() *CodeC {
 
 func (cc *CodeC) Encode(req rpc.Request) ([]byte, error) {
 	param := req.Params()
-	r, ok := param.(*callParam)
-	if ok {
-		cc.LastSUBID = r.OP
+	if _, ok := param.(*callParam); ok {
+		cc.LastSUBID = req.ID()
 	}
 
 	if _, ok := param.(*pingReq); ok {
@@ -97,7 +97,7 @@ func (cc *CodeC) Decode(raw []byte) (rpc.Response, error) {
 
 	if r.Event == opSubscribe || r.Event == opUnSubscribe {
 		return &rpc.Result{
-			ID:     r.Event,
+			ID:     callID(r.Event, r.Channel),
 			Result: resp,
 		}, nil
 	}
@@ -125,6 +125,12 @@ func (r *response) transfer() (*rpc.Notify, error) {
 	return cb(r.Table, r.Action, r.Data)
 }
 
+//callID return id of subscribe and unsubscribe call which is matched by the
+//ack of channel. each call carry exactly one channel so the ack complete it
+func callID(op string, channel string) string {
+	return op + ":" + channel
+}
+
 func ParseTime(timestamp string) (time.Time, error) {
 	t, err := time.Parse(timeFMT, timestamp)
 	return t, err
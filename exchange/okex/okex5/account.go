@@ -197,7 +197,7 @@ func (rc *RestClient) Finance(ctx context.Context, req *exchange.FinanceReqParam
 			continue
 		}
 
-		f, err := b.Parse()
+		f, err := b.parse(rc)
 		if err != nil {
 			return nil, err
 		}
@@ -208,6 +208,10 @@ func (rc *RestClient) Finance(ctx context.Context, req *exchange.FinanceReqParam
 }
 
 func (b *Bill) Parse() (*exchange.Finance, error) {
+	return b.parse(getDefaultClient())
+}
+
+func (b *Bill) parse(rc *RestClient) (*exchange.Finance, error) {
 	ts, err := ParseTimestamp(b.Ts)
 	if err != nil {
 		return nil, err
@@ -216,19 +220,19 @@ func (b *Bill) Parse() (*exchange.Finance, error) {
 	var symbol exchange.Symbol
 	switch b.InstType {
 	case InstTypeSpot:
-		symbol, err = ParseSpotSymbol(b.InstID)
+		symbol, err = rc.ParseSpotSymbol(b.InstID)
 		if err != nil {
 			return nil, err
 		}
 
 	case InstTypeMargin:
-		symbol, err = ParseMarginSymbol(b.InstID)
+		symbol, err = rc.ParseMarginSymbol(b.InstID)
 		if err != nil {
 			return nil, err
 		}
 
 	case InstTypeSwap:
-		symbol, err = ParseSwapSymbol(b.InstID)
+		symbol, err = rc.ParseSwapSymbol(b.InstID)
 		if err != nil {
 			return nil, err
 		}
@@ -8,12 +8,18 @@ import (
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/clock"
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
 
 type (
 	RestClient struct {
-		client *okex.RestClient
+		client      *okex.RestClient
+		spotStore   *exchange.SymbolStore
+		swapStore   *exchange.SymbolStore
+		marginStore *exchange.SymbolStore
 	}
 
 	GetRequest struct {
@@ -44,16 +50,59 @@ func (gr *GetRequest) Values() url.Values {
 	return ret
 }
 
-func NewRestClient(key, secret, pass string) *RestClient {
-	return &RestClient{
-		client: okex.NewRestClient(key, secret, pass),
+func NewRestClient(key, secret, pass string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		client: okex.NewRestClient(key, secret, pass, opts...),
 	}
+	ret.initStores()
+	ret.SetRateLimiter(NewRateLimiter())
+	return ret
 }
 
-func NewTestRestClient(key, secret, pass string) *RestClient {
-	return &RestClient{
-		client: okex.NewTESTRestClient(key, secret, pass),
+func NewTestRestClient(key, secret, pass string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		client: okex.NewTESTRestClient(key, secret, pass, opts...),
 	}
+	ret.initStores()
+	ret.SetRateLimiter(NewRateLimiter())
+	return ret
+}
+
+//SetRateLimiter replace the rate limiter of the client, nil disable rate limit
+func (rc *RestClient) SetRateLimiter(l *ratelimit.Limiter) {
+	rc.client.SetRateLimiter(l)
+}
+
+//RateLimiter return the rate limiter of the client
+func (rc *RestClient) RateLimiter() *ratelimit.Limiter {
+	return rc.client.RateLimiter()
+}
+
+//Use append interceptors which run around every request of the client.
+//Use is not concurrent safe and should be called before sending requests
+func (rc *RestClient) Use(interceptors ...request.Interceptor) {
+	rc.client.Use(interceptors...)
+}
+
+//SetRetryPolicy replace the retry policy of the client, nil disable retry
+func (rc *RestClient) SetRetryPolicy(p *request.RetryPolicy) {
+	rc.client.SetRetryPolicy(p)
+}
+
+//RetryPolicy return the retry policy of the client
+func (rc *RestClient) RetryPolicy() *request.RetryPolicy {
+	return rc.client.RetryPolicy()
+}
+
+//SetClock set the clock which provide timestamp of signed requests, nil use
+//local time
+func (rc *RestClient) SetClock(c *clock.Clock) {
+	rc.client.SetClock(c)
+}
+
+//Clock return the clock of the client
+func (rc *RestClient) Clock() *clock.Clock {
+	return rc.client.Clock()
 }
 
 //Request do okexv5 rest request. response data field will be store into dst
@@ -66,10 +115,15 @@ func (rc *RestClient) Request(ctx context.Context, method string, endPoint strin
 		return errors.WithMessagef(err, "request %s fail", endPoint)
 	}
 
-	if resp.Code != "0" {
-		return errors.Errorf("request: %s fail code: %s msg: %s", endPoint, resp.Code, resp.Msg)
-	}
+	return nil
+}
 
+//APIError return the api error of response code, it's called by okex client
+//in each attempt so codes such as 50011 rate limited are retried
+func (rr *RestResponse) APIError() error {
+	if rr.Code != "0" {
+		return okex.NewAPIError(rr.Code, rr.Msg)
+	}
 	return nil
 }
 
@@ -0,0 +1,48 @@
+package okex5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+func TestRequestRetry(t *testing.T) {
+	called := 0
+	resps := []string{
+		`{"code":"50011","msg":"Requests too frequent.","data":[]}`,
+		`{"code":"50013","msg":"System is busy, please try again later","data":[]}`,
+		`{"code":"0","msg":"","data":[{"ts":"1597026383085"}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(resps[called]))
+		called++
+	}))
+	defer srv.Close()
+
+	rc := NewRestClient("", "", "", request.WithBaseURL(srv.URL))
+	rc.SetRetryPolicy(&request.RetryPolicy{MaxAttempts: 3})
+
+	var dst []struct {
+		TS string `json:"ts"`
+	}
+	if err := rc.Request(context.Background(), http.MethodGet, "/api/v5/public/time", nil, nil, false, &dst); err != nil {
+		t.Fatalf("request fail %s", err.Error())
+	}
+	if called != 3 || len(dst) != 1 || dst[0].TS != "1597026383085" {
+		t.Errorf("bad response called=%d dst=%v", called, dst)
+	}
+
+	called = 1
+	rc.SetRetryPolicy(nil)
+	err := rc.Request(context.Background(), http.MethodGet, "/api/v5/public/time", nil, nil, false, &dst)
+	var ae *okex.APIError
+	if !errors.Is(err, exchange.ErrOverloaded) || !errors.As(err, &ae) || ae.Code != "50013" {
+		t.Errorf("expect overloaded error got %v", err)
+	}
+}
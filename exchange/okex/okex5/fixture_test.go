@@ -0,0 +1,58 @@
+package okex5
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureTrade(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+	trader := NewTrader(rc)
+
+	swap, err := rc.ParseSwapSymbol("BTC-USDT-SWAP")
+	if err != nil {
+		t.Fatalf("parse swap symbol fail %s", err.Error())
+	}
+	if !swap.ContractVal().Equal(decimal.RequireFromString("0.01")) {
+		t.Errorf("bad swap symbol %v", swap)
+	}
+
+	o, err := trader.FetchOrder(ctx, &exchange.Order{ID: exchange.NewStrID("312269865356374016"), Symbol: swap})
+	if err != nil {
+		t.Fatalf("fetch order fail %s", err.Error())
+	}
+	if o.Side != exchange.OrderSideCloseLong || o.Status != exchange.OrderStatusDone || o.Type != exchange.OrderTypeLimit ||
+		!o.Fee.Equal(decimal.RequireFromString("0.08")) || !o.Filled.Equal(decimal.NewFromInt(10)) ||
+		o.Created.Unix() != 1633072800 {
+		t.Errorf("bad order %+v", o)
+	}
+
+	spot, err := rc.ParseSpotSymbol("BTC-USDT")
+	if err != nil {
+		t.Fatalf("parse spot symbol fail %s", err.Error())
+	}
+	trades, err := rc.Trades(ctx, &exchange.TradeReqParam{Symbol: spot})
+	if err != nil {
+		t.Fatalf("fetch trades fail %s", err.Error())
+	}
+	if len(trades) != 1 {
+		t.Fatalf("bad trades %v", trades)
+	}
+	tr := trades[0]
+	if tr.ID != "1111" || tr.Side != exchange.OrderSideBuy || !tr.IsMaker ||
+		!tr.Price.Equal(decimal.RequireFromString("40001.5")) || tr.FeeCurrency != "BTC" {
+		t.Errorf("bad trade %+v", tr)
+	}
+
+	req := exchange.NewDecimalOrderRequest(spot, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit,
+		decimal.NewFromInt(40000), decimal.RequireFromString("0.01"))
+	if _, err := trader.CreateOrder(ctx, req); err == nil {
+		t.Errorf("expect create order fail")
+	}
+}
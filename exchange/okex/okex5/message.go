@@ -2,6 +2,7 @@ package okex5
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/NadiaSama/ccexgo/internal/rpc"
 	"github.com/pkg/errors"
@@ -9,7 +10,7 @@ import (
 
 type (
 	CodeC struct {
-		LastEvent string
+		LastEvent string //error response do not carry the channel, record the call id
 	}
 
 	wsReq struct {
@@ -18,9 +19,10 @@ type (
 	}
 
 	wsRespArg struct {
-		Channel string `json:"channel"`
-		InstId  string `json:"instId"`
-		Uly     string `json:"uly"`
+		Channel  string `json:"channel"`
+		InstType string `json:"instType"`
+		InstId   string `json:"instId"`
+		Uly      string `json:"uly"`
 	}
 
 	wsResp struct {
@@ -61,7 +63,7 @@ func (cc *CodeC) Encode(req rpc.Request) ([]byte, error) {
 		Op:   method,
 		Args: data,
 	}
-	cc.LastEvent = method
+	cc.LastEvent = req.ID()
 
 	return json.Marshal(&r)
 }
@@ -86,7 +88,7 @@ func (cc *CodeC) Decode(raw []byte) (rpc.Response, error) {
 			}, nil
 		}
 		return &rpc.Result{
-			ID:     cc.LastEvent,
+			ID:     callID(resp.Event, &resp.Arg),
 			Result: raw,
 		}, nil
 	}
@@ -95,6 +97,11 @@ func (cc *CodeC) Decode(raw []byte) (rpc.Response, error) {
 	return resp.transfer()
 }
 
+//callID return id of the call which is matched by the ack of arg channel
+func callID(op string, arg *wsRespArg) string {
+	return strings.Join([]string{op, arg.Channel, arg.InstType, arg.Uly, arg.InstId}, ":")
+}
+
 func (r *wsResp) transfer() (*rpc.Notify, error) {
 	cb, ok := parseCBMap[r.Arg.Channel]
 
@@ -0,0 +1,298 @@
+package okex5
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var _ exchange.Trader = (*Trader)(nil)
+
+type (
+	//Trader implement exchange.Trader with RestClient. the native order
+	//methods of RestClient are kept with their original signature
+	Trader struct {
+		*RestClient
+	}
+)
+
+var (
+	state2Status = map[OrderState]exchange.OrderStatus{
+		OrderStateLive:            exchange.OrderStatusOpen,
+		OrderStatePartiallyFilled: exchange.OrderStatusOpen,
+		OrderStateFilled:          exchange.OrderStatusDone,
+		OrderStateCanceled:        exchange.OrderStatusCancel,
+	}
+
+	ordType2Type = map[OrdType]exchange.OrderType{
+		OrdTypeLimit:           exchange.OrderTypeLimit,
+		OrdTypePostOnly:        exchange.OrderTypeLimit,
+		OrdTypeFOK:             exchange.OrderTypeLimit,
+		OrdTypeIOC:             exchange.OrderTypeLimit,
+		OrdTypeMaket:           exchange.OrderTypeMarket,
+		OrdTypeOptimalLimitIOC: exchange.OrderTypeMarket,
+	}
+)
+
+//NewTrader return exchange.Trader of rc
+func NewTrader(rc *RestClient) *Trader {
+	return &Trader{
+		RestClient: rc,
+	}
+}
+
+//CreateOrder create order with exchange.OrderRequest. spot symbol use cash
+//mode, margin and swap symbol use cross mode. OrderSideCloseLong and
+//OrderSideCloseShort are translated to reduceOnly order
+func (tr *Trader) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	cr := CreateOrderReq{
+		InstID: req.Symbol.String(),
+		Sz:     req.Amount.String(),
+	}
+
+	switch req.Symbol.(type) {
+	case *MarginSymbol:
+		cr.TDMode = TDModeCross
+	case *SwapSymbol:
+		cr.TDMode = TDModeCross
+	case *SpotSymbol:
+		cr.TDMode = TDModeCash
+	default:
+		return nil, exchange.NewBadArg("unsupport symbol", req.Symbol)
+	}
+
+	switch req.Side {
+	case exchange.OrderSideBuy:
+		cr.Side = OrderSideBuy
+	case exchange.OrderSideSell:
+		cr.Side = OrderSideSell
+	case exchange.OrderSideCloseLong:
+		cr.Side = OrderSideSell
+		cr.ReduecOnly = true
+	case exchange.OrderSideCloseShort:
+		cr.Side = OrderSideBuy
+		cr.ReduecOnly = true
+	default:
+		return nil, exchange.NewBadArg("unsupport order side", req.Side)
+	}
+
+	switch req.Type {
+	case exchange.OrderTypeLimit:
+		cr.OrdType = OrdTypeLimit
+		cr.Px = req.Price.String()
+	case exchange.OrderTypeMarket:
+		cr.OrdType = OrdTypeMaket
+	default:
+		return nil, exchange.NewBadArg("unsupport order type", req.Type)
+	}
+
+	if req.ClientID != nil {
+		cr.ClOrderID = req.ClientID.String()
+	}
+
+	for _, opt := range options {
+		if cr.OrdType != OrdTypeLimit {
+			return nil, exchange.NewBadArg("option only support limit order", opt)
+		}
+
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			if t.PostOnly {
+				cr.OrdType = OrdTypePostOnly
+			}
+
+		case *exchange.TimeInForceOption:
+			switch t.Flag {
+			case exchange.TimeInForceGTC:
+			case exchange.TimeInForceIOC:
+				cr.OrdType = OrdTypeIOC
+			case exchange.TimeInForceFOK:
+				cr.OrdType = OrdTypeFOK
+			default:
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
+			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+
+	resp, err := tr.RestClient.CreateOrder(ctx, &cr)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.SCode != CodeOK {
+		return nil, errors.WithMessage(okex.NewAPIError(resp.SCode, resp.SMsg), "create order fail")
+	}
+
+	return &exchange.Order{
+		ID:       exchange.NewStrID(resp.OrderID),
+		ClientID: exchange.NewStrID(resp.ClOrderID),
+		Symbol:   req.Symbol,
+		Status:   exchange.OrderStatusOpen,
+		Raw:      resp,
+	}, nil
+}
+
+//CancelOrder cancel the order and return the latest order info
+func (tr *Trader) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	resp, err := tr.RestClient.CancelOrder(ctx, &CancelOrderReq{
+		InstID: order.Symbol.String(),
+		OrdId:  order.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.SCode != CodeOK {
+		return nil, errors.WithMessage(okex.NewAPIError(resp.SCode, resp.SMsg), "cancel order fail")
+	}
+
+	return tr.FetchOrder(ctx, order)
+}
+
+func (tr *Trader) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	resp, err := tr.RestClient.FetchOrder(ctx, &FetchOrderReq{
+		InstID: order.Symbol.String(),
+		OrdID:  order.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.transform(tr.RestClient)
+}
+
+//OpenOrders return unfinished orders of the symbol
+func (tr *Trader) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	values := url.Values{}
+	values.Add("instId", symbol.String())
+
+	var resp []Order
+	if err := tr.Request(ctx, http.MethodGet, OrdersPendingEndPoint, values, nil, true, &resp); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, len(resp))
+	for i := range resp {
+		o, err := resp[i].transform(tr.RestClient)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = o
+	}
+	return ret, nil
+}
+
+//Transform convert okex5 order to exchange.Order
+func (o *Order) Transform() (*exchange.Order, error) {
+	return o.transform(getDefaultClient())
+}
+
+func (o *Order) transform(rc *RestClient) (*exchange.Order, error) {
+	var (
+		symbol exchange.Symbol
+		err    error
+	)
+
+	switch o.InstType {
+	case InstTypeSwap:
+		symbol, err = rc.ParseSwapSymbol(o.InstId)
+	case InstTypeMargin:
+		symbol, err = rc.ParseMarginSymbol(o.InstId)
+	case InstTypeSpot:
+		symbol, err = rc.ParseSpotSymbol(o.InstId)
+	default:
+		err = errors.Errorf("unsupport instType '%s'", o.InstType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status, ok := state2Status[o.State]
+	if !ok {
+		return nil, errors.Errorf("unkown order state '%s'", o.State)
+	}
+
+	typ, ok := ordType2Type[o.OrderType]
+	if !ok {
+		return nil, errors.Errorf("unkown order type '%s'", o.OrderType)
+	}
+
+	var side exchange.OrderSide
+	switch {
+	case o.Side == OrderSideBuy && o.PosSide == PosSideShort:
+		side = exchange.OrderSideCloseShort
+	case o.Side == OrderSideSell && o.PosSide == PosSideLong:
+		side = exchange.OrderSideCloseLong
+	case o.Side == OrderSideBuy:
+		side = exchange.OrderSideBuy
+	default:
+		side = exchange.OrderSideSell
+	}
+
+	price, err := toDecimal(o.Px)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid px")
+	}
+	amount, err := toDecimal(o.Sz)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid sz")
+	}
+	filled, err := toDecimal(o.AccFillSZ)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid accFillSz")
+	}
+	avgPrice, err := toDecimal(o.AvgPx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid avgPx")
+	}
+	fee, err := toDecimal(o.Fee)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid fee")
+	}
+
+	created, err := ParseTimestamp(o.CTime)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid cTime")
+	}
+	updated, err := ParseTimestamp(o.UTime)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid uTime")
+	}
+
+	return &exchange.Order{
+		ID:          exchange.NewStrID(o.OrderID),
+		ClientID:    exchange.NewStrID(o.ClOrdID),
+		Symbol:      symbol,
+		Amount:      amount,
+		Filled:      filled,
+		Price:       price,
+		AvgPrice:    avgPrice,
+		Fee:         fee.Abs(),
+		FeeCurrency: o.FeeCcy,
+		Created:     created,
+		Updated:     updated,
+		Status:      status,
+		Side:        side,
+		Type:        typ,
+		Raw:         o,
+	}, nil
+}
+
+func toDecimal(str string) (decimal.Decimal, error) {
+	if str == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(str)
+}
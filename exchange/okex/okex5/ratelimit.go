@@ -0,0 +1,46 @@
+package okex5
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+)
+
+const (
+	BucketDefault = "default"
+)
+
+//NewRateLimiter return the default v5 api rate limiter. okex v5 limit
+//requests per endpoint, each configured endpoint own a bucket named with
+//the endpoint path and other endpoints share the default bucket
+func NewRateLimiter() *ratelimit.Limiter {
+	endPoints := []struct {
+		method   string
+		endPoint string
+		limit    int
+	}{
+		{http.MethodPost, CreateOrderEndPoint, 60},
+		{http.MethodGet, FetchOrderEndPoint, 60},
+		{http.MethodPost, CancelOrderEndPoint, 60},
+		{http.MethodGet, OrdersPendingEndPoint, 60},
+		{http.MethodGet, FillsEndPoint, 60},
+		{http.MethodGet, InstrumentEndPoint, 20},
+		{http.MethodGet, BooksEndPoint, 20},
+		{http.MethodGet, FundingEndPoint, 20},
+		{http.MethodGet, PositionsEndPoint, 10},
+		{http.MethodGet, AccountBalanceEndPoint, 10},
+	}
+
+	buckets := []*ratelimit.Bucket{ratelimit.NewBucket(BucketDefault, 10, 2*time.Second)}
+	for _, ep := range endPoints {
+		buckets = append(buckets, ratelimit.NewBucket(ep.method+" "+ep.endPoint, ep.limit, 2*time.Second))
+	}
+
+	ret := ratelimit.NewLimiter(ratelimit.PolicyBlock, buckets...).
+		SetDefault(ratelimit.Cost{Bucket: BucketDefault, Weight: 1})
+	for _, ep := range endPoints {
+		ret.SetWeight(ep.method, ep.endPoint, ratelimit.Cost{Bucket: ep.method + " " + ep.endPoint, Weight: 1})
+	}
+	return ret
+}
@@ -0,0 +1,56 @@
+package okex5
+
+import (
+	"context"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+const (
+	ExchangeName = "okex5"
+)
+
+func init() {
+	exchange.Register(&exchange.Registration{
+		Name: ExchangeName,
+		NewRestClient: func(cfg *exchange.Config) (interface{}, error) {
+			if cfg.TestNet {
+				return NewTestRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...), nil
+			}
+			return NewRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...), nil
+		},
+		NewTrader: func(cfg *exchange.Config) (exchange.Trader, error) {
+			if cfg.TestNet {
+				return NewTrader(NewTestRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...)), nil
+			}
+			return NewTrader(NewRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...)), nil
+		},
+		NewWSClient: func(cfg *exchange.Config, data chan interface{}) (exchange.WSConn, error) {
+			if cfg.TestNet {
+				return NewTestWSPublicClient(data), nil
+			}
+			return NewWSPublicClient(data), nil
+		},
+		LoadSymbols: func(ctx context.Context, cfg *exchange.Config) error {
+			if cfg.TestNet {
+				return InitTestSymbols(ctx)
+			}
+			return InitSymbols(ctx)
+		},
+		ParseSymbol: func(symbol string) (exchange.Symbol, error) {
+			return ParseSymbol(symbol)
+		},
+		//spot and swap symbols are kept in the returned store, margin symbols
+		//share instId with spot. use RestClient.NewSymbolStore for margin
+		NewSymbolStore: func(cfg *exchange.Config) (*exchange.SymbolStore, error) {
+			types := []InstType{InstTypeSpot, InstTypeSwap}
+			if cfg.TestNet {
+				return NewTestRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...).NewSymbolStore(types), nil
+			}
+			return NewRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...).NewSymbolStore(types), nil
+		},
+		Capabilities: exchange.CapabilityTrader | exchange.CapabilityPublicStream | exchange.CapabilityTestNet |
+			exchange.CapabilityTrades | exchange.CapabilityFinance,
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/pkg/errors"
@@ -54,9 +55,8 @@ const (
 )
 
 var (
-	spotSymbolMap   = map[string]exchange.SpotSymbol{}
-	swapSymbolMap   = map[string]exchange.SwapSymbol{}
-	marginSymbolMap = map[string]exchange.MarginSymbol{}
+	defaultClient   = NewRestClient("", "", "")
+	defaultClientMu sync.RWMutex
 )
 
 func (rc *RestClient) Instruments(ctx context.Context, typ InstType) ([]Instrument, error) {
@@ -175,73 +175,141 @@ func initSymbols(ctx context.Context, isTest bool) error {
 		rc = NewRestClient("", "", "")
 	}
 
-	spots, err := rc.SpotSymbols(ctx)
-	if err != nil {
+	if err := rc.LoadSymbols(ctx); err != nil {
 		return err
 	}
 
-	for _, s := range spots {
-		spotSymbolMap[s.String()] = s
-	}
+	defaultClientMu.Lock()
+	defaultClient = rc
+	defaultClientMu.Unlock()
+	return nil
+}
 
-	swaps, err := rc.SwapSymbols(ctx)
-	if err != nil {
-		return err
-	}
-	for _, s := range swaps {
-		swapSymbolMap[s.String()] = s
-	}
+func getDefaultClient() *RestClient {
+	defaultClientMu.RLock()
+	defer defaultClientMu.RUnlock()
+	return defaultClient
+}
 
-	margins, err := rc.MarginSymbols(ctx)
-	if err != nil {
-		return err
-	}
+func (rc *RestClient) initStores() {
+	rc.spotStore = rc.NewSymbolStore([]InstType{InstTypeSpot})
+	rc.swapStore = rc.NewSymbolStore([]InstType{InstTypeSwap})
+	rc.marginStore = rc.NewSymbolStore([]InstType{InstTypeMargin})
+}
 
-	for _, m := range margins {
-		marginSymbolMap[m.String()] = m
+//LoadSymbols refresh spot, swap and margin symbol stores of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	for _, store := range []*exchange.SymbolStore{rc.spotStore, rc.swapStore, rc.marginStore} {
+		if _, err := store.Refresh(ctx); err != nil {
+			return err
+		}
 	}
-
 	return nil
 }
 
-func ParseSymbol(sym string) (ret exchange.Symbol, err error) {
-	ret, err = ParseSpotSymbol(sym)
+//SymbolStores return spot, swap and margin symbol stores of rc, symbols of
+//rc responses are parsed with them
+func (rc *RestClient) SymbolStores() (spot *exchange.SymbolStore, swap *exchange.SymbolStore, margin *exchange.SymbolStore) {
+	return rc.spotStore, rc.swapStore, rc.marginStore
+}
+
+//ParseSymbol parse spot, margin or swap symbol with symbol stores of rc. the
+//default client stores are used if rc never load symbols
+func (rc *RestClient) ParseSymbol(sym string) (ret exchange.Symbol, err error) {
+	ret, err = rc.ParseSpotSymbol(sym)
 	if err == nil {
 		return ret, nil
 	}
 
-	ret, err = ParseMarginSymbol(sym)
+	ret, err = rc.ParseMarginSymbol(sym)
 	if err == nil {
 		return ret, nil
 	}
 
-	ret, err = ParseSwapSymbol(sym)
+	ret, err = rc.ParseSwapSymbol(sym)
 	if err == nil {
 		return ret, nil
 	}
 	return nil, err
 }
 
-func ParseSpotSymbol(sym string) (exchange.SpotSymbol, error) {
-	s, ok := spotSymbolMap[sym]
-	if !ok {
-		return nil, errors.Errorf("unsupport symbol '%s'", sym)
+func (rc *RestClient) ParseSpotSymbol(sym string) (exchange.SpotSymbol, error) {
+	s, err := lookupSymbol(rc.spotStore, getDefaultClient().spotStore, sym)
+	if err != nil {
+		return nil, err
 	}
+	return s.(exchange.SpotSymbol), nil
+}
 
-	return s, nil
+func (rc *RestClient) ParseMarginSymbol(sym string) (exchange.MarginSymbol, error) {
+	s, err := lookupSymbol(rc.marginStore, getDefaultClient().marginStore, sym)
+	if err != nil {
+		return nil, err
+	}
+	return s.(exchange.MarginSymbol), nil
 }
 
-func ParseMarginSymbol(sym string) (exchange.MarginSymbol, error) {
-	s, ok := marginSymbolMap[sym]
-	if !ok {
-		return nil, errors.Errorf("unsupport symbol '%s'", sym)
+func (rc *RestClient) ParseSwapSymbol(sym string) (exchange.SwapSymbol, error) {
+	s, err := lookupSymbol(rc.swapStore, getDefaultClient().swapStore, sym)
+	if err != nil {
+		return nil, err
 	}
-	return s, nil
+	return s.(exchange.SwapSymbol), nil
+}
+
+//NewSymbolStore return a symbol store which fetch symbols of types with rc.
+//spot and margin instruments share the same instId, they should be kept
+//in different stores
+func (rc *RestClient) NewSymbolStore(types []InstType, opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		var ret []exchange.Symbol
+		for _, typ := range types {
+			its, err := rc.Instruments(ctx, typ)
+			if err != nil {
+				return nil, err
+			}
+
+			for i := range its {
+				sym, err := its[i].Parse()
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, sym)
+			}
+		}
+		return ret, nil
+	}, opts...)
+}
+
+//SymbolStores return spot, swap and margin symbol stores of the default
+//client which is set by InitSymbols or InitTestSymbols
+func SymbolStores() (spot *exchange.SymbolStore, swap *exchange.SymbolStore, margin *exchange.SymbolStore) {
+	return getDefaultClient().SymbolStores()
+}
+
+func ParseSymbol(sym string) (ret exchange.Symbol, err error) {
+	return getDefaultClient().ParseSymbol(sym)
+}
+
+func ParseSpotSymbol(sym string) (exchange.SpotSymbol, error) {
+	return getDefaultClient().ParseSpotSymbol(sym)
+}
+
+func ParseMarginSymbol(sym string) (exchange.MarginSymbol, error) {
+	return getDefaultClient().ParseMarginSymbol(sym)
 }
 
 func ParseSwapSymbol(sym string) (exchange.SwapSymbol, error) {
-	s, ok := swapSymbolMap[sym]
-	if !ok {
+	return getDefaultClient().ParseSwapSymbol(sym)
+}
+
+//lookupSymbol lookup sym in store, fallback is used if store is never loaded
+func lookupSymbol(store *exchange.SymbolStore, fallback *exchange.SymbolStore, sym string) (exchange.Symbol, error) {
+	if store.Updated().IsZero() {
+		store = fallback
+	}
+	s, err := store.Lookup(sym)
+	if err != nil {
 		return nil, errors.Errorf("unsupport symbol '%s'", sym)
 	}
 	return s, nil
@@ -289,8 +357,10 @@ func (it *Instrument) Parse() (exchange.Symbol, error) {
 		if err != nil {
 			return nil, errors.WithMessagef(err, "parse ctrVal fail %+v", it)
 		}
+		swapCfg := *cfg
+		swapCfg.Inverse = it.CtType == "inverse"
 		return &SwapSymbol{
-			exchange.NewBaseSwapSymbolWithCfg(it.Uly, ctVal, *cfg, it),
+			exchange.NewBaseSwapSymbolWithCfg(it.Uly, ctVal, swapCfg, it),
 		}, nil
 
 	default:
@@ -0,0 +1,31 @@
+package okex5
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	ServerTimeResp struct {
+		TS string `json:"ts"`
+	}
+)
+
+const (
+	ServerTimeEndPoint = "/api/v5/public/time"
+)
+
+//ServerTime return okex v5 server time, it can be used as clock.Source
+func (rc *RestClient) ServerTime(ctx context.Context) (time.Time, error) {
+	var resp []ServerTimeResp
+	if err := rc.Request(ctx, http.MethodGet, ServerTimeEndPoint, nil, nil, false, &resp); err != nil {
+		return time.Time{}, err
+	}
+	if len(resp) != 1 {
+		return time.Time{}, errors.Errorf("bad server time resp %+v", resp)
+	}
+	return ParseTimestamp(resp[0].TS)
+}
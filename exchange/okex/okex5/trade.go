@@ -19,7 +19,7 @@ type (
 		InstID     string    `json:"instId"`
 		TDMode     TDMode    `json:"tdMode"`
 		Ccy        string    `json:"ccy,omitempty"`
-		ClOrderID  string    `json:"clOrderId,omitempty"`
+		ClOrderID  string    `json:"clOrdId,omitempty"`
 		Tag        string    `json:"tag,omitempty"`
 		Side       OrderSide `json:"side"`
 		PosSide    PosSide   `json:"posSide,omitempty"`
@@ -133,10 +133,11 @@ type (
 )
 
 const (
-	CreateOrderEndPoint = "/api/v5/trade/order"
-	FetchOrderEndPoint  = CreateOrderEndPoint
-	CancelOrderEndPoint = "/api/v5/trade/cancel-order"
-	FillsEndPoint       = "/api/v5/trade/fills"
+	CreateOrderEndPoint   = "/api/v5/trade/order"
+	FetchOrderEndPoint    = CreateOrderEndPoint
+	CancelOrderEndPoint   = "/api/v5/trade/cancel-order"
+	OrdersPendingEndPoint = "/api/v5/trade/orders-pending"
+	FillsEndPoint         = "/api/v5/trade/fills"
 )
 
 const ()
@@ -295,7 +296,7 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 
 	ret := []exchange.Trade{}
 	for _, f := range fills {
-		t, err := f.Parse()
+		t, err := f.parse(rc)
 		if err != nil {
 			return nil, err
 		}
@@ -306,6 +307,10 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 }
 
 func (f *Fill) Parse() (*exchange.Trade, error) {
+	return f.parse(getDefaultClient())
+}
+
+func (f *Fill) parse(rc *RestClient) (*exchange.Trade, error) {
 	var (
 		symbol exchange.Symbol
 		err    error
@@ -313,19 +318,19 @@ func (f *Fill) Parse() (*exchange.Trade, error) {
 
 	switch f.InstType {
 	case InstTypeSwap:
-		symbol, err = ParseSwapSymbol(f.InstID)
+		symbol, err = rc.ParseSwapSymbol(f.InstID)
 		if err != nil {
 			return nil, err
 		}
 
 	case InstTypeMargin:
-		symbol, err = ParseMarginSymbol(f.InstID)
+		symbol, err = rc.ParseMarginSymbol(f.InstID)
 		if err != nil {
 			return nil, err
 		}
 
 	case InstTypeSpot:
-		symbol, err = ParseSpotSymbol(f.InstID)
+		symbol, err = rc.ParseSpotSymbol(f.InstID)
 		if err != nil {
 			return nil, err
 		}
@@ -2,6 +2,7 @@ package okex5
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
@@ -12,10 +13,13 @@ import (
 type (
 	WSClient struct {
 		*exchange.WSClient
-		data   chan interface{}
+		*exchange.Delivery
 		key    string
 		secret string
 		passwd string
+		//error response do not carry the channel, calls are serialized so
+		//the error is matched to the only inflight call
+		callMu sync.Mutex
 	}
 
 	Okex5Channel struct {
@@ -46,34 +50,17 @@ func NewTestWSPublicClient(data chan interface{}) *WSClient {
 
 func newWSClient(addr string, data chan interface{}) *WSClient {
 	ret := &WSClient{
-		data: data,
+		Delivery: exchange.NewDelivery(data, exchange.OverflowDropNewest),
 	}
 	ret.WSClient = exchange.NewWSClient(addr, NewCodec(), ret)
+	ret.WSClient.SetHeartbeat(&rpc.Heartbeat{
+		Interval:    time.Second * 25,
+		Ping:        rpc.PingCall("1", pingMethod, ""),
+		IdleTimeout: time.Minute,
+	})
 	return ret
 }
 
-func (ws *WSClient) Run(ctx context.Context) error {
-	if err := ws.WSClient.Run(ctx); err != nil {
-		return err
-	}
-
-	go func() {
-		ticker := time.NewTicker(time.Second * 25)
-		for {
-			select {
-			case <-ticker.C:
-				ws.WSClient.Call(ctx, "1", pingMethod, "", nil)
-			case <-ws.WSClient.Done():
-				return
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
-
-	return nil
-}
-
 func (ws *WSClient) Handle(ctx context.Context, notify *rpc.Notify) {
 	data := &exchange.WSNotify{
 		Exchange: "okex",
@@ -81,10 +68,7 @@ func (ws *WSClient) Handle(ctx context.Context, notify *rpc.Notify) {
 		Data:     notify.Params,
 	}
 
-	select {
-	case ws.data <- data:
-	default:
-	}
+	ws.Deliver(ctx, data)
 }
 
 func (ws *WSClient) Subscribe(ctx context.Context, channels ...exchange.Channel) error {
@@ -95,7 +79,7 @@ func (ws *WSClient) Subscribe(ctx context.Context, channels ...exchange.Channel)
 	c := channels[0].(*Okex5Channel)
 
 	var resp wsResp
-	if err := ws.Call(ctx, MethodSubscribe, MethodSubscribe, []Okex5Channel{*c}, &resp); err != nil {
+	if err := ws.call(ctx, MethodSubscribe, c, &resp); err != nil {
 		return errors.WithMessage(err, "subscribe error")
 	}
 	return nil
@@ -109,13 +93,25 @@ func (ws *WSClient) UnSubscribe(ctx context.Context, channels ...exchange.Channe
 	c := channels[0].(*Okex5Channel)
 
 	var resp wsResp
-	if err := ws.Call(ctx, MethodUnSubscribe, MethodUnSubscribe, []Okex5Channel{*c}, &resp); err != nil {
+	if err := ws.call(ctx, MethodUnSubscribe, c, &resp); err != nil {
 		return errors.WithMessage(err, "subscribe error")
 	}
 	return nil
 
 }
 
+func (ws *WSClient) call(ctx context.Context, method string, c *Okex5Channel, dest interface{}) error {
+	ws.callMu.Lock()
+	defer ws.callMu.Unlock()
+	id := callID(method, &wsRespArg{
+		Channel:  c.Channel,
+		InstType: string(c.InstType),
+		InstId:   c.InstID,
+		Uly:      c.Uly,
+	})
+	return ws.Call(ctx, id, method, []Okex5Channel{*c}, dest)
+}
+
 func (oc *Okex5Channel) String() string {
 	return ""
 }
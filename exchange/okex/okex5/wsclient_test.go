@@ -0,0 +1,72 @@
+package okex5
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+	"github.com/shopspring/decimal"
+)
+
+func TestWSClient(t *testing.T) {
+	//websocket notify are parsed with the default client stores
+	rc := NewRestClient("", "", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+	spot, _, _ := SymbolStores()
+	spot.Set(rc.spotStore.Symbols())
+
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Text(pingMethod), wstest.Frames(pongMethod)),
+			wstest.On(wstest.Field("op", MethodSubscribe), func(m *wstest.Message) []interface{} {
+				var req struct {
+					Args []Okex5Channel `json:"args"`
+				}
+				m.Decode(&req)
+				arg := req.Args[0]
+				if arg.InstID != "BTC-USDT" {
+					return []interface{}{map[string]string{"event": "error", "code": "60018", "msg": "channel doesn't exist"}}
+				}
+				return []interface{}{
+					map[string]interface{}{"event": "subscribe", "arg": arg},
+					map[string]interface{}{"arg": arg, "data": []Trade{
+						{InstID: "BTC-USDT", TradeID: "130639474", Px: "42219.9", Sz: "0.12", Side: OrderSideBuy, Ts: "1630048897897"},
+					}},
+				}
+			}),
+		)
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	data := make(chan interface{}, 1)
+	ws := newWSClient(srv.URL, data)
+	if err := ws.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer ws.Close()
+
+	if err := ws.Subscribe(ctx, NewTradesChannel("BTC-USDT")); err != nil {
+		t.Fatalf("subscribe fail %s", err.Error())
+	}
+
+	select {
+	case d := <-data:
+		notify := d.(*exchange.WSNotify)
+		trades := notify.Data.([]*exchange.Trade)
+		if notify.Chan != TradesChannel || len(trades) != 1 || trades[0].ID != "130639474" ||
+			!trades[0].Price.Equal(decimal.RequireFromString("42219.9")) || trades[0].Side != exchange.OrderSideBuy {
+			t.Errorf("bad notify %+v", notify)
+		}
+	case <-ctx.Done():
+		t.Fatalf("wait trades timeout")
+	}
+
+	if err := ws.Subscribe(ctx, NewTradesChannel("BTC-USD")); err == nil {
+		t.Errorf("expect subscribe fail")
+	}
+}
@@ -0,0 +1,19 @@
+package okex
+
+import (
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ratelimit"
+)
+
+const (
+	BucketDefault = "default"
+)
+
+//NewRateLimiter return the default v3 api rate limiter which allow
+//20 requests every 2 seconds
+func NewRateLimiter() *ratelimit.Limiter {
+	return ratelimit.NewLimiter(ratelimit.PolicyBlock,
+		ratelimit.NewBucket(BucketDefault, 20, 2*time.Second),
+	).SetDefault(ratelimit.Cost{Bucket: BucketDefault, Weight: 1})
+}
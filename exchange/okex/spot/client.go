@@ -1,21 +1,30 @@
 package spot
 
-import "github.com/NadiaSama/ccexgo/exchange/okex"
+import (
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
 
 type (
 	RestClient struct {
 		*okex.RestClient
+		store *exchange.SymbolStore
 	}
 )
 
-func NewRestClient(key, secret, pass string) *RestClient {
-	return &RestClient{
-		okex.NewRestClient(key, secret, pass),
+func NewRestClient(key, secret, pass string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		RestClient: okex.NewRestClient(key, secret, pass, opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	return ret
 }
 
-func NewTestRestClient(key, secret, pass string) *RestClient {
-	return &RestClient{
-		okex.NewTESTRestClient(key, secret, pass),
+func NewTestRestClient(key, secret, pass string, opts ...request.Option) *RestClient {
+	ret := &RestClient{
+		RestClient: okex.NewTESTRestClient(key, secret, pass, opts...),
 	}
+	ret.store = ret.NewSymbolStore()
+	return ret
 }
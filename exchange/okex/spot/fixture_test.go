@@ -0,0 +1,60 @@
+package spot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureOrders(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+	trader := NewTrader(rc)
+
+	sym, err := rc.ParseSymbol("BTC-USDT")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	if !sym.PricePrecision().Equal(decimal.RequireFromString("0.1")) || !sym.AmountMin().Equal(decimal.RequireFromString("0.00001")) {
+		t.Errorf("bad symbol %v", sym)
+	}
+
+	fills, err := rc.Fills(ctx, sym.String(), "7665237423958016", "", "", "")
+	if err != nil {
+		t.Fatalf("fetch fills fail %s", err.Error())
+	}
+	if len(fills) != 2 || fills[0].ExecType != "M" || !fills[0].Fee.Equal(decimal.RequireFromString("-0.000008")) ||
+		fills[1].Currency != "USDT" {
+		t.Errorf("bad fills %+v", fills)
+	}
+
+	o, err := trader.FetchOrder(ctx, &exchange.Order{ID: exchange.NewStrID("7665237423958016"), Symbol: sym})
+	if err != nil {
+		t.Fatalf("fetch order fail %s", err.Error())
+	}
+	if o.Side != exchange.OrderSideBuy || o.Type != exchange.OrderTypeLimit || o.Status != exchange.OrderStatusDone ||
+		!o.Filled.Equal(decimal.RequireFromString("0.01")) || !o.Fee.Equal(decimal.RequireFromString("0.000008")) ||
+		o.Created.Unix() != 1633072800 {
+		t.Errorf("bad order %+v", o)
+	}
+
+	o, err = trader.CancelOrder(ctx, &exchange.Order{ID: exchange.NewStrID("7665237423958016"), Symbol: sym})
+	if err != nil {
+		t.Fatalf("cancel order fail %s", err.Error())
+	}
+	if o.ID.String() != "7665237423958016" || o.Status != exchange.OrderStatusDone {
+		t.Errorf("bad cancel order %+v", o)
+	}
+
+	req := exchange.NewDecimalOrderRequest(sym, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit,
+		decimal.NewFromInt(43000), decimal.RequireFromString("0.01"))
+	_, err = trader.CreateOrder(ctx, req)
+	if !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("expect insufficient balance got %v", err)
+	}
+}
@@ -11,10 +11,28 @@ import (
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 )
 
+var _ exchange.Trader = (*Trader)(nil)
+
+type (
+	//Trader implement exchange.Trader with RestClient, CancelOrder of
+	//RestClient keep its original signature
+	Trader struct {
+		*RestClient
+	}
+)
+
+//NewTrader return exchange.Trader of rc
+func NewTrader(rc *RestClient) *Trader {
+	return &Trader{
+		RestClient: rc,
+	}
+}
+
 type (
 	OrderParam struct {
 		Type         string `json:"type"`
@@ -67,7 +85,8 @@ const (
 	OrderTypeFOK      = "2"
 	OrderTypeIOC      = "3"
 
-	CreateOrderEndPoint = "/api/spot/v3/orders"
+	CreateOrderEndPoint   = "/api/spot/v3/orders"
+	OrdersPendingEndPoint = "/api/spot/v3/orders_pending"
 )
 
 var (
@@ -84,6 +103,10 @@ var (
 
 //CreateOrder create a spot order
 func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
 	op := OrderParam{
 		InstrumentID: req.Symbol.String(),
 		Size:         req.Amount.String(),
@@ -123,7 +146,7 @@ func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 		switch t := option.(type) {
 		case *exchange.PostOnlyOption:
 			if t.PostOnly {
-				op.OrderType = OrderTypeMarket
+				op.OrderType = OrderTypePostOnly
 			}
 
 		case *exchange.TimeInForceOption:
@@ -134,6 +157,9 @@ func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 			} else {
 				return nil, errors.Errorf("unsuport timeinfor option %s", t.Flag)
 			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(option)
 		}
 	}
 
@@ -148,6 +174,7 @@ func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 
 func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
 	u := fmt.Sprintf("/api/spot/v3/orders/%s", order.ID.String())
+	ctx = request.WithEndpoint(ctx, "/api/spot/v3/orders/{order_id}")
 	params := url.Values{}
 	params.Add("instrument_id", order.Symbol.String())
 
@@ -156,28 +183,58 @@ func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*e
 		return nil, err
 	}
 
-	return resp.Transform()
+	return resp.transform(rc)
 }
 
 func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) error {
 	u := fmt.Sprintf("/api/spot/v3/cancel_orders/%s", order.ID.String())
+	ctx = request.WithEndpoint(ctx, "/api/spot/v3/cancel_orders/{order_id}")
 	params := url.Values{}
 	params.Add("instrument_id", order.Symbol.String())
 
 	var resp OrderResponse
-	if err := rc.Request(ctx, http.MethodPost, u, params, nil, true, &resp); err != nil {
+	if err := rc.Request(ctx, http.MethodPost, u, params, bytes.NewBuffer([]byte{}), true, &resp); err != nil {
 		return err
 	}
 
 	if !resp.Result {
-		return errors.Errorf("cancel order error error_code=%s error_message='%s'", resp.ErrorCode, resp.ErrorMessage)
+		return errors.WithMessage(okex.NewAPIError(resp.ErrorCode, resp.ErrorMessage), "cancel order error")
 	}
 	return nil
 }
 
+//CancelOrder cancel the order and return the latest order info
+func (tr *Trader) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	if err := tr.RestClient.CancelOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	return tr.FetchOrder(ctx, order)
+}
+
+//OpenOrders return unfinished orders of the symbol
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	params := url.Values{}
+	params.Add("instrument_id", symbol.String())
+
+	var resp []FetchOrderResponse
+	if err := rc.Request(ctx, http.MethodGet, OrdersPendingEndPoint, params, nil, true, &resp); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, len(resp))
+	for i := range resp {
+		o, err := resp[i].transform(rc)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = o
+	}
+	return ret, nil
+}
+
 func (resp *OrderResponse) Transform(sym exchange.Symbol) (*exchange.Order, error) {
 	if !resp.Result {
-		return nil, errors.Errorf("create order fail error_code=%s error_message='%s'", resp.ErrorCode, resp.ErrorMessage)
+		return nil, errors.WithMessage(okex.NewAPIError(resp.ErrorCode, resp.ErrorMessage), "create order fail")
 	}
 	return &exchange.Order{
 		Symbol:   sym,
@@ -189,6 +246,10 @@ func (resp *OrderResponse) Transform(sym exchange.Symbol) (*exchange.Order, erro
 }
 
 func (fResp *FetchOrderResponse) Transform() (*exchange.Order, error) {
+	return fResp.transform(getDefaultClient())
+}
+
+func (fResp *FetchOrderResponse) transform(rc *RestClient) (*exchange.Order, error) {
 	var (
 		side exchange.OrderSide
 	)
@@ -247,7 +308,7 @@ func (fResp *FetchOrderResponse) Transform() (*exchange.Order, error) {
 		return nil, err
 	}
 
-	sym, err := ParseSymbol(fResp.InstrumentID)
+	sym, err := rc.ParseSymbol(fResp.InstrumentID)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/pkg/errors"
@@ -27,7 +28,8 @@ type (
 )
 
 var (
-	symbolMap map[string]exchange.SpotSymbol = map[string]exchange.SpotSymbol{}
+	symbolMu      sync.RWMutex
+	defaultClient = NewRestClient("", "", "")
 )
 
 func okexInit(ctx context.Context, test bool) error {
@@ -38,17 +40,22 @@ func okexInit(ctx context.Context, test bool) error {
 		client = NewRestClient("", "", "")
 	}
 
-	symbols, err := client.Symbols(ctx)
-	if err != nil {
+	if err := client.LoadSymbols(ctx); err != nil {
 		return err
 	}
 
-	for _, sym := range symbols {
-		symbolMap[sym.String()] = sym
-	}
+	symbolMu.Lock()
+	defer symbolMu.Unlock()
+	defaultClient = client
 	return nil
 }
 
+func getDefaultClient() *RestClient {
+	symbolMu.RLock()
+	defer symbolMu.RUnlock()
+	return defaultClient
+}
+
 func Init(ctx context.Context) error {
 	return okexInit(ctx, false)
 }
@@ -57,12 +64,54 @@ func TestnetInit(ctx context.Context) error {
 	return okexInit(ctx, true)
 }
 
+//SymbolStore return symbol store of the default client which is used by ParseSymbol
+func SymbolStore() *exchange.SymbolStore {
+	return getDefaultClient().SymbolStore()
+}
+
 func ParseSymbol(symbol string) (exchange.SpotSymbol, error) {
-	ret, ok := symbolMap[symbol]
-	if !ok {
+	return getDefaultClient().ParseSymbol(symbol)
+}
+
+//SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
+
+//LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
+}
+
+//ParseSymbol parse symbol with symbol store of rc. the default client store
+//is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(symbol string) (exchange.SpotSymbol, error) {
+	store := rc.store
+	if store.Updated().IsZero() {
+		store = getDefaultClient().store
+	}
+	ret, err := store.Lookup(symbol)
+	if err != nil {
 		return nil, errors.Errorf("unkown symbol %s", symbol)
 	}
-	return ret, nil
+	return ret.(exchange.SpotSymbol), nil
+}
+
+//NewSymbolStore return a symbol store which fetch symbols with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		symbols, err := rc.Symbols(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]exchange.Symbol, len(symbols))
+		for i, s := range symbols {
+			ret[i] = s
+		}
+		return ret, nil
+	}, opts...)
 }
 
 func (rc *RestClient) Symbols(ctx context.Context) ([]exchange.SpotSymbol, error) {
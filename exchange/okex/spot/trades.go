@@ -52,7 +52,7 @@ func parseTradeCB(table string, action string, data json.RawMessage) (*rpc.Notif
 
 	ret := make([]exchange.PublicTrade, 0, len(trades))
 	for _, t := range trades {
-		pt, err := t.Transform()
+		pt, err := t.transform(getDefaultClient())
 		if err != nil {
 			return nil, err
 		}
@@ -66,7 +66,11 @@ func parseTradeCB(table string, action string, data json.RawMessage) (*rpc.Notif
 }
 
 func (t *Trade) Transform() (*exchange.PublicTrade, error) {
-	sym, err := ParseSymbol(t.InstrumentID)
+	return t.transform(getDefaultClient())
+}
+
+func (t *Trade) transform(rc *RestClient) (*exchange.PublicTrade, error) {
+	sym, err := rc.ParseSymbol(t.InstrumentID)
 	if err != nil {
 		return nil, err
 	}
@@ -7,11 +7,13 @@ import (
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 )
 
 func (rc *RestClient) Ledgers(ctx context.Context, instrumentID string, before, after, limit, typ string) ([]okex.Ledger, error) {
 	endPoint := fmt.Sprintf("/api/swap/v3/accounts/%s/ledger", instrumentID)
+	ctx = request.WithEndpoint(ctx, "/api/swap/v3/accounts/{instrument_id}/ledger")
 
 	ret, err := okex.FetchLedgers(ctx, rc, endPoint, before, after, limit, typ)
 	return ret, err
@@ -35,7 +37,7 @@ func (rc *RestClient) Finance(ctx context.Context, req *exchange.FinanceReqParam
 
 	for i := range ledgers {
 		ledger := ledgers[i]
-		f, err := ledger.Parse(parseSymbol)
+		f, err := ledger.Parse(rc.parseSymbol)
 		if err != nil {
 			return nil, errors.WithMessage(err, "parse ledger fail")
 		}
@@ -44,8 +46,8 @@ func (rc *RestClient) Finance(ctx context.Context, req *exchange.FinanceReqParam
 	return ret, nil
 }
 
-func parseSymbol(sym string) (exchange.Symbol, error) {
-	s, err := ParseSymbol(sym)
+func (rc *RestClient) parseSymbol(sym string) (exchange.Symbol, error) {
+	s, err := rc.ParseSymbol(sym)
 	if err != nil {
 		return nil, err
 	}
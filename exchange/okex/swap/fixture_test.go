@@ -0,0 +1,64 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/fixture"
+	"github.com/shopspring/decimal"
+)
+
+func TestFixtureTrades(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRestClient("", "", "", fixture.TestOptions(t, fixture.Dir)...)
+	fixture.MustLoad(t, rc.LoadSymbols)
+
+	sym, err := rc.ParseSymbol("BTC-USDT-SWAP")
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+	if !sym.ContractVal().Equal(decimal.RequireFromString("0.01")) || !sym.PricePrecision().Equal(decimal.RequireFromString("0.1")) {
+		t.Errorf("bad symbol %v", sym)
+	}
+
+	trades, err := rc.Trades(ctx, &exchange.TradeReqParam{Symbol: sym})
+	if err != nil {
+		t.Fatalf("fetch trades fail %s", err.Error())
+	}
+	if len(trades) != 1 {
+		t.Fatalf("bad trades %v", trades)
+	}
+	tr := trades[0]
+	if tr.ID != "197429674631450625" || tr.Side != exchange.OrderSideCloseShort || tr.IsMaker ||
+		!tr.Amount.Equal(decimal.NewFromInt(10)) || tr.Time.Unix() != 1633072800 {
+		t.Errorf("bad trade %+v", tr)
+	}
+
+	positions, err := rc.FetchPosition(ctx, sym)
+	if err != nil {
+		t.Fatalf("fetch position fail %s", err.Error())
+	}
+	if len(positions) != 1 || positions[0].Mode != exchange.PositionModeCross || positions[0].Side != exchange.PositionSideLong ||
+		!positions[0].Position.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("bad positions %+v", positions)
+	}
+
+	finances, err := rc.Finance(ctx, &exchange.FinanceReqParam{
+		TradeReqParam: exchange.TradeReqParam{Symbol: sym},
+		Type:          exchange.FinanceTypeFunding,
+	})
+	if err != nil {
+		t.Fatalf("fetch finance fail %s", err.Error())
+	}
+	if len(finances) != 1 || finances[0].Type != exchange.FinanceTypeFunding ||
+		!finances[0].Amount.Equal(decimal.RequireFromString("-0.0102")) || finances[0].Symbol.String() != "BTC-USDT-SWAP" {
+		t.Errorf("bad finances %+v", finances)
+	}
+
+	_, err = NewTrader(rc).FetchOrder(ctx, &exchange.Order{ID: exchange.NewStrID("1"), Symbol: sym})
+	if !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("expect order not found got %v", err)
+	}
+}
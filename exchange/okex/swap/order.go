@@ -6,17 +6,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/okex"
 	"github.com/NadiaSama/ccexgo/internal/rpc"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 )
 
 const (
-	orderTable    = "swap/order"
-	orderEndPoint = "/api/swap/v3/order"
+	orderTable     = "swap/order"
+	orderEndPoint  = "/api/swap/v3/order"
+	ordersEndPoint = "/api/swap/v3/orders"
+
+	//orderStateUnfinished query open and partial filled orders
+	orderStateUnfinished = "6"
 
 	orderTypeNormal = "0"
 	orderTypeMaker  = "1"
@@ -25,6 +31,23 @@ const (
 	orderTypeMarket = "4"
 )
 
+var _ exchange.Trader = (*Trader)(nil)
+
+type (
+	//Trader implement exchange.Trader with RestClient, CancelOrder of
+	//RestClient keep its original signature
+	Trader struct {
+		*RestClient
+	}
+)
+
+//NewTrader return exchange.Trader of rc
+func NewTrader(rc *RestClient) *Trader {
+	return &Trader{
+		RestClient: rc,
+	}
+}
+
 type (
 	OrderChannel struct {
 		sym exchange.SwapSymbol
@@ -63,6 +86,10 @@ type (
 		ErrorMessage string `json:"error_message"`
 		Result       string `json:"result"`
 	}
+
+	ordersResponse struct {
+		OrderInfo []Order `json:"order_info"`
+	}
 )
 
 var (
@@ -126,7 +153,11 @@ func parseOrderCB(table string, action string, raw json.RawMessage) (*rpc.Notify
 }
 
 func (o *Order) Transform() (*exchange.Order, error) {
-	sym, err := ParseSymbol(o.InstrumentID)
+	return o.transform(defaultClient)
+}
+
+func (o *Order) transform(rc *RestClient) (*exchange.Order, error) {
+	sym, err := rc.ParseSymbol(o.InstrumentID)
 	if err != nil {
 		return nil, err
 	}
@@ -163,6 +194,10 @@ func (o *Order) Transform() (*exchange.Order, error) {
 }
 
 func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+
 	oReq := orderRequest{
 		Size:         req.Amount.String(),
 		InstrumentID: req.Symbol.String(),
@@ -192,14 +227,21 @@ func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 	for _, opt := range options {
 		switch t := opt.(type) {
 		case *exchange.PostOnlyOption:
-			oReq.OrderType = orderTypeMaker
+			if t.PostOnly {
+				oReq.OrderType = orderTypeMaker
+			}
 
 		case *exchange.TimeInForceOption:
 			if t.Flag == exchange.TimeInForceFOK {
 				oReq.OrderType = orderTypeFOK
 			} else if t.Flag == exchange.TimeInForceIOC {
 				oReq.OrderType = orderTypeIOC
+			} else if t.Flag != exchange.TimeInForceGTC {
+				return nil, exchange.NewBadArg("invalid TimeInForceOption", t)
 			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
 		}
 	}
 
@@ -222,6 +264,7 @@ func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 
 func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) error {
 	endPoint := fmt.Sprintf("/api/swap/v3/cancel_order/%s/%s", order.Symbol.String(), order.ID.String())
+	ctx = request.WithEndpoint(ctx, "/api/swap/v3/cancel_order/{instrument_id}/{order_id}")
 	var resp orderResponse
 	if err := rc.Request(ctx, http.MethodPost, endPoint, nil, bytes.NewBuffer([]byte{}), true, &resp); err != nil {
 		return err
@@ -230,10 +273,50 @@ func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) er
 	return resp.Error()
 }
 
+//CancelOrder cancel the order and return the latest order info
+func (tr *Trader) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	if err := tr.RestClient.CancelOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	return tr.FetchOrder(ctx, order)
+}
+
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	endPoint := fmt.Sprintf("%s/%s/%s", ordersEndPoint, order.Symbol.String(), order.ID.String())
+	ctx = request.WithEndpoint(ctx, "/api/swap/v3/orders/{instrument_id}/{order_id}")
+	var resp Order
+	if err := rc.Request(ctx, http.MethodGet, endPoint, nil, nil, true, &resp); err != nil {
+		return nil, err
+	}
+	return resp.transform(rc)
+}
+
+//OpenOrders return unfinished orders of the symbol
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	endPoint := fmt.Sprintf("%s/%s", ordersEndPoint, symbol.String())
+	ctx = request.WithEndpoint(ctx, "/api/swap/v3/orders/{instrument_id}")
+	params := url.Values{}
+	params.Add("state", orderStateUnfinished)
+
+	var resp ordersResponse
+	if err := rc.Request(ctx, http.MethodGet, endPoint, params, nil, true, &resp); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, len(resp.OrderInfo))
+	for i := range resp.OrderInfo {
+		o, err := resp.OrderInfo[i].transform(rc)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = o
+	}
+	return ret, nil
+}
+
 func (or *orderResponse) Error() error {
 	if or.ErrorCode != "0" {
-		return errors.Errorf("okex order response error code=%s msg=\"%s\"",
-			or.ErrorCode, or.ErrorMessage)
+		return okex.NewAPIError(or.ErrorCode, or.ErrorMessage)
 	}
 	return nil
 }
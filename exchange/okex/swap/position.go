@@ -7,6 +7,7 @@ import (
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/request"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 )
@@ -62,6 +63,7 @@ func (rc *RestClient) FetchPosition(ctx context.Context, sym ...exchange.Symbol)
 
 	if len(sym) == 1 {
 		uri = fmt.Sprintf("/api/swap/v3/%s/position", sym[0].String())
+		ctx = request.WithEndpoint(ctx, "/api/swap/v3/{instrument_id}/position")
 	} else {
 		uri = "/api/swap/v3/position"
 	}
@@ -78,7 +80,7 @@ func (rc *RestClient) FetchPosition(ctx context.Context, sym ...exchange.Symbol)
 		}
 
 		for _, pos := range mp.Holding {
-			p, err := pos.Transform(posMode)
+			p, err := pos.transform(rc, posMode)
 			if err != nil {
 				return nil, err
 			}
@@ -89,7 +91,11 @@ func (rc *RestClient) FetchPosition(ctx context.Context, sym ...exchange.Symbol)
 }
 
 func (pos *Position) Transform(posMode exchange.PositionMode) (*exchange.Position, error) {
-	sym, err := ParseSymbol(pos.InstrumentID)
+	return pos.transform(defaultClient, posMode)
+}
+
+func (pos *Position) transform(rc *RestClient, posMode exchange.PositionMode) (*exchange.Position, error) {
+	sym, err := rc.ParseSymbol(pos.InstrumentID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,39 @@
+package swap
+
+import (
+	"context"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/okex"
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+const (
+	ExchangeName = "okex.swap"
+)
+
+func init() {
+	exchange.Register(&exchange.Registration{
+		Name: ExchangeName,
+		NewRestClient: func(cfg *exchange.Config) (interface{}, error) {
+			return NewRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...), nil
+		},
+		NewTrader: func(cfg *exchange.Config) (exchange.Trader, error) {
+			return NewTrader(NewRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...)), nil
+		},
+		NewWSClient: func(cfg *exchange.Config, data chan interface{}) (exchange.WSConn, error) {
+			return okex.NewWSClient(cfg.Key, cfg.Secret, cfg.PassPhrase, data), nil
+		},
+		LoadSymbols: func(ctx context.Context, cfg *exchange.Config) error {
+			return Init(ctx)
+		},
+		ParseSymbol: func(symbol string) (exchange.Symbol, error) {
+			return ParseSymbol(symbol)
+		},
+		NewSymbolStore: func(cfg *exchange.Config) (*exchange.SymbolStore, error) {
+			return NewRestClient(cfg.Key, cfg.Secret, cfg.PassPhrase, request.ConfigOptions(cfg)...).NewSymbolStore(), nil
+		},
+		Capabilities: exchange.CapabilityTrader | exchange.CapabilityPublicStream | exchange.CapabilityPrivateStream |
+			exchange.CapabilityTrades | exchange.CapabilityFinance,
+	})
+}
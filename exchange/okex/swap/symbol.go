@@ -4,8 +4,6 @@ import (
 	"context"
 	"net/http"
 
-	"github.com/NadiaSama/ccexgo/exchange/okex"
-
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
@@ -40,22 +38,57 @@ const (
 )
 
 var (
-	symbolMap map[string]*Symbol = map[string]*Symbol{}
+	defaultClient = NewRestClient("", "", "")
 )
 
 func Init(ctx context.Context) error {
-	client := RestClient{
-		okex.NewRestClient("", "", ""),
+	return defaultClient.LoadSymbols(ctx)
+}
+
+//SymbolStore return symbol store of the default client which is used by ParseSymbol
+func SymbolStore() *exchange.SymbolStore {
+	return defaultClient.SymbolStore()
+}
+
+//SymbolStore return symbol store of rc, symbols of rc responses are parsed with it
+func (rc *RestClient) SymbolStore() *exchange.SymbolStore {
+	return rc.store
+}
+
+//LoadSymbols refresh symbol store of rc
+func (rc *RestClient) LoadSymbols(ctx context.Context) error {
+	_, err := rc.store.Refresh(ctx)
+	return err
+}
+
+//ParseSymbol parse symbol with symbol store of rc. the default client store
+//is used if rc never load symbols
+func (rc *RestClient) ParseSymbol(symbol string) (exchange.SwapSymbol, error) {
+	store := rc.store
+	if store.Updated().IsZero() {
+		store = defaultClient.store
 	}
-	syms, err := client.Symbols(ctx)
+	ret, err := store.Lookup(symbol)
 	if err != nil {
-		return err
+		return nil, errors.Errorf("unkown symbol=%s", symbol)
 	}
+	return ret.(exchange.SwapSymbol), nil
+}
 
-	for _, s := range syms {
-		symbolMap[s.String()] = s.(*Symbol)
-	}
-	return nil
+//NewSymbolStore return a symbol store which fetch symbols with rc
+func (rc *RestClient) NewSymbolStore(opts ...exchange.SymbolStoreOption) *exchange.SymbolStore {
+	return exchange.NewSymbolStore(func(ctx context.Context) ([]exchange.Symbol, error) {
+		symbols, err := rc.Symbols(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]exchange.Symbol, len(symbols))
+		for i, s := range symbols {
+			ret[i] = s
+		}
+		return ret, nil
+	}, opts...)
 }
 
 //Symbols return swap symbol
@@ -82,6 +115,7 @@ func (os *OkexSymbol) Parse() (*Symbol, error) {
 	cfg := exchange.SymbolConfig{
 		PricePrecision:  os.TickSize,
 		AmountPrecision: os.SizeIncrement,
+		Inverse:         os.IsInverse == "true",
 	}
 	return &Symbol{
 		exchange.NewBaseSwapSymbolWithCfg(os.Underlying, os.ContractVal, cfg, os),
@@ -93,10 +127,5 @@ func (s *Symbol) String() string {
 }
 
 func ParseSymbol(symbol string) (exchange.SwapSymbol, error) {
-	sym, ok := symbolMap[symbol]
-	if !ok {
-		return nil, errors.Errorf("unkown symbol=%s", symbol)
-	}
-
-	return sym, nil
+	return defaultClient.ParseSymbol(symbol)
 }
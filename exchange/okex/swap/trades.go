@@ -145,7 +145,7 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 	var ret []exchange.Trade
 	for i := range fills {
 		fill := fills[i]
-		trade, err := fill.Parse()
+		trade, err := fill.parse(rc)
 		if err != nil {
 			return nil, errors.WithMessage(err, "parse fill error")
 		}
@@ -155,7 +155,11 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 }
 
 func (f *Fill) Parse() (*exchange.Trade, error) {
-	s, err := ParseSymbol(f.InstrumentID)
+	return f.parse(defaultClient)
+}
+
+func (f *Fill) parse(rc *RestClient) (*exchange.Trade, error) {
+	s, err := rc.ParseSymbol(f.InstrumentID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,34 @@
+package okex
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	//ServerTimeResp okex v3 server time
+	ServerTimeResp struct {
+		ISO   string `json:"iso"`
+		Epoch string `json:"epoch"`
+	}
+)
+
+const (
+	ServerTimeEndPoint = "/api/general/v3/time"
+)
+
+//ServerTime return okex v3 server time, it can be used as clock.Source
+func (rc *RestClient) ServerTime(ctx context.Context) (time.Time, error) {
+	var resp ServerTimeResp
+	if err := rc.Request(ctx, http.MethodGet, ServerTimeEndPoint, nil, nil, false, &resp); err != nil {
+		return time.Time{}, errors.WithMessagef(err, "request %s fail", ServerTimeEndPoint)
+	}
+	ts, err := ParseTime(resp.ISO)
+	if err != nil {
+		return time.Time{}, errors.WithMessagef(err, "bad server time '%s'", resp.ISO)
+	}
+	return ts, nil
+}
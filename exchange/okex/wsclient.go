@@ -6,21 +6,26 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
 	"github.com/NadiaSama/ccexgo/internal/rpc"
+	"github.com/NadiaSama/ccexgo/misc/clock"
 	"github.com/pkg/errors"
 )
 
 type (
 	WSClient struct {
 		*exchange.WSClient
-		data       chan interface{}
+		*exchange.Delivery
 		Key        string
 		Secret     string
 		PassPhrase string
+		clock      *clock.Clock
+		//okex error response do not carry the channel, calls are serialized
+		//so the error is matched to the only inflight call
+		callMu sync.Mutex
 	}
 )
 
@@ -44,83 +49,35 @@ func NewTESTWSClient(key, secret, passPhrase string, data chan interface{}) *WSC
 
 func newWSClient(addr, key, secret, passPhrase string, data chan interface{}) *WSClient {
 	ret := &WSClient{
-		data:       data,
+		Delivery:   exchange.NewDelivery(data, exchange.OverflowDropNewest),
 		Key:        key,
 		Secret:     secret,
 		PassPhrase: passPhrase,
 	}
 	codec := NewCodeC()
 	ret.WSClient = exchange.NewWSClient(addr, codec, ret)
+	ret.WSClient.SetHeartbeat(&rpc.Heartbeat{
+		Interval:    time.Second * 5,
+		Ping:        rpc.PingCall(idPingPong, pingMsg, pingMessage),
+		IdleTimeout: time.Second * 30,
+	})
 	return ret
 }
 
 //Subscribe due to okex api limit subscribe result can not ensure
 func (ws *WSClient) Subscribe(ctx context.Context, channels ...exchange.Channel) error {
-
-	args := make([]string, len(channels))
-	for i, c := range channels {
-		args[i] = c.String()
-	}
-	cm := callParam{
-		OP:   opSubscribe,
-		Args: args,
-	}
-
-	var r response
-	if err := ws.Call(ctx, opSubscribe, opSubscribe, &cm, &r); err != nil {
-		return errors.WithMessagef(err, "subscribe error '%s'", strings.Join(args, ","))
+	if len(channels) == 0 {
+		return errors.Errorf("no channel to subscribe")
 	}
-	return nil
+	return ws.channelCall(ctx, opSubscribe, channels)
 }
 
 //UnSubscribe due to okex api limit subscribe result can not ensure
 func (ws *WSClient) UnSubscribe(ctx context.Context, channels ...exchange.Channel) error {
-	args := make([]string, len(channels))
-	for i, c := range channels {
-		args[i] = c.String()
-	}
-	cm := callParam{
-		OP:   opUnSubscribe,
-		Args: args,
-	}
-
-	var r response
-	if err := ws.Call(ctx, opUnSubscribe, opUnSubscribe, &cm, &r); err != nil {
-		return errors.WithMessagef(err, "unsubscribe error '%s'", strings.Join(args, ","))
-	}
-	return nil
-
-}
-
-//Run start the websocket loop and create a goroutine which
-//will send ping message to okex server periodically
-func (ws *WSClient) Run(ctx context.Context) error {
-	if err := ws.WSClient.Run(ctx); err != nil {
-		return err
+	if len(channels) == 0 {
+		return errors.Errorf("no channel to unsubscribe")
 	}
-
-	//period send ping message check the ws conn is correct
-	go func() {
-		ticker := time.NewTicker(time.Second * 5)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-
-			case <-ws.Done():
-				return
-
-			case <-ticker.C:
-				var msg map[string]interface{}
-				if err := ws.Call(ctx, idPingPong, pingMsg, pingMessage, &msg); err != nil {
-					//TODO make rpc.Conn fail method public?
-					ws.WSClient.Close()
-					return
-				}
-			}
-		}
-	}()
-	return nil
+	return ws.channelCall(ctx, opUnSubscribe, channels)
 }
 
 func (ws *WSClient) Handle(ctx context.Context, notify *rpc.Notify) {
@@ -129,15 +86,16 @@ func (ws *WSClient) Handle(ctx context.Context, notify *rpc.Notify) {
 		Chan:     notify.Method,
 		Data:     notify.Params,
 	}
-	select {
-	case ws.data <- data:
-	default:
-		return
-	}
+	ws.Deliver(ctx, data)
+}
+
+//SetClock set the clock which provide timestamp of login, nil use local time
+func (ws *WSClient) SetClock(c *clock.Clock) {
+	ws.clock = c
 }
 
 func (ws *WSClient) Auth(ctx context.Context) error {
-	timestamp := strconv.FormatFloat(float64(time.Now().UnixNano()/1e6/1000), 'f', -1, 64)
+	timestamp := strconv.FormatFloat(float64(ws.clock.Now().UnixNano()/1e6/1000), 'f', -1, 64)
 	h := hmac.New(sha256.New, []byte(ws.Secret))
 	h.Write([]byte(timestamp + "GET/users/self/verify"))
 	sign := base64.StdEncoding.EncodeToString(h.Sum(nil))
@@ -148,8 +106,32 @@ func (ws *WSClient) Auth(ctx context.Context) error {
 	}
 
 	var msg map[string]interface{}
-	if err := ws.Call(ctx, opLogin, opLogin, &cm, &msg); err != nil {
+	if err := ws.call(ctx, opLogin, opLogin, &cm, &msg); err != nil {
 		return errors.WithMessage(err, "okex login error")
 	}
 	return nil
 }
+
+//channelCall send op for each channel in turn so every channel's ack or error
+//is checked, the first fail channel stop the call
+func (ws *WSClient) channelCall(ctx context.Context, op string, channels []exchange.Channel) error {
+	for _, c := range channels {
+		arg := c.String()
+		cm := callParam{
+			OP:   op,
+			Args: []string{arg},
+		}
+
+		var r response
+		if err := ws.call(ctx, callID(op, arg), op, &cm, &r); err != nil {
+			return errors.WithMessagef(err, "%s error '%s'", op, arg)
+		}
+	}
+	return nil
+}
+
+func (ws *WSClient) call(ctx context.Context, id string, method string, param interface{}, dest interface{}) error {
+	ws.callMu.Lock()
+	defer ws.callMu.Unlock()
+	return ws.Call(ctx, id, method, param, dest)
+}
@@ -110,25 +110,33 @@ func NewTimeInForceOption(flag TimeInForceFlag) OrderReqOption {
 }
 
 //NewOrderRequest create a order request with given param, the price and amount field
-//will be formatted according to symbol precision config
+//will be formatted according to symbol precision config. see NewDecimalOrderRequest
 func NewOrderRequest(sym Symbol, cid OrderID, side OrderSide, typ OrderType,
 	price float64, amount float64) *OrderRequest {
+	return NewDecimalOrderRequest(sym, cid, side, typ, decimal.NewFromFloat(price), decimal.NewFromFloat(amount))
+}
 
-	pp, _ := sym.PricePrecision().Float64()
-	ap, _ := sym.AmountPrecision().Float64()
+//NewDecimalOrderRequest create a order request with decimal price and amount.
+//the price and amount are rounded toward zero according to symbol precision
+//config. see NewRoundedOrderRequest for side aware price rounding
+func NewDecimalOrderRequest(sym Symbol, cid OrderID, side OrderSide, typ OrderType,
+	price decimal.Decimal, amount decimal.Decimal) *OrderRequest {
+	return NewRoundedOrderRequest(sym, cid, side, typ, price, amount, RoundModeDown)
+}
 
-	price = float64(int(price/pp)) * pp
-	amount = float64(int(amount/ap)) * ap
-	ret := &OrderRequest{
+//NewRoundedOrderRequest create a order request with price rounded with
+//priceMode, PriceRoundMode(side) make sure the price is never worse than
+//requested. the amount is rounded toward zero
+func NewRoundedOrderRequest(sym Symbol, cid OrderID, side OrderSide, typ OrderType,
+	price decimal.Decimal, amount decimal.Decimal, priceMode RoundMode) *OrderRequest {
+	return &OrderRequest{
 		Symbol:   sym,
 		ClientID: cid,
 		Side:     side,
 		Type:     typ,
-		Price:    decimal.NewFromFloatWithExponent(price, sym.PriceExponent()),
-		Amount:   decimal.NewFromFloatWithExponent(amount, sym.AmountExponent()),
+		Price:    RoundWithMode(price, sym.PricePrecision(), priceMode),
+		Amount:   RoundWithMode(amount, sym.AmountPrecision(), RoundModeDown),
 	}
-
-	return ret
 }
 
 func NewStrID(id string) StrID {
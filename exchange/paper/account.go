@@ -0,0 +1,248 @@
+package paper
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	//position is one way position of a contract symbol. positive amount
+	//means long
+	position struct {
+		symbol   exchange.Symbol
+		cv       decimal.Decimal
+		amount   decimal.Decimal
+		entry    decimal.Decimal
+		realized decimal.Decimal
+		created  time.Time
+	}
+)
+
+//reserve check the balance is enough for fills and the rest amount of o which
+//will be placed on the book. balance required by the rest amount is frozen
+func (e *Exchange) reserve(o *order, fills []fill, rest decimal.Decimal) error {
+	taker := e.fee(o.Symbol, false)
+	buy := isBuy(o.Side)
+
+	fillQty := sumAmount(fills)
+	fillValue := decimal.Zero
+	for _, f := range fills {
+		fillValue = fillValue.Add(f.price.Mul(f.amount))
+	}
+	restValue := o.Price.Mul(rest)
+
+	var ccy string
+	var required, frozen decimal.Decimal
+	if sym, ok := o.Symbol.(exchange.SpotSymbol); ok {
+		if buy {
+			ccy = exchange.CurrencyFormat(sym.Quote())
+			required = fillValue.Add(restValue).Mul(taker.Add(decimal.NewFromInt(1)))
+			frozen = restValue.Mul(taker.Add(decimal.NewFromInt(1)))
+		} else {
+			ccy = exchange.CurrencyFormat(sym.Base())
+			required = fillQty.Add(rest)
+			frozen = rest
+		}
+	} else {
+		ccy = e.cfg.MarginCurrency
+		cv := contractVal(o.Symbol)
+		qty := fillQty.Add(rest)
+		if qty.IsZero() {
+			return nil
+		}
+
+		//only the amount which increase the position require margin
+		var cur decimal.Decimal
+		if pos, ok := e.positions[o.Symbol.String()]; ok {
+			cur = pos.amount
+		}
+		delta := qty
+		if !buy {
+			delta = qty.Neg()
+		}
+		ratio := cur.Add(delta).Abs().Sub(cur.Abs()).Div(qty)
+		if ratio.IsNegative() {
+			ratio = decimal.Zero
+		}
+
+		rate := ratio.Div(e.cfg.Leverage).Add(taker)
+		required = fillValue.Add(restValue).Mul(cv).Mul(rate)
+		frozen = restValue.Mul(cv).Mul(rate)
+	}
+
+	if avail := e.available(ccy); avail.LessThan(required) {
+		return exchange.NewAPIError(exchange.ErrInsufficientBalance, "insufficient_balance",
+			fmt.Sprintf("%s available=%s required=%s", ccy, avail, required))
+	}
+	o.frozenCcy = ccy
+	o.frozen = frozen
+	e.frozen[ccy] = e.frozen[ccy].Add(frozen)
+	return nil
+}
+
+//available return balance of ccy which is not used by orders and positions
+func (e *Exchange) available(ccy string) decimal.Decimal {
+	ret := e.wallet[ccy].Sub(e.frozen[ccy])
+	if ccy == e.cfg.MarginCurrency {
+		for _, pos := range e.positions {
+			ret = ret.Sub(pos.margin(e.cfg.Leverage))
+		}
+	}
+	return ret
+}
+
+func (e *Exchange) unfreeze(o *order, amount decimal.Decimal) {
+	if amount.IsZero() {
+		return
+	}
+	o.frozen = o.frozen.Sub(amount)
+	e.frozen[o.frozenCcy] = e.frozen[o.frozenCcy].Sub(amount)
+}
+
+//closable return position amount which can be closed by side
+func (e *Exchange) closable(sym exchange.Symbol, side exchange.OrderSide) decimal.Decimal {
+	pos, ok := e.positions[sym.String()]
+	if !ok {
+		return decimal.Zero
+	}
+	if side == exchange.OrderSideCloseLong && pos.amount.IsPositive() {
+		return pos.amount
+	}
+	if side == exchange.OrderSideCloseShort && pos.amount.IsNegative() {
+		return pos.amount.Neg()
+	}
+	return decimal.Zero
+}
+
+//fill apply a fill of order o to balances, positions and trades
+func (e *Exchange) fill(o *order, price decimal.Decimal, amount decimal.Decimal, maker bool) {
+	now := e.cfg.Now()
+	sym := o.Symbol
+	buy := isBuy(o.Side)
+
+	if remain := o.Amount.Sub(o.Filled); o.frozen.IsPositive() && remain.IsPositive() {
+		e.unfreeze(o, o.frozen.Mul(amount).Div(remain))
+	}
+
+	var feeCcy string
+	var fee decimal.Decimal
+	if s, ok := sym.(exchange.SpotSymbol); ok {
+		base := exchange.CurrencyFormat(s.Base())
+		quote := exchange.CurrencyFormat(s.Quote())
+		value := price.Mul(amount)
+		fee = value.Mul(e.fee(sym, maker))
+		feeCcy = quote
+		if buy {
+			e.wallet[quote] = e.wallet[quote].Sub(value).Sub(fee)
+			e.wallet[base] = e.wallet[base].Add(amount)
+		} else {
+			e.wallet[base] = e.wallet[base].Sub(amount)
+			e.wallet[quote] = e.wallet[quote].Add(value).Sub(fee)
+		}
+	} else {
+		key := sym.String()
+		pos, ok := e.positions[key]
+		if !ok {
+			pos = &position{symbol: sym, cv: contractVal(sym)}
+			e.positions[key] = pos
+		}
+
+		delta := amount
+		if !buy {
+			delta = amount.Neg()
+		}
+		fee = price.Mul(amount).Mul(pos.cv).Mul(e.fee(sym, maker))
+		feeCcy = e.cfg.MarginCurrency
+		pnl := pos.update(delta, price, now)
+		e.wallet[feeCcy] = e.wallet[feeCcy].Add(pnl).Sub(fee)
+	}
+
+	total := o.Filled.Add(amount)
+	o.AvgPrice = o.AvgPrice.Mul(o.Filled).Add(price.Mul(amount)).Div(total)
+	o.Filled = total
+	o.Fee = o.Fee.Sub(fee)
+	o.FeeCurrency = feeCcy
+	o.Updated = now
+
+	e.trades = append(e.trades, exchange.Trade{
+		ID:          strconv.Itoa(len(e.trades) + 1),
+		OrderID:     o.ID.String(),
+		Symbol:      sym,
+		Price:       price,
+		Amount:      amount,
+		Fee:         fee.Neg(),
+		FeeCurrency: feeCcy,
+		Time:        now,
+		Side:        o.Side,
+		IsMaker:     maker,
+	})
+
+	if o.Filled.Equal(o.Amount) {
+		e.finish(o, exchange.OrderStatusDone)
+	}
+}
+
+//update apply signed delta filled at price and return the realized pnl
+func (p *position) update(delta decimal.Decimal, price decimal.Decimal, now time.Time) decimal.Decimal {
+	if p.amount.IsZero() || p.amount.Sign() == delta.Sign() {
+		if p.amount.IsZero() {
+			p.created = now
+		}
+		total := p.amount.Add(delta)
+		p.entry = p.entry.Mul(p.amount.Abs()).Add(price.Mul(delta.Abs())).Div(total.Abs())
+		p.amount = total
+		return decimal.Zero
+	}
+
+	closed := decimal.Min(delta.Abs(), p.amount.Abs())
+	pnl := price.Sub(p.entry).Mul(closed).Mul(p.cv)
+	if p.amount.IsNegative() {
+		pnl = pnl.Neg()
+	}
+	p.realized = p.realized.Add(pnl)
+
+	reverse := delta.Abs().GreaterThan(closed)
+	p.amount = p.amount.Add(delta)
+	if p.amount.IsZero() {
+		p.entry = decimal.Zero
+	} else if reverse {
+		p.entry = price
+		p.created = now
+	}
+	return pnl
+}
+
+func (p *position) margin(leverage decimal.Decimal) decimal.Decimal {
+	return p.amount.Abs().Mul(p.entry).Mul(p.cv).Div(leverage)
+}
+
+func (p *position) unrealized(mark decimal.Decimal) decimal.Decimal {
+	if mark.IsZero() || p.amount.IsZero() {
+		return decimal.Zero
+	}
+	return mark.Sub(p.entry).Mul(p.amount).Mul(p.cv)
+}
+
+func (p *position) transform(leverage decimal.Decimal, mark decimal.Decimal) *exchange.Position {
+	ret := &exchange.Position{
+		Symbol:        p.symbol,
+		Mode:          exchange.PositionModeCross,
+		Side:          exchange.PositionSideLong,
+		AvgOpenPrice:  p.entry,
+		CreateTime:    p.created,
+		Margin:        p.margin(leverage),
+		Position:      p.amount.Abs(),
+		AvailPosition: p.amount.Abs(),
+		RealizedPNL:   p.realized,
+		UNRealizedPNL: p.unrealized(mark),
+		Leverage:      leverage,
+	}
+	if p.amount.IsNegative() {
+		ret.Side = exchange.PositionSideShort
+	}
+	return ret
+}
@@ -0,0 +1,141 @@
+package paper
+
+import (
+	"sort"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	level struct {
+		price  decimal.Decimal
+		amount decimal.Decimal
+	}
+
+	//book is the orderbook snapshot used to match orders. liquidity taken by
+	//paper orders is removed until next snapshot arrive
+	book struct {
+		bids    []level
+		asks    []level
+		created time.Time
+	}
+
+	fill struct {
+		price  decimal.Decimal
+		amount decimal.Decimal
+	}
+)
+
+func newBook(ob *exchange.OrderBook) *book {
+	convert := func(elems []exchange.OrderElem) []level {
+		ret := make([]level, 0, len(elems))
+		for _, elem := range elems {
+			if elem.Price <= 0 || elem.Amount <= 0 {
+				continue
+			}
+			ret = append(ret, level{
+				price:  decimal.NewFromFloat(elem.Price),
+				amount: decimal.NewFromFloat(elem.Amount),
+			})
+		}
+		return ret
+	}
+
+	ret := &book{
+		bids:    convert(ob.Bids),
+		asks:    convert(ob.Asks),
+		created: ob.Created,
+	}
+	sort.Slice(ret.bids, func(i, j int) bool {
+		return ret.bids[i].price.GreaterThan(ret.bids[j].price)
+	})
+	sort.Slice(ret.asks, func(i, j int) bool {
+		return ret.asks[i].price.LessThan(ret.asks[j].price)
+	})
+	return ret
+}
+
+//take walk the opposite side of book from the best price and return fills
+//of amount whose price is not worse than limit. limit is ignored for market
+//order. the book is not changed if dry is true
+func (b *book) take(buy bool, market bool, limit decimal.Decimal, amount decimal.Decimal, dry bool) []fill {
+	levels := b.asks
+	if !buy {
+		levels = b.bids
+	}
+
+	var ret []fill
+	for i := range levels {
+		if !amount.IsPositive() {
+			break
+		}
+		lv := &levels[i]
+		if !lv.amount.IsPositive() {
+			continue
+		}
+		if !market && (buy && lv.price.GreaterThan(limit) || !buy && lv.price.LessThan(limit)) {
+			break
+		}
+
+		amt := decimal.Min(amount, lv.amount)
+		ret = append(ret, fill{price: lv.price, amount: amt})
+		amount = amount.Sub(amt)
+		if !dry {
+			lv.amount = lv.amount.Sub(amt)
+		}
+	}
+	return ret
+}
+
+//mid return the mid price of best bid and ask, zero if either side is empty
+func (b *book) mid() decimal.Decimal {
+	if len(b.bids) == 0 || len(b.asks) == 0 {
+		return decimal.Zero
+	}
+	return b.bids[0].price.Add(b.asks[0].price).Div(decimal.NewFromInt(2))
+}
+
+func (e *Exchange) updateBook(ob *exchange.OrderBook) {
+	bk := newBook(ob)
+	e.books[ob.Symbol.String()] = bk
+
+	//resting orders are matched with time priority
+	orders := make([]*order, len(e.open))
+	copy(orders, e.open)
+	for _, o := range orders {
+		if o.Symbol.String() != ob.Symbol.String() {
+			continue
+		}
+
+		remain := o.Amount.Sub(o.Filled)
+		if isReduceOnly(o.Side) {
+			remain = decimal.Min(remain, e.closable(o.Symbol, o.Side))
+			if remain.IsZero() {
+				e.finish(o, exchange.OrderStatusCancel)
+				continue
+			}
+		}
+
+		for _, f := range bk.take(isBuy(o.Side), false, o.Price, remain, false) {
+			e.fill(o, o.Price, f.amount, true)
+		}
+	}
+}
+
+func (e *Exchange) markPrice(sym exchange.Symbol) decimal.Decimal {
+	bk, ok := e.books[sym.String()]
+	if !ok {
+		return decimal.Zero
+	}
+	return bk.mid()
+}
+
+func sumAmount(fills []fill) decimal.Decimal {
+	ret := decimal.Zero
+	for _, f := range fills {
+		ret = ret.Add(f.amount)
+	}
+	return ret
+}
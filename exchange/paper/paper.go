@@ -0,0 +1,522 @@
+//Package paper implement a paper trading exchange. orders are matched against
+//orderbook snapshots fed from any real adapter so that strategies can be
+//shadow run with real market data and zero capital risk.
+//
+//spot symbols(exchange.SpotSymbol) exchange base and quote balances. other
+//symbols are treated as linear contracts which are settled in
+//Config.MarginCurrency with one way(net) position
+package paper
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	//Config initial state of the paper exchange
+	Config struct {
+		//Balances initial wallet balances keyed by currency
+		Balances map[string]decimal.Decimal
+		//Fees trade fee keyed by symbol string
+		Fees map[string]*exchange.TradeFee
+		//DefaultFee used by symbols which are not in Fees, zero fee if nil
+		DefaultFee *exchange.TradeFee
+		//MarginCurrency settle currency of non spot symbols, default USDT
+		MarginCurrency string
+		//Leverage of non spot positions, default 1
+		Leverage decimal.Decimal
+		//Now return the current time, default time.Now
+		Now func() time.Time
+	}
+
+	//Exchange paper trading venue. it implement exchange.Trader and produce
+	//trades, balances and positions like a real exchange adapter
+	Exchange struct {
+		cfg *Config
+
+		mu        sync.Mutex
+		orderID   int64
+		books     map[string]*book
+		bookDS    map[string]*exchange.OrderBookDS
+		orders    map[string]*order
+		open      []*order
+		trades    []exchange.Trade
+		wallet    map[string]decimal.Decimal
+		frozen    map[string]decimal.Decimal
+		positions map[string]*position
+	}
+
+	order struct {
+		exchange.Order
+		tif      exchange.TimeInForceFlag
+		postOnly bool
+		//frozen balance reserved by the unfilled amount
+		frozen    decimal.Decimal
+		frozenCcy string
+	}
+)
+
+const (
+	//DefaultMarginCurrency settle currency of non spot symbols
+	DefaultMarginCurrency = "USDT"
+)
+
+var (
+	_ exchange.Trader = (*Exchange)(nil)
+)
+
+//NewConfig return config with zero balance and fee, 1x leverage and USDT margin
+func NewConfig() *Config {
+	return &Config{
+		Balances:       map[string]decimal.Decimal{},
+		Fees:           map[string]*exchange.TradeFee{},
+		MarginCurrency: DefaultMarginCurrency,
+		Leverage:       decimal.NewFromInt(1),
+	}
+}
+
+//NewExchange create paper exchange with cfg, NewConfig is used if cfg is nil
+func NewExchange(cfg *Config) *Exchange {
+	if cfg == nil {
+		cfg = NewConfig()
+	}
+	if cfg.MarginCurrency == "" {
+		cfg.MarginCurrency = DefaultMarginCurrency
+	}
+	if cfg.Leverage.Sign() <= 0 {
+		cfg.Leverage = decimal.NewFromInt(1)
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if cfg.Fees == nil {
+		cfg.Fees = map[string]*exchange.TradeFee{}
+	}
+
+	ret := &Exchange{
+		cfg:       cfg,
+		books:     map[string]*book{},
+		bookDS:    map[string]*exchange.OrderBookDS{},
+		orders:    map[string]*order{},
+		wallet:    map[string]decimal.Decimal{},
+		frozen:    map[string]decimal.Decimal{},
+		positions: map[string]*position{},
+	}
+	for ccy, amt := range cfg.Balances {
+		ret.wallet[exchange.CurrencyFormat(ccy)] = amt
+	}
+	return ret
+}
+
+//SetFee update trade fee of fee.Symbol
+func (e *Exchange) SetFee(fee *exchange.TradeFee) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cfg.Fees[fee.Symbol.String()] = fee
+}
+
+//Deposit add amount to wallet balance of currency, negative amount withdraw
+func (e *Exchange) Deposit(currency string, amount decimal.Decimal) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ccy := exchange.CurrencyFormat(currency)
+	e.wallet[ccy] = e.wallet[ccy].Add(amount)
+}
+
+//UpdateOrderBook replace the orderbook of ob.Symbol and match resting
+//orders which are crossed by the new book
+func (e *Exchange) UpdateOrderBook(ob *exchange.OrderBook) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.updateBook(ob)
+}
+
+//Handle feed market data message pushed by websocket client. *exchange.OrderBook
+//replace the book and *exchange.OrderBookNotify is applied as incremental
+//update. *exchange.WSNotify is unwrapped and other messages are ignored
+func (e *Exchange) Handle(msg interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if n, ok := msg.(*exchange.WSNotify); ok {
+		msg = n.Data
+	}
+
+	switch t := msg.(type) {
+	case *exchange.OrderBook:
+		delete(e.bookDS, t.Symbol.String())
+		e.updateBook(t)
+
+	case *exchange.OrderBookNotify:
+		key := t.Symbol.String()
+		ds, ok := e.bookDS[key]
+		if !ok {
+			ds = exchange.NewOrderBookDS(t)
+			e.bookDS[key] = ds
+		} else {
+			ds.Update(t)
+		}
+		e.updateBook(ds.Snapshot())
+	}
+}
+
+//Run feed messages of data to the exchange until ctx is done or data is closed
+func (e *Exchange) Run(ctx context.Context, data <-chan interface{}) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case msg, ok := <-data:
+			if !ok {
+				return nil
+			}
+			e.Handle(msg)
+		}
+	}
+}
+
+//CreateOrder match req against current orderbook. taker fills happen
+//immediately and the rest of limit order is placed on the book unless the
+//TimeInForceOption is ioc or fok. PostOnlyOption which would take liquidity
+//is rejected with exchange.ErrPostOnlyRejected
+func (e *Exchange) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if err := exchange.ValidateOrderRequest(req); err != nil {
+		return nil, err
+	}
+	if req.Type != exchange.OrderTypeLimit && req.Type != exchange.OrderTypeMarket {
+		return nil, exchange.NewBadArg("unsupport order type", req.Type)
+	}
+
+	o := &order{tif: exchange.TimeInForceGTC}
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			o.postOnly = t.PostOnly
+
+		case *exchange.TimeInForceOption:
+			switch t.Flag {
+			case exchange.TimeInForceGTC, exchange.TimeInForceIOC, exchange.TimeInForceFOK:
+				o.tif = t.Flag
+			default:
+				return nil, exchange.NewBadArg("unsupport time in force", t.Flag)
+			}
+
+		default:
+			return nil, exchange.NewUnsupportOption(opt)
+		}
+	}
+	if o.postOnly && (req.Type == exchange.OrderTypeMarket || o.tif != exchange.TimeInForceGTC) {
+		return nil, exchange.NewBadArg("post only conflict with order type", req.Type)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sym := req.Symbol
+	bk := e.books[sym.String()]
+	if req.Type == exchange.OrderTypeMarket && bk == nil {
+		return nil, exchange.NewBadArg("no orderbook for symbol", sym.String())
+	}
+
+	buy := isBuy(req.Side)
+	amount := req.Amount
+	if isReduceOnly(req.Side) {
+		if isSpot(sym) {
+			return nil, exchange.NewBadArg("unsupport spot order side", req.Side)
+		}
+		avail := e.closable(sym, req.Side)
+		if avail.LessThan(amount) {
+			return nil, exchange.NewAPIError(exchange.ErrInsufficientBalance, "reduce_only",
+				fmt.Sprintf("close amount=%s exceed position=%s", amount, avail))
+		}
+	}
+
+	var fills []fill
+	if bk != nil {
+		fills = bk.take(buy, req.Type == exchange.OrderTypeMarket, req.Price, amount, true)
+	}
+	filled := sumAmount(fills)
+	if o.postOnly && len(fills) != 0 {
+		return nil, exchange.NewAPIError(exchange.ErrPostOnlyRejected, "post_only", "post only order would take liquidity")
+	}
+
+	now := e.cfg.Now()
+	e.orderID++
+	o.Order = exchange.Order{
+		ID:       exchange.NewIntID(e.orderID),
+		ClientID: req.ClientID,
+		Symbol:   sym,
+		Amount:   amount,
+		Price:    req.Price,
+		Created:  now,
+		Updated:  now,
+		Side:     req.Side,
+		Type:     req.Type,
+		Status:   exchange.OrderStatusOpen,
+	}
+
+	if o.tif == exchange.TimeInForceFOK && filled.LessThan(amount) {
+		o.Status = exchange.OrderStatusCancel
+		e.orders[o.ID.String()] = o
+		return o.snapshot(), nil
+	}
+
+	rest := decimal.Zero
+	if req.Type == exchange.OrderTypeLimit && o.tif == exchange.TimeInForceGTC {
+		rest = amount.Sub(filled)
+	}
+	if err := e.reserve(o, fills, rest); err != nil {
+		e.orderID--
+		return nil, err
+	}
+
+	if bk != nil {
+		fills = bk.take(buy, req.Type == exchange.OrderTypeMarket, req.Price, amount, false)
+	}
+	for _, f := range fills {
+		e.fill(o, f.price, f.amount, false)
+	}
+
+	e.orders[o.ID.String()] = o
+	if o.Status == exchange.OrderStatusOpen {
+		if rest.IsPositive() {
+			e.open = append(e.open, o)
+		} else {
+			o.Status = exchange.OrderStatusCancel
+		}
+	}
+	return o.snapshot(), nil
+}
+
+//CancelOrder cancel open order, exchange.ErrOrderNotFound is returned if the
+//order is unknown or finished
+func (e *Exchange) CancelOrder(ctx context.Context, ord *exchange.Order) (*exchange.Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o, err := e.getOrder(ord)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != exchange.OrderStatusOpen {
+		return nil, exchange.NewAPIError(exchange.ErrOrderNotFound, "order_closed",
+			fmt.Sprintf("order %s is finished", o.ID.String()))
+	}
+
+	e.finish(o, exchange.OrderStatusCancel)
+	return o.snapshot(), nil
+}
+
+//FetchOrder return the latest state of order
+func (e *Exchange) FetchOrder(ctx context.Context, ord *exchange.Order) (*exchange.Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o, err := e.getOrder(ord)
+	if err != nil {
+		return nil, err
+	}
+	return o.snapshot(), nil
+}
+
+//OpenOrders return open orders of symbol ordered by create time, orders of
+//all symbols are returned if symbol is nil
+func (e *Exchange) OpenOrders(ctx context.Context, symbol exchange.Symbol) ([]*exchange.Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ret := []*exchange.Order{}
+	for _, o := range e.open {
+		if symbol == nil || o.Symbol.String() == symbol.String() {
+			ret = append(ret, o.snapshot())
+		}
+	}
+	return ret, nil
+}
+
+//Trades return private trades which match req. StartID and EndID are the
+//inclusive trade id range
+func (e *Exchange) Trades(ctx context.Context, req *exchange.TradeReqParam) ([]exchange.Trade, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var startID, endID int64
+	if req.StartID != "" {
+		if _, err := fmt.Sscan(req.StartID, &startID); err != nil {
+			return nil, exchange.NewBadArg("invalid start id", req.StartID)
+		}
+	}
+	if req.EndID != "" {
+		if _, err := fmt.Sscan(req.EndID, &endID); err != nil {
+			return nil, exchange.NewBadArg("invalid end id", req.EndID)
+		}
+	}
+
+	ret := []exchange.Trade{}
+	for i, t := range e.trades {
+		id := int64(i + 1)
+		if req.Symbol != nil && t.Symbol.String() != req.Symbol.String() ||
+			startID != 0 && id < startID || endID != 0 && id > endID ||
+			!req.StartTime.IsZero() && t.Time.Before(req.StartTime) ||
+			!req.EndTime.IsZero() && t.Time.After(req.EndTime) {
+			continue
+		}
+		ret = append(ret, t)
+		if req.Limit > 0 && len(ret) == req.Limit {
+			break
+		}
+	}
+	return ret, nil
+}
+
+//FetchBalance return balances of currencies, all balances are returned if
+//currencies is empty. Total is the wallet balance, Frozen is reserved by open
+//orders and positions and Equitity include unrealized pnl of positions
+func (e *Exchange) FetchBalance(ctx context.Context, currencies ...string) (*exchange.Balances, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(currencies) == 0 {
+		for ccy := range e.wallet {
+			currencies = append(currencies, ccy)
+		}
+		for ccy := range e.frozen {
+			if _, ok := e.wallet[ccy]; !ok {
+				currencies = append(currencies, ccy)
+			}
+		}
+		sort.Strings(currencies)
+	}
+
+	ret := exchange.NewBalances()
+	for _, c := range currencies {
+		ccy := exchange.CurrencyFormat(c)
+		total := e.wallet[ccy]
+		frozen := e.frozen[ccy]
+		equity := total
+		if ccy == e.cfg.MarginCurrency {
+			for _, pos := range e.positions {
+				frozen = frozen.Add(pos.margin(e.cfg.Leverage))
+				equity = equity.Add(pos.unrealized(e.markPrice(pos.symbol)))
+			}
+		}
+		ret.Add(&exchange.Balance{
+			Currency: ccy,
+			Equitity: equity,
+			Total:    total,
+			Free:     total.Sub(frozen),
+			Frozen:   frozen,
+		})
+	}
+	return ret, nil
+}
+
+//Positions return non zero positions of symbols, all positions are returned if
+//symbols is empty. unrealized pnl is calculated with the mid price of orderbook
+func (e *Exchange) Positions(ctx context.Context, symbols ...exchange.Symbol) ([]*exchange.Position, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	keys := []string{}
+	if len(symbols) == 0 {
+		for key := range e.positions {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	} else {
+		for _, sym := range symbols {
+			keys = append(keys, sym.String())
+		}
+	}
+
+	ret := []*exchange.Position{}
+	for _, key := range keys {
+		pos, ok := e.positions[key]
+		if !ok || pos.amount.IsZero() {
+			continue
+		}
+		ret = append(ret, pos.transform(e.cfg.Leverage, e.markPrice(pos.symbol)))
+	}
+	return ret, nil
+}
+
+func (e *Exchange) getOrder(ord *exchange.Order) (*order, error) {
+	if ord.ID != nil {
+		if o, ok := e.orders[ord.ID.String()]; ok {
+			return o, nil
+		}
+	} else if ord.ClientID != nil {
+		for _, o := range e.orders {
+			if o.ClientID != nil && o.ClientID.String() == ord.ClientID.String() {
+				return o, nil
+			}
+		}
+	}
+	return nil, exchange.NewAPIError(exchange.ErrOrderNotFound, "order_not_found", "unknown order")
+}
+
+//finish close open order with status and release the frozen balance
+func (e *Exchange) finish(o *order, status exchange.OrderStatus) {
+	e.unfreeze(o, o.frozen)
+	o.Status = status
+	o.Updated = e.cfg.Now()
+	for i, oo := range e.open {
+		if oo == o {
+			e.open = append(e.open[:i], e.open[i+1:]...)
+			break
+		}
+	}
+}
+
+func (e *Exchange) fee(sym exchange.Symbol, maker bool) decimal.Decimal {
+	fee, ok := e.cfg.Fees[sym.String()]
+	if !ok {
+		fee = e.cfg.DefaultFee
+	}
+	if fee == nil {
+		return decimal.Zero
+	}
+	if maker {
+		return fee.Maker
+	}
+	return fee.Taker
+}
+
+func (o *order) snapshot() *exchange.Order {
+	ret := o.Order
+	return &ret
+}
+
+func isBuy(side exchange.OrderSide) bool {
+	return side == exchange.OrderSideBuy || side == exchange.OrderSideCloseShort
+}
+
+func isReduceOnly(side exchange.OrderSide) bool {
+	return side == exchange.OrderSideCloseLong || side == exchange.OrderSideCloseShort
+}
+
+func isSpot(sym exchange.Symbol) bool {
+	_, ok := sym.(exchange.SpotSymbol)
+	return ok
+}
+
+func contractVal(sym exchange.Symbol) decimal.Decimal {
+	var cv decimal.Decimal
+	switch t := sym.(type) {
+	case exchange.SwapSymbol:
+		cv = t.ContractVal()
+	case exchange.FuturesSymbol:
+		cv = t.ContractVal()
+	}
+	if cv.Sign() <= 0 {
+		return decimal.NewFromInt(1)
+	}
+	return cv
+}
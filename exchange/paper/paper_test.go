@@ -0,0 +1,210 @@
+package paper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	testSpotSymbol struct {
+		*exchange.BaseSpotSymbol
+	}
+
+	testSwapSymbol struct {
+		*exchange.BaseSwapSymbol
+	}
+)
+
+var (
+	testCfg = exchange.SymbolConfig{
+		PricePrecision:  decimal.RequireFromString("0.01"),
+		AmountPrecision: decimal.RequireFromString("0.001"),
+	}
+	btcusdt = &testSpotSymbol{exchange.NewBaseSpotSymbol("btc", "usdt", testCfg, nil)}
+	btcswap = &testSwapSymbol{exchange.NewBaseSwapSymbolWithCfg("BTCUSDT", decimal.NewFromInt(1), testCfg, nil)}
+)
+
+func (s *testSpotSymbol) String() string {
+	return "btc_usdt"
+}
+
+func (s *testSwapSymbol) String() string {
+	return "BTCUSDT-SWAP"
+}
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func newOrderBook(sym exchange.Symbol, bids []exchange.OrderElem, asks []exchange.OrderElem) *exchange.OrderBook {
+	return &exchange.OrderBook{
+		Symbol: sym,
+		Bids:   bids,
+		Asks:   asks,
+	}
+}
+
+func balanceOf(t *testing.T, e *Exchange, ccy string) *exchange.Balance {
+	balances, err := e.FetchBalance(context.Background(), ccy)
+	if err != nil {
+		t.Fatalf("fetch balance fail %s", err.Error())
+	}
+	b, err := balances.Get(ccy)
+	if err != nil {
+		t.Fatalf("get balance fail %s", err.Error())
+	}
+	return b
+}
+
+func TestSpot(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Balances["usdt"] = d("10000")
+	e := NewExchange(cfg)
+	e.SetFee(&exchange.TradeFee{Symbol: btcusdt, Maker: d("0.001"), Taker: d("0.002")})
+	ctx := context.Background()
+
+	req := exchange.NewDecimalOrderRequest(btcusdt, nil, exchange.OrderSideBuy, exchange.OrderTypeMarket, decimal.Zero, d("2"))
+	if _, err := e.CreateOrder(ctx, req); err == nil {
+		t.Errorf("expect error for market order without orderbook")
+	}
+
+	e.UpdateOrderBook(newOrderBook(btcusdt,
+		[]exchange.OrderElem{{Price: 99, Amount: 2}, {Price: 100, Amount: 1}},
+		[]exchange.OrderElem{{Price: 101, Amount: 1}, {Price: 102, Amount: 2}}))
+
+	order, err := e.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("create market order fail %s", err.Error())
+	}
+	if order.Status != exchange.OrderStatusDone || !order.AvgPrice.Equal(d("101.5")) ||
+		!order.Fee.Equal(d("-0.406")) || order.FeeCurrency != "USDT" {
+		t.Errorf("bad market order %+v", order)
+	}
+	if b := balanceOf(t, e, "USDT"); !b.Total.Equal(d("9796.594")) {
+		t.Errorf("bad usdt balance %+v", b)
+	}
+
+	//the liquidity at 102 is partially taken by the market order
+	req = exchange.NewDecimalOrderRequest(btcusdt, exchange.NewStrID("c1"), exchange.OrderSideBuy, exchange.OrderTypeLimit, d("102"), d("0.5"))
+	if _, err := e.CreateOrder(ctx, req, exchange.NewPostOnlyOption(true)); !errors.Is(err, exchange.ErrPostOnlyRejected) {
+		t.Errorf("expect post only rejected got %v", err)
+	}
+	req = exchange.NewDecimalOrderRequest(btcusdt, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit, d("102"), d("5"))
+	order, err = e.CreateOrder(ctx, req, exchange.NewTimeInForceOption(exchange.TimeInForceFOK))
+	if err != nil || order.Status != exchange.OrderStatusCancel || !order.Filled.IsZero() {
+		t.Errorf("bad fok order %v %+v", err, order)
+	}
+	req = exchange.NewDecimalOrderRequest(btcusdt, nil, exchange.OrderSideBuy, exchange.OrderTypeLimit, d("100"), d("1000"))
+	if _, err := e.CreateOrder(ctx, req); !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("expect insufficient balance got %v", err)
+	}
+
+	req = exchange.NewDecimalOrderRequest(btcusdt, exchange.NewStrID("c2"), exchange.OrderSideSell, exchange.OrderTypeLimit, d("105"), d("1"))
+	sell, err := e.CreateOrder(ctx, req)
+	if err != nil || sell.Status != exchange.OrderStatusOpen {
+		t.Fatalf("create limit order fail %v %+v", err, sell)
+	}
+	if b := balanceOf(t, e, "BTC"); !b.Free.Equal(d("1")) || !b.Frozen.Equal(d("1")) {
+		t.Errorf("bad btc balance %+v", b)
+	}
+
+	e.UpdateOrderBook(newOrderBook(btcusdt,
+		[]exchange.OrderElem{{Price: 106, Amount: 0.5}},
+		[]exchange.OrderElem{{Price: 107, Amount: 1}}))
+	sell, err = e.FetchOrder(ctx, &exchange.Order{ClientID: exchange.NewStrID("c2")})
+	if err != nil || sell.Status != exchange.OrderStatusOpen || !sell.Filled.Equal(d("0.5")) ||
+		!sell.AvgPrice.Equal(d("105")) || !sell.Fee.Equal(d("-0.0525")) {
+		t.Errorf("bad partial filled order %v %+v", err, sell)
+	}
+
+	orders, err := e.OpenOrders(ctx, btcusdt)
+	if err != nil || len(orders) != 1 {
+		t.Fatalf("bad open orders %v %v", err, orders)
+	}
+	sell, err = e.CancelOrder(ctx, orders[0])
+	if err != nil || sell.Status != exchange.OrderStatusCancel {
+		t.Errorf("cancel order fail %v %+v", err, sell)
+	}
+	if _, err := e.CancelOrder(ctx, sell); !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("expect order not found got %v", err)
+	}
+	if b := balanceOf(t, e, "BTC"); !b.Total.Equal(d("1.5")) || !b.Frozen.IsZero() {
+		t.Errorf("bad btc balance %+v", b)
+	}
+
+	trades, err := e.Trades(ctx, exchange.NewTradeReqParam().SetSymbol(btcusdt))
+	if err != nil || len(trades) != 3 {
+		t.Fatalf("bad trades %v %v", err, trades)
+	}
+	if trades[0].IsMaker || !trades[2].IsMaker || trades[2].Side != exchange.OrderSideSell ||
+		!trades[2].Fee.Equal(d("-0.0525")) || trades[2].OrderID != sell.ID.String() {
+		t.Errorf("bad trades %+v", trades)
+	}
+	trades, _ = e.Trades(ctx, exchange.NewTradeReqParam().SetStartID("2").SetLimit(1))
+	if len(trades) != 1 || trades[0].ID != "2" {
+		t.Errorf("bad trades %+v", trades)
+	}
+}
+
+func TestSwap(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Balances["USDT"] = d("100")
+	cfg.Leverage = d("10")
+	e := NewExchange(cfg)
+	ctx := context.Background()
+
+	e.Handle(&exchange.WSNotify{Data: &exchange.OrderBookNotify{
+		Symbol: btcswap,
+		Bids:   []exchange.OrderElem{{Price: 100, Amount: 10}},
+		Asks:   []exchange.OrderElem{{Price: 101, Amount: 10}},
+	}})
+
+	req := exchange.NewDecimalOrderRequest(btcswap, nil, exchange.OrderSideBuy, exchange.OrderTypeMarket, decimal.Zero, d("10"))
+	if _, err := e.CreateOrder(ctx, req); !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("expect insufficient balance got %v", err)
+	}
+	req = exchange.NewDecimalOrderRequest(btcswap, nil, exchange.OrderSideBuy, exchange.OrderTypeMarket, decimal.Zero, d("5"))
+	if _, err := e.CreateOrder(ctx, req); err != nil {
+		t.Fatalf("create order fail %s", err.Error())
+	}
+
+	//incremental update move the book up
+	e.Handle(&exchange.OrderBookNotify{
+		Symbol: btcswap,
+		Bids:   []exchange.OrderElem{{Price: 100, Amount: 0}, {Price: 110, Amount: 10}},
+		Asks:   []exchange.OrderElem{{Price: 101, Amount: 0}, {Price: 111, Amount: 10}},
+	})
+	positions, err := e.Positions(ctx)
+	if err != nil || len(positions) != 1 {
+		t.Fatalf("bad positions %v %v", err, positions)
+	}
+	pos := positions[0]
+	if pos.Side != exchange.PositionSideLong || !pos.Position.Equal(d("5")) || !pos.AvgOpenPrice.Equal(d("101")) ||
+		!pos.Margin.Equal(d("50.5")) || !pos.UNRealizedPNL.Equal(d("47.5")) {
+		t.Errorf("bad position %+v", pos)
+	}
+	if b := balanceOf(t, e, "USDT"); !b.Free.Equal(d("49.5")) || !b.Equitity.Equal(d("147.5")) {
+		t.Errorf("bad usdt balance %+v", b)
+	}
+
+	req = exchange.NewDecimalOrderRequest(btcswap, nil, exchange.OrderSideCloseLong, exchange.OrderTypeMarket, decimal.Zero, d("6"))
+	if _, err := e.CreateOrder(ctx, req); err == nil {
+		t.Errorf("expect close amount exceed position error")
+	}
+	req = exchange.NewDecimalOrderRequest(btcswap, nil, exchange.OrderSideCloseLong, exchange.OrderTypeMarket, decimal.Zero, d("5"))
+	if _, err := e.CreateOrder(ctx, req); err != nil {
+		t.Fatalf("close position fail %s", err.Error())
+	}
+
+	positions, _ = e.Positions(ctx)
+	if len(positions) != 0 {
+		t.Errorf("position not closed %+v", positions)
+	}
+	if b := balanceOf(t, e, "USDT"); !b.Total.Equal(d("145")) || !b.Free.Equal(d("145")) {
+		t.Errorf("bad usdt balance %+v", b)
+	}
+}
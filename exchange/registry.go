@@ -0,0 +1,288 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	//Config specific credential and environment which used to create
+	//exchange clients via registry
+	Config struct {
+		Key        string
+		Secret     string
+		PassPhrase string
+		TestNet    bool
+		//HTTPClient used by rest client, nil use the default client
+		HTTPClient *http.Client
+		//BaseURL override rest api base url, empty use the exchange default
+		BaseURL string
+	}
+
+	//Capability is bit flags which describe what an exchange adapter support
+	Capability uint
+
+	//WSConn unified websocket client interface which created by WSConstructor
+	WSConn interface {
+		Run(ctx context.Context) error
+		Close() error
+		Error() error
+		Done() <-chan struct{}
+		Subscribe(ctx context.Context, channels ...Channel) error
+		UnSubscribe(ctx context.Context, channels ...Channel) error
+	}
+
+	//RestConstructor create exchange rest client. the return value is the
+	//adapter specific RestClient which can be type assert to Trader etc.
+	RestConstructor func(cfg *Config) (interface{}, error)
+
+	//WSConstructor create exchange websocket client. notify message will be
+	//pushed to data
+	WSConstructor func(cfg *Config, data chan interface{}) (WSConn, error)
+
+	//TraderConstructor create Trader for adapters whose rest client do not
+	//implement Trader directly
+	TraderConstructor func(cfg *Config) (Trader, error)
+
+	//SymbolLoader load exchange symbols which is required by SymbolParser
+	SymbolLoader func(ctx context.Context, cfg *Config) error
+
+	//SymbolParser parse exchange symbol string
+	SymbolParser func(symbol string) (Symbol, error)
+
+	//SymbolStoreConstructor create a SymbolStore which is owned by the
+	//caller. stores created with different cfg do not share symbols
+	SymbolStoreConstructor func(cfg *Config) (*SymbolStore, error)
+
+	//Registration describe an exchange adapter. nil constructor means the
+	//adapter do not support it
+	Registration struct {
+		Name           string
+		NewRestClient  RestConstructor
+		NewWSClient    WSConstructor
+		NewTrader      TraderConstructor
+		LoadSymbols    SymbolLoader
+		ParseSymbol    SymbolParser
+		NewSymbolStore SymbolStoreConstructor
+		Capabilities   Capability
+	}
+)
+
+const (
+	//CapabilityTrader rest client implement Trader interface
+	CapabilityTrader Capability = 1 << iota
+	//CapabilityPublicStream websocket client support public market channels
+	CapabilityPublicStream
+	//CapabilityPrivateStream websocket client support private channels
+	CapabilityPrivateStream
+	//CapabilityTestNet Config.TestNet is supported
+	CapabilityTestNet
+	//CapabilityTrades rest client support Trades request
+	CapabilityTrades
+	//CapabilityFinance rest client support Finance request
+	CapabilityFinance
+	//CapabilityBalance rest client support FetchBalance request
+	CapabilityBalance
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Registration{}
+
+	capabilityNames = map[Capability]string{
+		CapabilityTrader:        "trader",
+		CapabilityPublicStream:  "public_stream",
+		CapabilityPrivateStream: "private_stream",
+		CapabilityTestNet:       "testnet",
+		CapabilityTrades:        "trades",
+		CapabilityFinance:       "finance",
+		CapabilityBalance:       "balance",
+	}
+)
+
+//Register add an exchange adapter to registry. it is usually called in
+//adapter package init func. Register panic if the name is duplicate
+func Register(reg *Registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if reg.Name == "" {
+		panic("register exchange with empty name")
+	}
+	if _, ok := registry[reg.Name]; ok {
+		panic(fmt.Sprintf("duplicate exchange %s", reg.Name))
+	}
+	registry[reg.Name] = reg
+}
+
+//Lookup return registration of exchange name. the adapter package must be
+//imported to make the registration available
+func Lookup(name string) (*Registration, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	reg, ok := registry[name]
+	if !ok {
+		return nil, NewBadArg("unknown exchange", name)
+	}
+	return reg, nil
+}
+
+//Registered return sorted names of all registered exchanges
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	ret := make([]string, 0, len(registry))
+	for name := range registry {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+//NewRestClientByName create rest client of exchange name
+func NewRestClientByName(name string, cfg *Config) (interface{}, error) {
+	reg, err := Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return reg.RestClient(cfg)
+}
+
+//NewWSClientByName create websocket client of exchange name
+func NewWSClientByName(name string, cfg *Config, data chan interface{}) (WSConn, error) {
+	reg, err := Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return reg.WSClient(cfg, data)
+}
+
+//NewTraderByName create rest client of exchange name which implement Trader
+func NewTraderByName(name string, cfg *Config) (Trader, error) {
+	reg, err := Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return reg.Trader(cfg)
+}
+
+//NewSymbolStoreByName create symbol store of exchange name
+func NewSymbolStoreByName(name string, cfg *Config) (*SymbolStore, error) {
+	reg, err := Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return reg.SymbolStore(cfg)
+}
+
+//Has check whether all the capabilities c is supported
+func (reg *Registration) Has(c Capability) bool {
+	return reg.Capabilities&c == c
+}
+
+//RestClient create rest client with cfg
+func (reg *Registration) RestClient(cfg *Config) (interface{}, error) {
+	if reg.NewRestClient == nil {
+		return nil, errors.Errorf("exchange %s do not support rest client", reg.Name)
+	}
+	if err := reg.checkConfig(cfg); err != nil {
+		return nil, err
+	}
+	return reg.NewRestClient(cfg)
+}
+
+//WSClient create websocket client with cfg
+func (reg *Registration) WSClient(cfg *Config, data chan interface{}) (WSConn, error) {
+	if reg.NewWSClient == nil {
+		return nil, errors.Errorf("exchange %s do not support websocket client", reg.Name)
+	}
+	if err := reg.checkConfig(cfg); err != nil {
+		return nil, err
+	}
+	return reg.NewWSClient(cfg, data)
+}
+
+//Trader create Trader with NewTrader if set, otherwise create rest client
+//with cfg and convert it to Trader
+func (reg *Registration) Trader(cfg *Config) (Trader, error) {
+	if !reg.Has(CapabilityTrader) {
+		return nil, errors.Errorf("exchange %s do not support trader", reg.Name)
+	}
+	if reg.NewTrader != nil {
+		if err := reg.checkConfig(cfg); err != nil {
+			return nil, err
+		}
+		return reg.NewTrader(cfg)
+	}
+	client, err := reg.RestClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	trader, ok := client.(Trader)
+	if !ok {
+		return nil, errors.Errorf("exchange %s rest client %T is not a trader", reg.Name, client)
+	}
+	return trader, nil
+}
+
+//Symbols load exchange symbols. it's a no-op if adapter have no loader
+func (reg *Registration) Symbols(ctx context.Context, cfg *Config) error {
+	if reg.LoadSymbols == nil {
+		return nil
+	}
+	if err := reg.checkConfig(cfg); err != nil {
+		return err
+	}
+	return reg.LoadSymbols(ctx, cfg)
+}
+
+//SymbolStore create symbol store with cfg. Refresh must be called before use
+func (reg *Registration) SymbolStore(cfg *Config) (*SymbolStore, error) {
+	if reg.NewSymbolStore == nil {
+		return nil, errors.Errorf("exchange %s do not support symbol store", reg.Name)
+	}
+	if err := reg.checkConfig(cfg); err != nil {
+		return nil, err
+	}
+	return reg.NewSymbolStore(cfg)
+}
+
+//Parse parse exchange symbol string. LoadSymbols must be called before
+func (reg *Registration) Parse(symbol string) (Symbol, error) {
+	if reg.ParseSymbol == nil {
+		return nil, errors.Errorf("exchange %s do not support parse symbol", reg.Name)
+	}
+	return reg.ParseSymbol(symbol)
+}
+
+func (reg *Registration) checkConfig(cfg *Config) error {
+	if cfg == nil {
+		return NewBadArg("nil config", cfg)
+	}
+	if cfg.TestNet && !reg.Has(CapabilityTestNet) {
+		return NewBadArg("testnet is not supported", reg.Name)
+	}
+	return nil
+}
+
+func (c Capability) String() string {
+	var ret string
+	for i := Capability(1); i <= CapabilityBalance; i <<= 1 {
+		if c&i == 0 {
+			continue
+		}
+		if ret != "" {
+			ret += "|"
+		}
+		ret += capabilityNames[i]
+	}
+	return ret
+}
@@ -0,0 +1,78 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type (
+	testRegistryTrader struct {
+		Trader
+		cfg *Config
+	}
+)
+
+func TestRegistry(t *testing.T) {
+	Register(&Registration{
+		Name: "test.registry",
+		NewRestClient: func(cfg *Config) (interface{}, error) {
+			return &testRegistryTrader{cfg: cfg}, nil
+		},
+		Capabilities: CapabilityTrader | CapabilityTrades,
+	})
+
+	reg, err := Lookup("test.registry")
+	if err != nil {
+		t.Fatalf("lookup fail %s", err.Error())
+	}
+
+	if !reg.Has(CapabilityTrader) || !reg.Has(CapabilityTrader|CapabilityTrades) || reg.Has(CapabilityFinance) {
+		t.Errorf("bad capabilities %s", reg.Capabilities)
+	}
+
+	if reg.Capabilities.String() != "trader|trades" {
+		t.Errorf("bad capabilities string %s", reg.Capabilities)
+	}
+
+	trader, err := NewTraderByName("test.registry", &Config{Key: "key"})
+	if err != nil {
+		t.Fatalf("create trader fail %s", err.Error())
+	}
+	if tt := trader.(*testRegistryTrader); tt.cfg.Key != "key" {
+		t.Errorf("bad config %+v", tt.cfg)
+	}
+
+	if _, err := NewTraderByName("test.registry", &Config{TestNet: true}); !errors.Is(err, &ErrBadArg{}) {
+		t.Errorf("testnet should be rejected %v", err)
+	}
+
+	if _, err := NewWSClientByName("test.registry", &Config{}, nil); err == nil {
+		t.Errorf("websocket should not be supported")
+	}
+
+	if err := reg.Symbols(context.Background(), &Config{}); err != nil {
+		t.Errorf("nil symbol loader should be no-op %s", err.Error())
+	}
+
+	if _, err := Lookup("test.unknown"); !errors.Is(err, &ErrBadArg{}) {
+		t.Errorf("unknown exchange should return ErrBadArg %v", err)
+	}
+
+	found := false
+	for _, name := range Registered() {
+		if name == "test.registry" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("registered exchange not found")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("duplicate register should panic")
+		}
+	}()
+	Register(&Registration{Name: "test.registry"})
+}
@@ -0,0 +1,72 @@
+package exchange
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type (
+	//RoundMode specific how a value is rounded to precision
+	RoundMode int
+)
+
+const (
+	//RoundModeDown round toward zero
+	RoundModeDown RoundMode = iota
+	//RoundModeFloor round toward negative infinity
+	RoundModeFloor
+	//RoundModeCeil round toward positive infinity
+	RoundModeCeil
+	//RoundModeNearest round to the nearest multiple, half away from zero
+	RoundModeNearest
+)
+
+var (
+	decimalOne = decimal.NewFromInt(1)
+)
+
+//RoundWithMode round val to multiple of p with mode. the calculation is
+//decimal exact. val is returned as is if p is not positive
+func RoundWithMode(val decimal.Decimal, p decimal.Decimal, mode RoundMode) decimal.Decimal {
+	if p.Sign() <= 0 {
+		return val
+	}
+
+	q, r := val.QuoRem(p, 0)
+	switch mode {
+	case RoundModeFloor:
+		if r.Sign() < 0 {
+			q = q.Sub(decimalOne)
+		}
+
+	case RoundModeCeil:
+		if r.Sign() > 0 {
+			q = q.Add(decimalOne)
+		}
+
+	case RoundModeNearest:
+		if r.Abs().Add(r.Abs()).GreaterThanOrEqual(p) {
+			if r.Sign() > 0 {
+				q = q.Add(decimalOne)
+			} else {
+				q = q.Sub(decimalOne)
+			}
+		}
+	}
+	return q.Mul(p)
+}
+
+//PriceRoundMode return the price round mode of order side which never make
+//the price worse than requested. buy price is floored and sell price is ceiled
+func PriceRoundMode(side OrderSide) RoundMode {
+	switch side {
+	case OrderSideSell, OrderSideCloseLong:
+		return RoundModeCeil
+	default:
+		return RoundModeFloor
+	}
+}
+
+//RoundPriceBySide round price to sym price precision according to side
+func RoundPriceBySide(sym Symbol, price decimal.Decimal, side OrderSide) decimal.Decimal {
+	return RoundWithMode(price, sym.PricePrecision(), PriceRoundMode(side))
+}
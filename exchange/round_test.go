@@ -0,0 +1,68 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRoundWithMode(t *testing.T) {
+	for _, c := range []struct {
+		val    string
+		p      string
+		mode   RoundMode
+		expect string
+	}{
+		{"1.2345", "0.01", RoundModeDown, "1.23"},
+		{"1.2345", "0.01", RoundModeFloor, "1.23"},
+		{"1.2345", "0.01", RoundModeCeil, "1.24"},
+		{"1.2345", "0.01", RoundModeNearest, "1.23"},
+		{"1.235", "0.01", RoundModeNearest, "1.24"},
+		{"1.23", "0.01", RoundModeCeil, "1.23"},
+		{"-1.2345", "0.01", RoundModeDown, "-1.23"},
+		{"-1.2345", "0.01", RoundModeFloor, "-1.24"},
+		{"-1.2345", "0.01", RoundModeCeil, "-1.23"},
+		{"0.3", "0.1", RoundModeDown, "0.3"},
+		{"123456789012345678.123456789", "0.00000001", RoundModeDown, "123456789012345678.12345678"},
+		{"0.000000012345", "0.0000000001", RoundModeCeil, "0.0000000124"},
+		{"1.5", "0.5", RoundModeFloor, "1.5"},
+		{"1.7", "0.5", RoundModeCeil, "2"},
+		{"1.2345", "0", RoundModeDown, "1.2345"},
+	} {
+		val := decimal.RequireFromString(c.val)
+		p := decimal.RequireFromString(c.p)
+		expect := decimal.RequireFromString(c.expect)
+		if ret := RoundWithMode(val, p, c.mode); !ret.Equal(expect) {
+			t.Errorf("round %s %s mode=%d expect=%s got=%s", c.val, c.p, c.mode, c.expect, ret)
+		}
+	}
+}
+
+func TestNewDecimalOrderRequest(t *testing.T) {
+	sym := &testStoreSymbol{NewBaseSpotSymbol("BTC", "USDT", SymbolConfig{
+		PricePrecision:  decimal.RequireFromString("0.01"),
+		AmountPrecision: decimal.RequireFromString("0.000001"),
+	}, nil)}
+	price := decimal.RequireFromString("38123.456")
+	amount := decimal.RequireFromString("0.1234567")
+
+	buy := NewDecimalOrderRequest(sym, nil, OrderSideBuy, OrderTypeLimit, price, amount)
+	if buy.Price.String() != "38123.45" || buy.Amount.String() != "0.123456" {
+		t.Errorf("bad buy request price=%s amount=%s", buy.Price, buy.Amount)
+	}
+
+	sell := NewDecimalOrderRequest(sym, nil, OrderSideSell, OrderTypeLimit, price, amount)
+	if sell.Price.String() != "38123.45" || sell.Amount.String() != "0.123456" {
+		t.Errorf("bad sell request price=%s amount=%s", sell.Price, sell.Amount)
+	}
+
+	sell = NewRoundedOrderRequest(sym, nil, OrderSideSell, OrderTypeLimit, price, amount, PriceRoundMode(OrderSideSell))
+	if sell.Price.String() != "38123.46" || sell.Amount.String() != "0.123456" {
+		t.Errorf("bad rounded sell request price=%s amount=%s", sell.Price, sell.Amount)
+	}
+
+	req := NewOrderRequest(sym, nil, OrderSideBuy, OrderTypeLimit, 0.29, 3)
+	if req.Price.String() != "0.29" || req.Amount.String() != "3" {
+		t.Errorf("bad float request price=%s amount=%s", req.Price, req.Amount)
+	}
+}
@@ -0,0 +1,396 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	//DataKind is the kind of normalized notify data
+	DataKind int
+
+	//Normalizer is implemented by adapter specific notify data which can be
+	//converted to normalized payload. the payload must be a pointer or a
+	//slice of pointers of the type listed in DataKind
+	Normalizer interface {
+		Normalize() (DataKind, interface{}, error)
+	}
+
+	//Subscription dispatch notify pushed by websocket clients to typed
+	//callbacks with normalized payload. pass Data to websocket client
+	//constructor, register callbacks and start Run. callbacks are called in
+	//the Run goroutine
+	Subscription struct {
+		data chan interface{}
+
+		onOrderBook []func(*WSNotify, *OrderBook)
+		onTrade     []func(*WSNotify, *PublicTrade)
+		onTicker    []func(*WSNotify, *Ticker)
+		onOrder     []func(*WSNotify, *Order)
+		onFill      []func(*WSNotify, *Trade)
+		onPosition  []func(*WSNotify, *Position)
+		onBalances  []func(*WSNotify, *Balances)
+		onOther     []func(*WSNotify)
+		onResync    []func(*WSNotify)
+		onError     []func(*WSNotify, error)
+
+		//books keep incremental orderbook of OrderBookNotify
+		mu    sync.Mutex
+		books map[string]*OrderBookDS
+	}
+)
+
+const (
+	DataKindUnknown DataKind = iota
+	//DataKindOrderBook payload *OrderBook
+	DataKindOrderBook
+	//DataKindTrades public trades, payload *PublicTrade
+	DataKindTrades
+	//DataKindTicker payload *Ticker
+	DataKindTicker
+	//DataKindOrders private orders, payload *Order
+	DataKindOrders
+	//DataKindFills private trades, payload *Trade
+	DataKindFills
+	//DataKindPositions payload *Position
+	DataKindPositions
+	//DataKindBalances payload *Balances
+	DataKindBalances
+)
+
+var (
+	//ErrUnexpectedPayload normalized payload do not match the data kind
+	ErrUnexpectedPayload = errors.New("unexpected normalized payload")
+)
+
+//NewSubscription create subscription whose data channel has size buffer
+func NewSubscription(size int) *Subscription {
+	return &Subscription{
+		data:  make(chan interface{}, size),
+		books: make(map[string]*OrderBookDS),
+	}
+}
+
+//Data return the channel which should be passed to websocket client
+func (s *Subscription) Data() chan interface{} {
+	return s.data
+}
+
+//OnOrderBook register callback for orderbook. incremental OrderBookNotify is
+//merged and the full book snapshot is passed to cb
+func (s *Subscription) OnOrderBook(cb func(*WSNotify, *OrderBook)) *Subscription {
+	s.onOrderBook = append(s.onOrderBook, cb)
+	return s
+}
+
+//OnTrade register callback for public trades. the trades of a notify are
+//passed one by one
+func (s *Subscription) OnTrade(cb func(*WSNotify, *PublicTrade)) *Subscription {
+	s.onTrade = append(s.onTrade, cb)
+	return s
+}
+
+//OnTicker register callback for ticker
+func (s *Subscription) OnTicker(cb func(*WSNotify, *Ticker)) *Subscription {
+	s.onTicker = append(s.onTicker, cb)
+	return s
+}
+
+//OnOrder register callback for private order update
+func (s *Subscription) OnOrder(cb func(*WSNotify, *Order)) *Subscription {
+	s.onOrder = append(s.onOrder, cb)
+	return s
+}
+
+//OnFill register callback for private trade
+func (s *Subscription) OnFill(cb func(*WSNotify, *Trade)) *Subscription {
+	s.onFill = append(s.onFill, cb)
+	return s
+}
+
+//OnPosition register callback for position update
+func (s *Subscription) OnPosition(cb func(*WSNotify, *Position)) *Subscription {
+	s.onPosition = append(s.onPosition, cb)
+	return s
+}
+
+//OnBalances register callback for balance update
+func (s *Subscription) OnBalances(cb func(*WSNotify, *Balances)) *Subscription {
+	s.onBalances = append(s.onBalances, cb)
+	return s
+}
+
+//OnOther register callback for notify which can not be normalized such as
+//index, pong or exchange specific data
+func (s *Subscription) OnOther(cb func(*WSNotify)) *Subscription {
+	s.onOther = append(s.onOther, cb)
+	return s
+}
+
+//OnResync register callback for notify whose previous notify of the same
+//channel are dropped, see Delivery. the local orderbook is rebuilt from the
+//incremental OrderBookNotify which follow the drop, cb should resubscribe the
+//channel to get a full snapshot
+func (s *Subscription) OnResync(cb func(*WSNotify)) *Subscription {
+	s.onResync = append(s.onResync, cb)
+	return s
+}
+
+//OnError register callback for notify which fail to normalize
+func (s *Subscription) OnError(cb func(*WSNotify, error)) *Subscription {
+	s.onError = append(s.onError, cb)
+	return s
+}
+
+//Run dispatch messages of Data until ctx is done or Data is closed
+func (s *Subscription) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case msg, ok := <-s.data:
+			if !ok {
+				return nil
+			}
+			s.Dispatch(msg)
+		}
+	}
+}
+
+//Dispatch normalize msg and call registered callbacks. msg should be
+//*WSNotify, other messages are wrapped in a WSNotify
+func (s *Subscription) Dispatch(msg interface{}) {
+	notify, ok := msg.(*WSNotify)
+	if !ok {
+		notify = &WSNotify{Data: msg}
+	}
+
+	if notify.Resync {
+		if on, ok := notify.Data.(*OrderBookNotify); ok {
+			s.dropBook(on.Symbol)
+		}
+		for _, cb := range s.onResync {
+			cb(notify)
+		}
+	}
+
+	if on, ok := notify.Data.(*OrderBookNotify); ok {
+		s.dispatchOrderBook(notify, s.mergeBook(on))
+		return
+	}
+
+	kind, payload, err := Normalize(notify.Data)
+	if err != nil {
+		for _, cb := range s.onError {
+			cb(notify, err)
+		}
+		return
+	}
+
+	switch kind {
+	case DataKindOrderBook:
+		ob := payload.(*OrderBook)
+		s.resetBook(ob)
+		s.dispatchOrderBook(notify, ob)
+
+	case DataKindTrades:
+		for _, t := range payload.([]*PublicTrade) {
+			for _, cb := range s.onTrade {
+				cb(notify, t)
+			}
+		}
+
+	case DataKindTicker:
+		for _, cb := range s.onTicker {
+			cb(notify, payload.(*Ticker))
+		}
+
+	case DataKindOrders:
+		for _, o := range payload.([]*Order) {
+			for _, cb := range s.onOrder {
+				cb(notify, o)
+			}
+		}
+
+	case DataKindFills:
+		for _, t := range payload.([]*Trade) {
+			for _, cb := range s.onFill {
+				cb(notify, t)
+			}
+		}
+
+	case DataKindPositions:
+		for _, p := range payload.([]*Position) {
+			for _, cb := range s.onPosition {
+				cb(notify, p)
+			}
+		}
+
+	case DataKindBalances:
+		for _, cb := range s.onBalances {
+			cb(notify, payload.(*Balances))
+		}
+
+	default:
+		for _, cb := range s.onOther {
+			cb(notify)
+		}
+	}
+}
+
+func (s *Subscription) dispatchOrderBook(notify *WSNotify, ob *OrderBook) {
+	for _, cb := range s.onOrderBook {
+		cb(notify, ob)
+	}
+}
+
+func (s *Subscription) mergeBook(notify *OrderBookNotify) *OrderBook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := notify.Symbol.String()
+	ds, ok := s.books[key]
+	if !ok {
+		ds = NewOrderBookDS(notify)
+		s.books[key] = ds
+	} else {
+		ds.Update(notify)
+	}
+	ret := ds.Snapshot()
+	ret.Raw = notify.Raw
+	return ret
+}
+
+func (s *Subscription) dropBook(sym Symbol) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sym != nil {
+		delete(s.books, sym.String())
+	}
+}
+
+//resetBook replace the incremental book with the snapshot
+func (s *Subscription) resetBook(ob *OrderBook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ob.Symbol == nil {
+		return
+	}
+	if _, ok := s.books[ob.Symbol.String()]; !ok {
+		return
+	}
+	s.books[ob.Symbol.String()] = NewOrderBookDS(&OrderBookNotify{
+		Symbol: ob.Symbol,
+		Bids:   ob.Bids,
+		Asks:   ob.Asks,
+	})
+}
+
+//Normalize convert notify data to DataKind and payload. the payload of
+//orderbook, ticker and balances is a pointer and others are slice of
+//pointers. data which implement Normalizer is converted by its Normalize
+//method. []*Trade is regarded as public trades which is pushed by most
+//adapters. DataKindUnknown is returned for data which can not be normalized
+func Normalize(data interface{}) (DataKind, interface{}, error) {
+	kind := DataKindUnknown
+	if n, ok := data.(Normalizer); ok {
+		var err error
+		kind, data, err = n.Normalize()
+		if err != nil {
+			return DataKindUnknown, nil, err
+		}
+	}
+
+	var ret interface{}
+	switch t := data.(type) {
+	case *OrderBook:
+		ret = t
+		kind = checkKind(kind, DataKindOrderBook)
+
+	case *Ticker:
+		ret = t
+		kind = checkKind(kind, DataKindTicker)
+
+	case *Balances:
+		ret = t
+		kind = checkKind(kind, DataKindBalances)
+
+	case *PublicTrade:
+		ret = []*PublicTrade{t}
+		kind = checkKind(kind, DataKindTrades)
+
+	case []*PublicTrade:
+		ret = t
+		kind = checkKind(kind, DataKindTrades)
+
+	case *Order:
+		ret = []*Order{t}
+		kind = checkKind(kind, DataKindOrders)
+
+	case []*Order:
+		ret = t
+		kind = checkKind(kind, DataKindOrders)
+
+	case *Position:
+		ret = []*Position{t}
+		kind = checkKind(kind, DataKindPositions)
+
+	case []*Position:
+		ret = t
+		kind = checkKind(kind, DataKindPositions)
+
+	case *Trade:
+		ret, kind = normalizeTrades(kind, []*Trade{t})
+
+	case []*Trade:
+		ret, kind = normalizeTrades(kind, t)
+
+	default:
+		if kind != DataKindUnknown {
+			return DataKindUnknown, nil, errors.WithMessagef(ErrUnexpectedPayload, "kind=%d payload=%T", kind, data)
+		}
+		return DataKindUnknown, data, nil
+	}
+
+	if kind == DataKindUnknown {
+		return DataKindUnknown, nil, errors.WithMessagef(ErrUnexpectedPayload, "payload=%T", data)
+	}
+	return kind, ret, nil
+}
+
+//checkKind return expect if kind is unknown or equal to expect
+func checkKind(kind DataKind, expect DataKind) DataKind {
+	if kind == DataKindUnknown || kind == expect {
+		return expect
+	}
+	return DataKindUnknown
+}
+
+//normalizeTrades return fills if kind is DataKindFills otherwise trades are
+//converted to public trades
+func normalizeTrades(kind DataKind, trades []*Trade) (interface{}, DataKind) {
+	if kind == DataKindFills {
+		return trades, DataKindFills
+	}
+	if kind != DataKindUnknown && kind != DataKindTrades {
+		return nil, DataKindUnknown
+	}
+
+	ret := make([]*PublicTrade, len(trades))
+	for i, t := range trades {
+		ret[i] = &PublicTrade{
+			Symbol: t.Symbol,
+			Price:  t.Price,
+			Amount: t.Amount,
+			Side:   t.Side,
+			ID:     t.ID,
+			Time:   t.Time,
+			Raw:    t.Raw,
+		}
+	}
+	return ret, DataKindTrades
+}
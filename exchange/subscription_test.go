@@ -0,0 +1,106 @@
+package exchange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	testSubSymbol struct {
+		*BaseSwapSymbol
+	}
+
+	testFills []*Trade
+
+	testBadKind struct{}
+)
+
+func (ts *testSubSymbol) String() string {
+	return "BTCUSDT-SWAP"
+}
+
+func (tf testFills) Normalize() (DataKind, interface{}, error) {
+	return DataKindFills, []*Trade(tf), nil
+}
+
+func (tb testBadKind) Normalize() (DataKind, interface{}, error) {
+	return DataKindTicker, &Order{}, nil
+}
+
+func TestSubscription(t *testing.T) {
+	sym := &testSubSymbol{NewBaseSwapSymbol("BTCUSDT")}
+	sub := NewSubscription(16)
+
+	var (
+		books  []*OrderBook
+		trades []*PublicTrade
+		fills  []*Trade
+		orders []*Order
+		others []*WSNotify
+		errs   []error
+	)
+	sub.OnOrderBook(func(n *WSNotify, ob *OrderBook) {
+		books = append(books, ob)
+	}).OnTrade(func(n *WSNotify, pt *PublicTrade) {
+		trades = append(trades, pt)
+	}).OnFill(func(n *WSNotify, tr *Trade) {
+		fills = append(fills, tr)
+	}).OnOrder(func(n *WSNotify, o *Order) {
+		orders = append(orders, o)
+	}).OnOther(func(n *WSNotify) {
+		others = append(others, n)
+	}).OnError(func(n *WSNotify, err error) {
+		errs = append(errs, err)
+	})
+
+	msgs := []interface{}{
+		&WSNotify{Exchange: "test", Chan: "depth", Data: &OrderBookNotify{
+			Symbol: sym,
+			Bids:   []OrderElem{{1.0, 1.0}},
+			Asks:   []OrderElem{{2.0, 1.0}},
+		}},
+		&WSNotify{Exchange: "test", Chan: "depth", Data: &OrderBookNotify{
+			Symbol: sym,
+			Bids:   []OrderElem{{1.5, 1.0}},
+			Asks:   []OrderElem{{2.0, 0.0}, {3.0, 1.0}},
+		}},
+		&WSNotify{Exchange: "test", Chan: "trade", Data: []*Trade{
+			{ID: "1", Symbol: sym, Price: decimal.NewFromInt(2)},
+			{ID: "2", Symbol: sym, Price: decimal.NewFromInt(3)},
+		}},
+		&WSNotify{Exchange: "test", Chan: "fill", Data: testFills{{ID: "3", Symbol: sym}}},
+		&Order{ID: NewIntID(1), Symbol: sym},
+		&WSNotify{Exchange: "test", Chan: "index", Data: "index"},
+		&WSNotify{Exchange: "test", Chan: "bad", Data: testBadKind{}},
+	}
+	for _, msg := range msgs {
+		sub.Data() <- msg
+	}
+	close(sub.Data())
+	if err := sub.Run(context.Background()); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+
+	if len(books) != 2 || len(books[1].Bids) != 2 || books[1].Bids[0].Price != 1.5 ||
+		len(books[1].Asks) != 1 || books[1].Asks[0].Price != 3.0 {
+		t.Errorf("bad books %+v", books)
+	}
+	if len(trades) != 2 || trades[1].ID != "2" || trades[1].Symbol.String() != sym.String() {
+		t.Errorf("bad trades %+v", trades)
+	}
+	if len(fills) != 1 || fills[0].ID != "3" {
+		t.Errorf("bad fills %+v", fills)
+	}
+	if len(orders) != 1 || orders[0].ID.String() != "1" {
+		t.Errorf("bad orders %+v", orders)
+	}
+	if len(others) != 1 || others[0].Chan != "index" {
+		t.Errorf("bad others %+v", others)
+	}
+	if len(errs) != 1 || errors.Cause(errs[0]) != ErrUnexpectedPayload {
+		t.Errorf("bad errors %+v", errs)
+	}
+}
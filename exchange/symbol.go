@@ -59,6 +59,7 @@ type (
 		valueMin        decimal.Decimal //minuim price * amount
 		amountExponent  int32
 		priceExponent   int32
+		inverse         bool
 	}
 
 	//SymbolConfig used to specific symbol property
@@ -69,6 +70,7 @@ type (
 		AmountMin       decimal.Decimal
 		AmountMax       decimal.Decimal
 		ValueMin        decimal.Decimal
+		Inverse         bool //contract value is quoted in quote currency e.g. BTC-USD swap
 	}
 
 	//BaseOptionSymbol define common property of option symbol
@@ -159,19 +161,18 @@ func (p *SymbolConfig) Property() BaseSymbolProperty {
 		valueMin:        p.ValueMin,
 		amountExponent:  int32(p.AmountPrecision.Exponent()),
 		priceExponent:   int32(p.PricePrecision.Exponent()),
+		inverse:         p.Inverse,
 	}
 }
 
+//RoundAmount round amt toward zero to multiple of amount precision
 func (p *BaseSymbolProperty) RoundAmount(amt decimal.Decimal) decimal.Decimal {
-	k := amt.Div(p.amountPrecision)
-	v, _ := k.Float64()
-	return decimal.NewFromInt(int64(v)).Mul(p.amountPrecision)
+	return RoundWithMode(amt, p.amountPrecision, RoundModeDown)
 }
 
+//RoundPrice round price toward zero to multiple of price precision
 func (p *BaseSymbolProperty) RoundPrice(price decimal.Decimal) decimal.Decimal {
-	k := price.Div(p.pricePrecision)
-	v, _ := k.Float64()
-	return decimal.NewFromInt(int64(v)).Mul(p.pricePrecision)
+	return RoundWithMode(price, p.pricePrecision, RoundModeDown)
 }
 
 func (p *BaseSymbolProperty) AmountExponent() int32 {
@@ -211,6 +212,12 @@ func (p *BaseSymbolProperty) ValueMin() decimal.Decimal {
 	return p.valueMin
 }
 
+//Inverse return true if contract value of the symbol is quoted in quote
+//currency, the order value is amount * ContractVal
+func (p *BaseSymbolProperty) Inverse() bool {
+	return p.inverse
+}
+
 func NewBaseOptionSymbol(index string, st time.Time, strike decimal.Decimal, typ OptionType, prop SymbolConfig, raw interface{}) *BaseOptionSymbol {
 	return &BaseOptionSymbol{
 		RawMixin:           RawMixin{raw},
@@ -342,7 +349,7 @@ func (bsv *BaseSwapSymbol) ContractVal() decimal.Decimal {
 	return bsv.contractVal
 }
 
+//Round round val toward zero to multiple of p
 func Round(val decimal.Decimal, p decimal.Decimal) decimal.Decimal {
-	times, _ := val.Div(p).Float64()
-	return decimal.NewFromInt(int64(times)).Mul(p)
+	return RoundWithMode(val, p, RoundModeDown)
 }
@@ -0,0 +1,210 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	//SymbolFetcher fetch all symbols of an exchange
+	SymbolFetcher func(ctx context.Context) ([]Symbol, error)
+
+	//SymbolDiff symbols which are listed and delisted between two refresh
+	SymbolDiff struct {
+		Listed   []Symbol
+		Delisted []Symbol
+	}
+
+	//SymbolStore keep symbols of an exchange client instance. the symbols can
+	//be lookup by exchange name (Symbol.String()) or by canonical name which
+	//is exchange independent. SymbolStore is safe for concurrent use
+	SymbolStore struct {
+		fetcher    SymbolFetcher
+		canonical  func(Symbol) string
+		mu         sync.RWMutex
+		symbols    map[string]Symbol
+		canonicals map[string]Symbol
+		updated    time.Time
+	}
+
+	//SymbolStoreOption specific optional SymbolStore config
+	SymbolStoreOption func(*SymbolStore)
+)
+
+//NewSymbolStore return an empty store, Refresh must be called to load symbols
+func NewSymbolStore(fetcher SymbolFetcher, opts ...SymbolStoreOption) *SymbolStore {
+	ret := &SymbolStore{
+		fetcher:    fetcher,
+		canonical:  CanonicalName,
+		symbols:    map[string]Symbol{},
+		canonicals: map[string]Symbol{},
+	}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return ret
+}
+
+//WithCanonicalName replace the default CanonicalName func
+func WithCanonicalName(fn func(Symbol) string) SymbolStoreOption {
+	return func(ss *SymbolStore) {
+		ss.canonical = fn
+	}
+}
+
+//Refresh fetch symbols and replace the store content. the returned diff
+//contain listed and delisted symbols compare with the previous content
+func (ss *SymbolStore) Refresh(ctx context.Context) (*SymbolDiff, error) {
+	symbols, err := ss.fetcher(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "fetch symbols fail")
+	}
+	return ss.Set(symbols), nil
+}
+
+//Set replace the store content with symbols and return the diff
+func (ss *SymbolStore) Set(symbols []Symbol) *SymbolDiff {
+	nm := make(map[string]Symbol, len(symbols))
+	cm := make(map[string]Symbol, len(symbols))
+	for _, s := range symbols {
+		nm[s.String()] = s
+		cm[ss.canonical(s)] = s
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	diff := &SymbolDiff{}
+	for name, s := range nm {
+		if _, ok := ss.symbols[name]; !ok {
+			diff.Listed = append(diff.Listed, s)
+		}
+	}
+	for name, s := range ss.symbols {
+		if _, ok := nm[name]; !ok {
+			diff.Delisted = append(diff.Delisted, s)
+		}
+	}
+	sortSymbols(diff.Listed)
+	sortSymbols(diff.Delisted)
+
+	ss.symbols = nm
+	ss.canonicals = cm
+	ss.updated = time.Now()
+	return diff
+}
+
+//Loop refresh the store every interval until ctx is done. cb is invoked
+//with the refresh result if it is not nil
+func (ss *SymbolStore) Loop(ctx context.Context, interval time.Duration, cb func(*SymbolDiff, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			diff, err := ss.Refresh(ctx)
+			if cb != nil {
+				cb(diff, err)
+			}
+		}
+	}
+}
+
+//Lookup return symbol with exchange symbol name
+func (ss *SymbolStore) Lookup(name string) (Symbol, error) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	sym, ok := ss.symbols[name]
+	if !ok {
+		return nil, NewBadArg("unknown symbol", name)
+	}
+	return sym, nil
+}
+
+//LookupCanonical return symbol with canonical name
+func (ss *SymbolStore) LookupCanonical(name string) (Symbol, error) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	sym, ok := ss.canonicals[name]
+	if !ok {
+		return nil, NewBadArg("unknown canonical symbol", name)
+	}
+	return sym, nil
+}
+
+//Symbols return all symbols sorted by exchange symbol name
+func (ss *SymbolStore) Symbols() []Symbol {
+	ss.mu.RLock()
+	ret := make([]Symbol, 0, len(ss.symbols))
+	for _, s := range ss.symbols {
+		ret = append(ret, s)
+	}
+	ss.mu.RUnlock()
+
+	sortSymbols(ret)
+	return ret
+}
+
+//Updated return the last refresh time. zero time means never refreshed
+func (ss *SymbolStore) Updated() time.Time {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.updated
+}
+
+//Empty return true if there is no listed and delisted symbols
+func (sd *SymbolDiff) Empty() bool {
+	return len(sd.Listed) == 0 && len(sd.Delisted) == 0
+}
+
+//CanonicalName return exchange independent name of sym. the format is
+//	SPOT:BASE/QUOTE
+//	MARGIN:BASE/QUOTE
+//	SWAP:INDEX
+//	FUTURE:INDEX:20060102
+//	OPTION:INDEX:20060102:STRIKE:C|P
+func CanonicalName(sym Symbol) string {
+	var ret string
+	switch s := sym.(type) {
+	case OptionSymbol:
+		typ := "P"
+		if s.Type() == OptionTypeCall {
+			typ = "C"
+		}
+		ret = fmt.Sprintf("OPTION:%s:%s:%s:%s", s.Index(), s.SettleTime().UTC().Format("20060102"), s.Strike().String(), typ)
+
+	case FuturesSymbol:
+		ret = fmt.Sprintf("FUTURE:%s:%s", s.Index(), s.SettleTime().UTC().Format("20060102"))
+
+	case SwapSymbol:
+		ret = fmt.Sprintf("SWAP:%s", s.Index())
+
+	case MarginSymbol:
+		ret = fmt.Sprintf("MARGIN:%s/%s", s.Base(), s.Quote())
+
+	case SpotSymbol:
+		ret = fmt.Sprintf("SPOT:%s/%s", s.Base(), s.Quote())
+
+	default:
+		ret = sym.String()
+	}
+	return strings.ToUpper(ret)
+}
+
+func sortSymbols(symbols []Symbol) {
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].String() < symbols[j].String()
+	})
+}
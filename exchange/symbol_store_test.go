@@ -0,0 +1,125 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	testStoreSymbol struct {
+		*BaseSpotSymbol
+	}
+
+	testMarginSymbol struct {
+		*BaseMarginSymbol
+	}
+
+	testSwapSymbol struct {
+		*BaseSwapSymbol
+	}
+
+	testFutureSymbol struct {
+		*BaseFutureSymbol
+	}
+
+	testOptionSymbol struct {
+		*BaseOptionSymbol
+	}
+)
+
+func (s *testMarginSymbol) String() string { return "margin" }
+func (s *testSwapSymbol) String() string   { return "swap" }
+func (s *testFutureSymbol) String() string { return "future" }
+func (s *testOptionSymbol) String() string { return "option" }
+
+func (s *testStoreSymbol) String() string {
+	return s.Base() + s.Quote()
+}
+
+func newTestStoreSymbol(base, quote string) Symbol {
+	return &testStoreSymbol{NewBaseSpotSymbol(base, quote, SymbolConfig{}, nil)}
+}
+
+func TestSymbolStore(t *testing.T) {
+	var (
+		symbols  []Symbol
+		fetchErr error
+	)
+	store := NewSymbolStore(func(ctx context.Context) ([]Symbol, error) {
+		return symbols, fetchErr
+	})
+
+	if !store.Updated().IsZero() {
+		t.Errorf("store should not be updated")
+	}
+
+	symbols = []Symbol{newTestStoreSymbol("BTC", "USDT"), newTestStoreSymbol("ETH", "USDT")}
+	diff, err := store.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("refresh fail %s", err.Error())
+	}
+	if len(diff.Listed) != 2 || len(diff.Delisted) != 0 {
+		t.Errorf("bad diff %+v", diff)
+	}
+
+	sym, err := store.Lookup("BTCUSDT")
+	if err != nil || sym.String() != "BTCUSDT" {
+		t.Errorf("lookup fail %v %v", sym, err)
+	}
+	sym, err = store.LookupCanonical("SPOT:ETH/USDT")
+	if err != nil || sym.String() != "ETHUSDT" {
+		t.Errorf("lookup canonical fail %v %v", sym, err)
+	}
+	if _, err := store.Lookup("XRPUSDT"); !errors.Is(err, &ErrBadArg{}) {
+		t.Errorf("unknown symbol should return ErrBadArg %v", err)
+	}
+
+	symbols = []Symbol{newTestStoreSymbol("ETH", "USDT"), newTestStoreSymbol("XRP", "USDT")}
+	diff, err = store.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("refresh fail %s", err.Error())
+	}
+	if len(diff.Listed) != 1 || diff.Listed[0].String() != "XRPUSDT" ||
+		len(diff.Delisted) != 1 || diff.Delisted[0].String() != "BTCUSDT" {
+		t.Errorf("bad diff %+v", diff)
+	}
+
+	all := store.Symbols()
+	if len(all) != 2 || all[0].String() != "ETHUSDT" || all[1].String() != "XRPUSDT" {
+		t.Errorf("bad symbols %v", all)
+	}
+
+	fetchErr = errors.New("network error")
+	if _, err := store.Refresh(context.Background()); err == nil {
+		t.Errorf("refresh should fail")
+	}
+	if _, err := store.Lookup("XRPUSDT"); err != nil {
+		t.Errorf("symbols should be kept if refresh fail %s", err.Error())
+	}
+
+	if diff := store.Set(store.Symbols()); !diff.Empty() {
+		t.Errorf("diff should be empty %+v", diff)
+	}
+}
+
+func TestCanonicalName(t *testing.T) {
+	st := time.Date(2021, 6, 25, 8, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		symbol Symbol
+		expect string
+	}{
+		{newTestStoreSymbol("btc", "usdt"), "SPOT:BTC/USDT"},
+		{&testMarginSymbol{NewBaseMarginSymbol("BTC", "USDT", SymbolConfig{}, decimal.NewFromInt(3), nil)}, "MARGIN:BTC/USDT"},
+		{&testSwapSymbol{NewBaseSwapSymbol("BTC-USD")}, "SWAP:BTC-USD"},
+		{&testFutureSymbol{NewBaseFutureSymbol("BTC-USD", st, FutureTypeCQ)}, "FUTURE:BTC-USD:20210625"},
+		{&testOptionSymbol{NewBaseOptionSymbol("BTC", st, decimal.NewFromInt(40000), OptionTypeCall, SymbolConfig{}, nil)}, "OPTION:BTC:20210625:40000:C"},
+	} {
+		if name := CanonicalName(c.symbol); name != c.expect {
+			t.Errorf("bad canonical name expect=%s got=%s", c.expect, name)
+		}
+	}
+}
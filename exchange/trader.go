@@ -0,0 +1,25 @@
+package exchange
+
+import "context"
+
+type (
+	//Trader unified order entry interface which implemented by every exchange
+	//adapter. strategy code can switch venue without per exchange glue code
+	Trader interface {
+		//CreateOrder create a order with req. options specific additional order
+		//flag, adapter return ErrBadArg if the option is not supported
+		CreateOrder(ctx context.Context, req *OrderRequest, options ...OrderReqOption) (*Order, error)
+		//CancelOrder cancel the order. only Symbol and ID field of order is required
+		CancelOrder(ctx context.Context, order *Order) (*Order, error)
+		//FetchOrder get latest order info. only Symbol and ID field of order is required
+		FetchOrder(ctx context.Context, order *Order) (*Order, error)
+		//OpenOrders return all unfinished orders of symbol
+		OpenOrders(ctx context.Context, symbol Symbol) ([]*Order, error)
+	}
+)
+
+//NewUnsupportOption return ErrBadArg which used by Trader.CreateOrder to
+//notify user that the option is not supported by exchange
+func NewUnsupportOption(option OrderReqOption) error {
+	return NewBadArg("unsupport option", option)
+}
@@ -0,0 +1,94 @@
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	//ConstraintMinQty order amount is less than symbol AmountMin or not positive
+	ConstraintMinQty = "min qty"
+	//ConstraintMaxQty order amount is greater than symbol AmountMax
+	ConstraintMaxQty = "max qty"
+	//ConstraintLotSize order amount is not multiple of symbol AmountPrecision
+	ConstraintLotSize = "lot size"
+	//ConstraintTickSize order price is not multiple of symbol PricePrecision or not positive
+	ConstraintTickSize = "tick size"
+	//ConstraintMinNotional order value is less than symbol ValueMin, the value
+	//of futures and swap order is price * amount * ContractVal and the value of
+	//inverse contract is amount * ContractVal
+	ConstraintMinNotional = "min notional"
+)
+
+//ValidateOrderRequest check req against symbol limits before send it to
+//exchange. the returned error is *ErrBadArg whose Msg is one of Constraint*
+//const. zero limits of symbol are skipped. price and notional are only
+//checked for limit orders
+func ValidateOrderRequest(req *OrderRequest) error {
+	if req == nil {
+		return NewBadArg("nil order request", req)
+	}
+	sym := req.Symbol
+	if sym == nil {
+		return NewBadArg("nil symbol", req)
+	}
+
+	amount := req.Amount
+	if amount.Sign() <= 0 {
+		return NewBadArg(ConstraintMinQty, fmt.Sprintf("amount=%s must be positive", amount))
+	}
+	if min := sym.AmountMin(); min.Sign() > 0 && amount.LessThan(min) {
+		return NewBadArg(ConstraintMinQty, fmt.Sprintf("amount=%s min=%s", amount, min))
+	}
+	if max := sym.AmountMax(); max.Sign() > 0 && amount.GreaterThan(max) {
+		return NewBadArg(ConstraintMaxQty, fmt.Sprintf("amount=%s max=%s", amount, max))
+	}
+	if ap := sym.AmountPrecision(); ap.Sign() > 0 && !amount.Mod(ap).IsZero() {
+		return NewBadArg(ConstraintLotSize, fmt.Sprintf("amount=%s step=%s", amount, ap))
+	}
+
+	if req.Type != OrderTypeLimit && req.Type != OrderTypeStopLimit {
+		return nil
+	}
+
+	price := req.Price
+	if price.Sign() <= 0 {
+		return NewBadArg(ConstraintTickSize, fmt.Sprintf("price=%s must be positive", price))
+	}
+	if pp := sym.PricePrecision(); pp.Sign() > 0 && !price.Mod(pp).IsZero() {
+		return NewBadArg(ConstraintTickSize, fmt.Sprintf("price=%s tick=%s", price, pp))
+	}
+	if vm := sym.ValueMin(); vm.Sign() > 0 {
+		value := price.Mul(amount)
+		if cv := contractVal(sym); cv.Sign() > 0 {
+			if inverse(sym) {
+				value = amount.Mul(cv)
+			} else {
+				value = value.Mul(cv)
+			}
+		}
+		if value.LessThan(vm) {
+			return NewBadArg(ConstraintMinNotional, fmt.Sprintf("value=%s min=%s", value, vm))
+		}
+	}
+	return nil
+}
+
+//contractVal return contract value of futures and swap symbol, zero for others
+func contractVal(sym Symbol) decimal.Decimal {
+	switch s := sym.(type) {
+	case FuturesSymbol:
+		return s.ContractVal()
+	case SwapSymbol:
+		return s.ContractVal()
+	default:
+		return decimal.Zero
+	}
+}
+
+//inverse return true if sym is an inverse contract
+func inverse(sym Symbol) bool {
+	s, ok := sym.(interface{ Inverse() bool })
+	return ok && s.Inverse()
+}
@@ -0,0 +1,104 @@
+package exchange
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestValidateOrderRequest(t *testing.T) {
+	sym := &testStoreSymbol{NewBaseSpotSymbol("BTC", "USDT", SymbolConfig{
+		PricePrecision:  decimal.RequireFromString("0.01"),
+		AmountPrecision: decimal.RequireFromString("0.001"),
+		AmountMin:       decimal.RequireFromString("0.001"),
+		AmountMax:       decimal.RequireFromString("100"),
+		ValueMin:        decimal.RequireFromString("10"),
+	}, nil)}
+
+	for _, c := range []struct {
+		price      string
+		amount     string
+		typ        OrderType
+		constraint string
+	}{
+		{"40000.01", "0.001", OrderTypeLimit, ""},
+		{"40000.001", "0.001", OrderTypeLimit, ConstraintTickSize},
+		{"0", "0.001", OrderTypeLimit, ConstraintTickSize},
+		{"40000", "0.0015", OrderTypeLimit, ConstraintLotSize},
+		{"40000", "0", OrderTypeLimit, ConstraintMinQty},
+		{"40000", "0.0005", OrderTypeLimit, ConstraintMinQty},
+		{"40000", "101", OrderTypeLimit, ConstraintMaxQty},
+		{"1000", "0.001", OrderTypeLimit, ConstraintMinNotional},
+		{"0", "0.001", OrderTypeMarket, ""},
+	} {
+		req := &OrderRequest{
+			Symbol: sym,
+			Type:   c.typ,
+			Price:  decimal.RequireFromString(c.price),
+			Amount: decimal.RequireFromString(c.amount),
+		}
+
+		err := ValidateOrderRequest(req)
+		if c.constraint == "" {
+			if err != nil {
+				t.Errorf("validate price=%s amount=%s fail %s", c.price, c.amount, err.Error())
+			}
+			continue
+		}
+
+		var ba *ErrBadArg
+		if !errors.As(err, &ba) || ba.Msg != c.constraint {
+			t.Errorf("validate price=%s amount=%s expect=%s got=%v", c.price, c.amount, c.constraint, err)
+		}
+	}
+
+	swap := &testSwapSymbol{NewBaseSwapSymbolWithCfg("BTC-USDT", decimal.RequireFromString("0.01"), SymbolConfig{
+		PricePrecision:  decimal.RequireFromString("0.1"),
+		AmountPrecision: decimal.RequireFromString("1"),
+		ValueMin:        decimal.RequireFromString("100"),
+	}, nil)}
+	for _, c := range []struct {
+		amount string
+		ok     bool
+	}{
+		{"1", false},
+		{"2", false},
+		{"3", true},
+	} {
+		err := ValidateOrderRequest(&OrderRequest{
+			Symbol: swap,
+			Type:   OrderTypeLimit,
+			Price:  decimal.NewFromInt(4000),
+			Amount: decimal.RequireFromString(c.amount),
+		})
+		if (err == nil) != c.ok {
+			t.Errorf("validate swap amount=%s expect ok=%v got=%v", c.amount, c.ok, err)
+		}
+	}
+
+	//inverse contract value is amount * ContractVal regardless of price
+	inverse := &testSwapSymbol{NewBaseSwapSymbolWithCfg("BTC-USD", decimal.NewFromInt(100), SymbolConfig{
+		PricePrecision:  decimal.RequireFromString("0.1"),
+		AmountPrecision: decimal.RequireFromString("1"),
+		ValueMin:        decimal.NewFromInt(200),
+		Inverse:         true,
+	}, nil)}
+	for _, c := range []struct {
+		amount string
+		ok     bool
+	}{
+		{"1", false},
+		{"2", true},
+	} {
+		err := ValidateOrderRequest(&OrderRequest{
+			Symbol: inverse,
+			Type:   OrderTypeLimit,
+			Price:  decimal.NewFromInt(40000),
+			Amount: decimal.RequireFromString(c.amount),
+		})
+		if (err == nil) != c.ok {
+			t.Errorf("validate inverse amount=%s expect ok=%v got=%v", c.amount, c.ok, err)
+		}
+	}
+}
@@ -12,12 +12,17 @@ type (
 		handler rpc.Handler
 		codec   rpc.Codec
 		addr    string
+		hb      *rpc.Heartbeat
+		opts    []DialOption
 	}
 
 	WSNotify struct {
 		Exchange string
 		Chan     string
 		Data     interface{}
+		//Resync is set if previous notify of the same channel are dropped, see
+		//Delivery
+		Resync bool
 	}
 
 	//Channel a subscribe channel
@@ -35,17 +40,36 @@ func NewWSClient(addr string, codec rpc.Codec, handler rpc.Handler) *WSClient {
 }
 
 func (wc *WSClient) Run(ctx context.Context) error {
-	stream, err := rpc.NewWebsocketStream(wc.addr, wc.codec)
+	stream, err := rpc.NewWebsocketStream(wc.addr, wc.codec, wc.opts...)
 	if err != nil {
 		return err
 	}
 
-	conn := rpc.NewConn(stream)
+	conn := rpc.NewConnWithHeartbeat(stream, wc.hb)
 	wc.Conn = conn
 	go wc.Conn.Run(ctx, wc.handler)
 	return nil
 }
 
+//WrapCodec replace the codec with wrap(codec). it must be called before Run
+//and is used to capture raw frames, see misc/wsrecord
+func (wc *WSClient) WrapCodec(wrap func(rpc.Codec) rpc.Codec) {
+	wc.codec = wrap(wc.codec)
+}
+
+//SetHeartbeat set ping strategy and idle timeout of the connection, the
+//connection fail with rpc.StreamError if it is idle. it must be called
+//before Run, adapters set the exchange default in constructor
+func (wc *WSClient) SetHeartbeat(hb *rpc.Heartbeat) {
+	wc.hb = hb
+}
+
+//SetDialOptions append opts which config header, proxy, tls, compression,
+//read limit and write timeout of the stream. it must be called before Run
+func (wc *WSClient) SetDialOptions(opts ...DialOption) {
+	wc.opts = append(wc.opts, opts...)
+}
+
 func (ws *WSClient) Close() error {
 	if ws.Conn == nil {
 		return nil
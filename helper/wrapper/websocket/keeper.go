@@ -2,8 +2,13 @@ package websocket
 
 import (
 	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
 
 	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/misc/metrics"
 	"github.com/pkg/errors"
 )
 
@@ -15,48 +20,175 @@ type (
 		Channels(ctx context.Context, oldChannel []exchange.Channel) (newChannels []exchange.Channel, notify chan struct{}, err error)
 	}
 
+	//Backoff config delay between reconnect attempts. the delay of nth
+	//failed attempt is Min * Factor^(n-1) capped by Max, then randomized by
+	//+/- Jitter fraction
+	Backoff struct {
+		Min    time.Duration
+		Max    time.Duration
+		Factor float64
+		Jitter float64
+	}
+
+	//KeeperConfig config of Keeper, zero fields use the default value
+	KeeperConfig struct {
+		Backoff Backoff
+		//BatchSize max channels subscribed in one request, default 1 since
+		//some exchanges such as okex do not support multi channel subscribe
+		BatchSize int
+		//EventSize buffer size of Events channel, events are dropped if the
+		//buffer is full
+		EventSize int
+		//MinUptime the connection must be kept at least MinUptime before the
+		//failed attempt count is reset, so a connection which is dropped soon
+		//after subscribe still back off. default 10s
+		MinUptime time.Duration
+	}
+
+	//EventKind kind of keeper lifecycle event
+	EventKind int
+
+	//Event keeper lifecycle event
+	Event struct {
+		Kind EventKind
+		Time time.Time
+		//Attempt count of consecutive failed connect attempts
+		Attempt int
+		//Subscribed and Unsubscribed channels of EventResubscribed
+		Subscribed   []exchange.Channel
+		Unsubscribed []exchange.Channel
+		//Err reason of EventDisconnected, nil if closed by Close or ctx
+		Err error
+	}
+
 	//Keeper is a struct which used to make websocket connection auto reconnect and auto update subscribe channels
 	Keeper struct {
-		channels []exchange.Channel
-		conn     Conn
-		gen      Gen
-		done     chan struct{}
-		close    chan struct{}
-		closed   bool
-		ech      chan error
+		cfg       KeeperConfig
+		channels  []exchange.Channel
+		conn      Conn
+		gen       Gen
+		done      chan struct{}
+		close     chan struct{}
+		closeOnce sync.Once
+		ech       chan error
+		events    chan Event
+	}
+)
+
+const (
+	//EventConnecting a connect attempt start
+	EventConnecting EventKind = iota
+	//EventConnected connection is created
+	EventConnected
+	//EventResubscribed subscribed channels are updated
+	EventResubscribed
+	//EventDisconnected connect attempt fail or connection is lost
+	EventDisconnected
+)
+
+const (
+	defaultMinUptime = time.Second * 10
+)
+
+var (
+	//DefaultBackoff is used if KeeperConfig.Backoff is zero
+	DefaultBackoff = Backoff{
+		Min:    time.Millisecond * 100,
+		Max:    time.Second * 30,
+		Factor: 2,
+		Jitter: 0.2,
 	}
 )
 
 func NewKeeper(gen Gen) *Keeper {
+	return NewKeeperWithConfig(gen, &KeeperConfig{})
+}
+
+//NewKeeperWithConfig create Keeper with backoff, batch size and event buffer
+//of cfg
+func NewKeeperWithConfig(gen Gen, cfg *KeeperConfig) *Keeper {
+	c := *cfg
+	if c.Backoff == (Backoff{}) {
+		c.Backoff = DefaultBackoff
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 1
+	}
+	if c.EventSize <= 0 {
+		c.EventSize = 16
+	}
+	if c.MinUptime <= 0 {
+		c.MinUptime = defaultMinUptime
+	}
 	return &Keeper{
-		gen:   gen,
-		done:  make(chan struct{}, 0),
-		ech:   make(chan error, 1),
-		close: make(chan struct{}),
+		cfg:    c,
+		gen:    gen,
+		done:   make(chan struct{}, 0),
+		ech:    make(chan error, 1),
+		close:  make(chan struct{}),
+		events: make(chan Event, c.EventSize),
 	}
 }
 
+//Loop keep the connection until ctx is done or Close is called. failed
+//attempts are retried after backoff, Events is closed when Loop return
 func (k *Keeper) Loop(ctx context.Context) {
 	defer close(k.done)
+	defer close(k.events)
+
+	attempt := 0
 	for {
+		if k.stopped(ctx) {
+			return
+		}
+
+		k.emit(Event{Kind: EventConnecting, Attempt: attempt})
 		conn, err := k.gen.NewConn(ctx)
-		if err == context.Canceled || err == context.DeadlineExceeded || k.closed {
+		if err == context.Canceled || err == context.DeadlineExceeded {
 			return
 		}
 		if err != nil {
-			k.pushErrorClose(err)
+			attempt++
+			k.pushError(err)
+			k.emit(Event{Kind: EventDisconnected, Attempt: attempt, Err: err})
+			if !wait(ctx, k.close, k.cfg.Backoff.Delay(attempt)) {
+				return
+			}
 			continue
 		}
+		if k.stopped(ctx) {
+			conn.Close()
+			return
+		}
+
 		k.conn = conn
 		k.channels = nil
-		k.connLoop(ctx)
+		k.emit(Event{Kind: EventConnected, Attempt: attempt})
+		connected := time.Now()
+		healthy, err := k.connLoop(ctx)
+		if healthy && time.Since(connected) >= k.cfg.MinUptime {
+			attempt = 0
+		}
+		if k.stopped(ctx) {
+			k.emit(Event{Kind: EventDisconnected})
+			return
+		}
+
+		attempt++
+		metrics.Default().Add(metrics.KeeperReconnects, 1)
+		k.pushError(err)
+		k.emit(Event{Kind: EventDisconnected, Attempt: attempt, Err: err})
+		if !wait(ctx, k.close, k.cfg.Backoff.Delay(attempt)) {
+			return
+		}
 	}
 }
 
-// Close loop manualy
+// Close loop manualy, it is safe to call Close multiple times
 func (k *Keeper) Close() {
-	k.closed = true
-	close(k.close)
+	k.closeOnce.Do(func() {
+		close(k.close)
+	})
 }
 
 // ECh push error when error happen
@@ -68,67 +200,150 @@ func (k *Keeper) Done() chan struct{} {
 	return k.done
 }
 
-func (k *Keeper) connLoop(ctx context.Context) {
+//Events return lifecycle events channel which is closed when Loop return
+func (k *Keeper) Events() <-chan Event {
+	return k.events
+}
+
+//Delay return the delay before the next attempt after attempt failures
+func (b Backoff) Delay(attempt int) time.Duration {
+	if attempt <= 0 {
+		return 0
+	}
+	factor := b.Factor
+	if factor < 1 {
+		factor = 1
+	}
+	d := float64(b.Min) * math.Pow(factor, float64(attempt-1))
+	if b.Max > 0 && d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		d *= 1 + b.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(d)
+}
+
+//connLoop serve the connection until it fail, ctx is done or Close is
+//called. healthy is true if channels are subscribed successfully
+func (k *Keeper) connLoop(ctx context.Context) (healthy bool, err error) {
+	defer k.conn.Close()
+
 	notify, err := k.updateSubscribe(ctx)
 	if err != nil {
-		k.pushErrorClose(err)
-		return
+		return false, err
 	}
 	for {
 		select {
 		case <-k.conn.Done():
 			if err := k.conn.Error(); err != nil {
-				k.pushErrorClose(err)
+				return true, err
 			}
-			return
+			return true, errors.New("connection closed")
 
 		case <-k.close:
-			if err := k.conn.Close(); err != nil {
-				k.pushErrorClose(err)
-				return
-			}
+			return true, nil
 
 		case <-notify:
 			notify, err = k.updateSubscribe(ctx)
 			if err != nil {
-				k.pushErrorClose(err)
-				return
+				return true, err
 			}
 
 		case <-ctx.Done():
-			return
+			return true, nil
 		}
 	}
 }
 
+//updateSubscribe subscribe added channels and unsubscribe removed channels
+//compared with current subscribed channels
 func (k *Keeper) updateSubscribe(ctx context.Context) (chan struct{}, error) {
 	channels, notify, err := k.gen.Channels(ctx, k.channels)
 	if err != nil {
 		return nil, err
 	}
 
-	if k.channels != nil {
-		if err := k.conn.UnSubscribe(ctx, k.channels...); err != nil {
+	added := diffChannels(channels, k.channels)
+	removed := diffChannels(k.channels, channels)
+	for _, batch := range splitChannels(removed, k.cfg.BatchSize) {
+		if err := k.conn.UnSubscribe(ctx, batch...); err != nil {
 			return nil, errors.WithMessage(err, "unsubscribe channel fail")
 		}
 	}
-	for _, c := range channels {
-		//okex subscirbe multi channel not work
-		if err := k.conn.Subscribe(ctx, c); err != nil {
+	for _, batch := range splitChannels(added, k.cfg.BatchSize) {
+		if err := k.conn.Subscribe(ctx, batch...); err != nil {
 			return nil, errors.WithMessage(err, "subscribe channel fail")
 		}
 	}
 	k.channels = channels
+	k.emit(Event{Kind: EventResubscribed, Subscribed: added, Unsubscribed: removed})
 	return notify, nil
 }
 
-func (k *Keeper) pushErrorClose(err error) {
-	if k.conn != nil {
-		k.conn.Close()
+func (k *Keeper) stopped(ctx context.Context) bool {
+	select {
+	case <-k.close:
+		return true
+	case <-ctx.Done():
+		return true
+	default:
+		return false
 	}
+}
 
+//wait d and return false if ctx is done or closed is closed
+func wait(ctx context.Context, closed chan struct{}, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-closed:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (k *Keeper) emit(ev Event) {
+	ev.Time = time.Now()
+	select {
+	case k.events <- ev:
+	default:
+	}
+}
+
+func (k *Keeper) pushError(err error) {
 	select {
 	case k.ech <- err:
 	default:
 	}
 }
+
+//diffChannels return channels of a which are not in b
+func diffChannels(a []exchange.Channel, b []exchange.Channel) []exchange.Channel {
+	set := make(map[string]struct{}, len(b))
+	for _, c := range b {
+		set[c.String()] = struct{}{}
+	}
+	var ret []exchange.Channel
+	for _, c := range a {
+		if _, ok := set[c.String()]; !ok {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+func splitChannels(channels []exchange.Channel, size int) [][]exchange.Channel {
+	var ret [][]exchange.Channel
+	for len(channels) > size {
+		ret = append(ret, channels[:size])
+		channels = channels[size:]
+	}
+	if len(channels) != 0 {
+		ret = append(ret, channels)
+	}
+	return ret
+}
@@ -0,0 +1,232 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/exchange/binance/swap"
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+)
+
+type (
+	testGen struct {
+		addr string
+		data chan interface{}
+	}
+
+	testDiffGen struct {
+		testGen
+		mu      sync.Mutex
+		symbols []string
+		notify  chan struct{}
+	}
+)
+
+func (tg *testGen) NewConn(ctx context.Context) (Conn, error) {
+	data := tg.data
+	if data == nil {
+		data = make(chan interface{}, 1)
+	}
+	conn := binance.NewNotifyClient(tg.addr, swap.NewCodeC(), data, nil)
+	if err := conn.Run(ctx); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (tg *testGen) Channels(ctx context.Context, old []exchange.Channel) ([]exchange.Channel, chan struct{}, error) {
+	return []exchange.Channel{swap.NewBookTickerChannel("BTCUSDT")}, nil, nil
+}
+
+func (tg *testDiffGen) Channels(ctx context.Context, old []exchange.Channel) ([]exchange.Channel, chan struct{}, error) {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+	var ret []exchange.Channel
+	for _, sym := range tg.symbols {
+		ret = append(ret, swap.NewBookTickerChannel(sym))
+	}
+	return ret, tg.notify, nil
+}
+
+func (tg *testDiffGen) update(symbols ...string) {
+	tg.mu.Lock()
+	tg.symbols = symbols
+	tg.mu.Unlock()
+	tg.notify <- struct{}{}
+}
+
+func TestKeeperReconnect(t *testing.T) {
+	subs := make(chan string, 4)
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Field("method", binance.MethodSubscibe), func(m *wstest.Message) []interface{} {
+				subs <- m.String("id")
+				return []interface{}{map[string]interface{}{"result": nil, "id": m.Get("id")}}
+			}),
+		)
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	keeper := NewKeeper(&testGen{addr: srv.URL})
+	go keeper.Loop(ctx)
+
+	wait := func() {
+		select {
+		case <-subs:
+		case <-ctx.Done():
+			t.Fatalf("wait subscribe timeout")
+		}
+	}
+
+	wait()
+	srv.Drop()
+	select {
+	case err := <-keeper.ECh():
+		if err == nil {
+			t.Errorf("expect conn error")
+		}
+	case <-ctx.Done():
+		t.Fatalf("wait conn error timeout")
+	}
+	//channels are subscribed again after reconnect
+	wait()
+
+	keeper.Close()
+	select {
+	case <-keeper.Done():
+	case <-ctx.Done():
+		t.Fatalf("wait keeper done timeout")
+	}
+}
+
+func TestKeeperDiff(t *testing.T) {
+	reqs := make(chan *wstest.Message, 8)
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Has("method"), func(m *wstest.Message) []interface{} {
+				reqs <- m
+				return []interface{}{ack(m)}
+			}),
+		)
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	gen := &testDiffGen{
+		testGen: testGen{addr: srv.URL},
+		symbols: []string{"BTCUSDT", "ETHUSDT", "LTCUSDT"},
+		notify:  make(chan struct{}),
+	}
+	keeper := NewKeeperWithConfig(gen, &KeeperConfig{BatchSize: 2})
+	go keeper.Loop(ctx)
+
+	expect := func(method string, params ...string) {
+		select {
+		case m := <-reqs:
+			ps, _ := m.Get("params").([]interface{})
+			ok := m.String("method") == method && len(ps) == len(params)
+			for i := 0; ok && i < len(ps); i++ {
+				ok = ps[i] == params[i]
+			}
+			if !ok {
+				t.Errorf("expect %s %v got %s", method, params, string(m.Data))
+			}
+		case <-ctx.Done():
+			t.Fatalf("wait %s timeout", method)
+		}
+	}
+
+	expect(binance.MethodSubscibe, "btcusdt@bookTicker", "ethusdt@bookTicker")
+	expect(binance.MethodSubscibe, "ltcusdt@bookTicker")
+	gen.update("ETHUSDT", "XRPUSDT")
+	expect(binance.MethodUnSubscribe, "btcusdt@bookTicker", "ltcusdt@bookTicker")
+	expect(binance.MethodSubscibe, "xrpusdt@bookTicker")
+
+	keeper.Close()
+	keeper.Close()
+	var kinds []EventKind
+	for ev := range keeper.Events() {
+		kinds = append(kinds, ev.Kind)
+	}
+	expectKinds := []EventKind{EventConnecting, EventConnected, EventResubscribed, EventResubscribed, EventDisconnected}
+	if len(kinds) != len(expectKinds) {
+		t.Fatalf("bad events %v", kinds)
+	}
+	for i := range kinds {
+		if kinds[i] != expectKinds[i] {
+			t.Errorf("bad events %v", kinds)
+		}
+	}
+}
+
+func TestKeeperMinUptime(t *testing.T) {
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Has("method"), func(m *wstest.Message) []interface{} {
+				return []interface{}{ack(m)}
+			}),
+		)
+	})
+	defer srv.Close()
+
+	//attempts grow if connection is dropped before MinUptime and are reset
+	//if the connection is kept longer
+	for _, c := range []struct {
+		minUptime time.Duration
+		attempts  []int
+	}{
+		{time.Minute, []int{1, 2}},
+		{time.Nanosecond, []int{1, 1}},
+	} {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		keeper := NewKeeperWithConfig(&testGen{addr: srv.URL}, &KeeperConfig{
+			Backoff:   Backoff{Min: time.Millisecond, Factor: 1},
+			MinUptime: c.minUptime,
+		})
+		go keeper.Loop(ctx)
+
+		var attempts []int
+		for len(attempts) != len(c.attempts) {
+			select {
+			case ev := <-keeper.Events():
+				switch ev.Kind {
+				case EventResubscribed:
+					srv.Drop()
+				case EventDisconnected:
+					attempts = append(attempts, ev.Attempt)
+				}
+			case <-ctx.Done():
+				t.Fatalf("wait events timeout %v", attempts)
+			}
+		}
+		keeper.Close()
+		cancel()
+		for i := range attempts {
+			if attempts[i] != c.attempts[i] {
+				t.Errorf("bad attempts min uptime=%s %v", c.minUptime, attempts)
+			}
+		}
+	}
+}
+
+//ack reply of binance subscribe and unsubscribe request
+func ack(m *wstest.Message) interface{} {
+	return map[string]interface{}{"result": nil, "id": m.Get("id")}
+}
+
+func TestBackoff(t *testing.T) {
+	b := Backoff{Min: time.Second, Max: time.Second * 5, Factor: 2, Jitter: 0.5}
+	for attempt, base := range []time.Duration{0, time.Second, time.Second * 2, time.Second * 4, time.Second * 5, time.Second * 5} {
+		d := b.Delay(attempt)
+		if d < base/2 || d > base*3/2 {
+			t.Errorf("bad delay attempt=%d %s", attempt, d)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
+type (
+	//PoolGen create connections and return channels for Pool. NewConn
+	//should create the connection which push notify to data, data of each
+	//connection is merged into Pool.Data
+	PoolGen interface {
+		NewConn(ctx context.Context, data chan interface{}) (Conn, error)
+		Channels(ctx context.Context, oldChannel []exchange.Channel) (newChannels []exchange.Channel, notify chan struct{}, err error)
+	}
+
+	//PoolConfig config of Pool
+	PoolConfig struct {
+		//MaxChannels max channels subscribed by one connection such as 200
+		//for binance, all channels share one connection if zero
+		MaxChannels int
+		//Keeper config of the Keeper of each connection
+		Keeper KeeperConfig
+		//EventSize buffer size of Events channel
+		EventSize int
+		//DataSize buffer size of Data channel and data channel of each
+		//connection
+		DataSize int
+	}
+
+	//ShardEvent lifecycle event of the Shard-th connection
+	ShardEvent struct {
+		Shard int
+		Event
+	}
+
+	//Pool spread channels returned by PoolGen over multiple connections
+	//which are created by PoolGen.NewConn and kept by Keeper. each connection
+	//push notify to its own data channel and the Pool merge them into Data.
+	//
+	//new channels are added to connections with free capacity and new
+	//connections are created if needed. once a connection reconnect its
+	//channels are moved to other connections if they have enough capacity
+	Pool struct {
+		gen    PoolGen
+		cfg    PoolConfig
+		done   chan struct{}
+		close  chan struct{}
+		once   sync.Once
+		ech    chan error
+		events chan ShardEvent
+		data   chan interface{}
+
+		mu     sync.Mutex
+		seq    int
+		shards []*shard
+		wg     sync.WaitGroup
+	}
+
+	//shard implement Gen for the Keeper of one connection
+	shard struct {
+		pool       *Pool
+		id         int
+		keeper     *Keeper
+		data       chan interface{}
+		channels   []exchange.Channel
+		notify     chan struct{}
+		subscribed bool
+	}
+)
+
+//NewPool create Pool with gen and cfg
+func NewPool(gen PoolGen, cfg *PoolConfig) *Pool {
+	c := *cfg
+	if c.EventSize <= 0 {
+		c.EventSize = 16
+	}
+	if c.DataSize <= 0 {
+		c.DataSize = 64
+	}
+	return &Pool{
+		gen:    gen,
+		cfg:    c,
+		done:   make(chan struct{}),
+		close:  make(chan struct{}),
+		ech:    make(chan error, 1),
+		events: make(chan ShardEvent, c.EventSize),
+		data:   make(chan interface{}, c.DataSize),
+	}
+}
+
+//Loop fetch channels from PoolGen and keep connections until ctx is done or
+//Close is called. Events and Data are closed when Loop return
+func (p *Pool) Loop(ctx context.Context) {
+	defer close(p.done)
+	defer close(p.events)
+	defer close(p.data)
+	defer p.stop()
+
+	var channels []exchange.Channel
+	attempt := 0
+	for {
+		chs, notify, err := p.gen.Channels(ctx, channels)
+		if err != nil {
+			attempt++
+			p.pushError(err)
+			if !p.sleep(ctx, attempt) {
+				return
+			}
+			continue
+		}
+		attempt = 0
+		channels = chs
+		p.assign(ctx, chs)
+
+		select {
+		case <-notify:
+		case <-p.close:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+//Close stop all connections, it is safe to call Close multiple times
+func (p *Pool) Close() {
+	p.once.Do(func() {
+		close(p.close)
+	})
+}
+
+func (p *Pool) Done() chan struct{} {
+	return p.done
+}
+
+//Data return notify merged from all connections
+func (p *Pool) Data() <-chan interface{} {
+	return p.data
+}
+
+//ECh push errors of PoolGen and connections
+func (p *Pool) ECh() chan error {
+	return p.ech
+}
+
+//Events return lifecycle events of all connections
+func (p *Pool) Events() <-chan ShardEvent {
+	return p.events
+}
+
+//Shards return channels of each connection
+func (p *Pool) Shards() [][]exchange.Channel {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ret := make([][]exchange.Channel, len(p.shards))
+	for i, s := range p.shards {
+		ret[i] = append([]exchange.Channel(nil), s.channels...)
+	}
+	return ret
+}
+
+func (s *shard) NewConn(ctx context.Context) (Conn, error) {
+	return s.pool.gen.NewConn(ctx, s.data)
+}
+
+func (s *shard) Channels(ctx context.Context, old []exchange.Channel) ([]exchange.Channel, chan struct{}, error) {
+	p := s.pool
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if old == nil && s.subscribed {
+		p.compact(s)
+	}
+	s.subscribed = true
+	return append([]exchange.Channel(nil), s.channels...), s.notify, nil
+}
+
+//assign remove channels which are not in channels from shards and add the
+//new channels to shards with free capacity
+func (p *Pool) assign(ctx context.Context, channels []exchange.Channel) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	want := make(map[string]exchange.Channel, len(channels))
+	for _, c := range channels {
+		want[c.String()] = c
+	}
+
+	var shards []*shard
+	for _, s := range p.shards {
+		var kept []exchange.Channel
+		for _, c := range s.channels {
+			if _, ok := want[c.String()]; ok {
+				kept = append(kept, c)
+				delete(want, c.String())
+			}
+		}
+		changed := len(kept) != len(s.channels)
+		s.channels = kept
+		if len(kept) == 0 {
+			s.keeper.Close()
+			continue
+		}
+		if changed {
+			s.update()
+		}
+		shards = append(shards, s)
+	}
+	p.shards = shards
+
+	for _, c := range channels {
+		if _, ok := want[c.String()]; !ok {
+			continue
+		}
+		s := p.free()
+		if s == nil {
+			s = p.newShard(ctx)
+		}
+		s.channels = append(s.channels, c)
+		s.update()
+	}
+}
+
+//compact move channels of reconnected s to other shards if they have enough
+//free capacity, s is closed after its channels are moved
+func (p *Pool) compact(s *shard) {
+	if p.cfg.MaxChannels <= 0 || len(p.shards) < 2 {
+		return
+	}
+	free := 0
+	for _, o := range p.shards {
+		if o != s {
+			free += p.cfg.MaxChannels - len(o.channels)
+		}
+	}
+	if free < len(s.channels) {
+		return
+	}
+
+	channels := s.channels
+	s.channels = nil
+	p.remove(s)
+	for _, c := range channels {
+		o := p.free()
+		o.channels = append(o.channels, c)
+		o.update()
+	}
+	s.keeper.Close()
+}
+
+//free return the shard with free capacity, nil is returned if all shards are full
+func (p *Pool) free() *shard {
+	for _, s := range p.shards {
+		if p.cfg.MaxChannels <= 0 || len(s.channels) < p.cfg.MaxChannels {
+			return s
+		}
+	}
+	return nil
+}
+
+func (p *Pool) newShard(ctx context.Context) *shard {
+	s := &shard{
+		pool:   p,
+		id:     p.seq,
+		notify: make(chan struct{}, 1),
+		data:   make(chan interface{}, p.cfg.DataSize),
+	}
+	p.seq++
+	s.keeper = NewKeeperWithConfig(s, &p.cfg.Keeper)
+	p.shards = append(p.shards, s)
+
+	p.wg.Add(3)
+	go func() {
+		defer p.wg.Done()
+		s.keeper.Loop(ctx)
+	}()
+	go func() {
+		defer p.wg.Done()
+		for ev := range s.keeper.Events() {
+			select {
+			case p.events <- ShardEvent{Shard: s.id, Event: ev}:
+			default:
+			}
+		}
+	}()
+	go func() {
+		defer p.wg.Done()
+		for {
+			select {
+			case d := <-s.data:
+				p.forward(ctx, d)
+			case err := <-s.keeper.ECh():
+				p.pushError(err)
+			case <-s.keeper.Done():
+				//forward notify which are received before the connection closed
+				for {
+					select {
+					case d := <-s.data:
+						p.forward(ctx, d)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return s
+}
+
+//forward push d to Data until the pool is closed
+func (p *Pool) forward(ctx context.Context, d interface{}) {
+	select {
+	case p.data <- d:
+	case <-p.close:
+	case <-ctx.Done():
+	}
+}
+
+func (p *Pool) remove(s *shard) {
+	for i, o := range p.shards {
+		if o == s {
+			p.shards = append(p.shards[:i], p.shards[i+1:]...)
+			return
+		}
+	}
+}
+
+//stop close all connections and wait their Keeper and forwarding done
+func (p *Pool) stop() {
+	p.mu.Lock()
+	for _, s := range p.shards {
+		s.keeper.Close()
+	}
+	p.shards = nil
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+//sleep wait backoff delay of attempt, false is returned if the pool is closed
+func (p *Pool) sleep(ctx context.Context, attempt int) bool {
+	b := p.cfg.Keeper.Backoff
+	if b == (Backoff{}) {
+		b = DefaultBackoff
+	}
+	return wait(ctx, p.close, b.Delay(attempt))
+}
+
+func (p *Pool) pushError(err error) {
+	select {
+	case p.ech <- err:
+	default:
+	}
+}
+
+//update notify the Keeper to fetch channels again
+func (s *shard) update() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
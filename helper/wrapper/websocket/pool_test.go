@@ -0,0 +1,123 @@
+package websocket
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/exchange/binance/swap"
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+)
+
+type (
+	testPoolGen struct {
+		*testDiffGen
+	}
+)
+
+func (tg *testPoolGen) NewConn(ctx context.Context, data chan interface{}) (Conn, error) {
+	conn := binance.NewNotifyClient(tg.addr, swap.NewCodeC(), data, nil)
+	if err := conn.Run(ctx); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func TestPool(t *testing.T) {
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Field("method", binance.MethodSubscibe), func(m *wstest.Message) []interface{} {
+				var req binance.SubscribeRequest
+				params := []string{}
+				req.Params = &params
+				m.Decode(&req)
+				ret := []interface{}{ack(m)}
+				for _, p := range params {
+					ret = append(ret, map[string]interface{}{
+						"e": "bookTicker", "u": 1, "E": 1568014460893, "T": 1568014460891,
+						"s": strings.ToUpper(strings.TrimSuffix(p, "@bookTicker")),
+						"b": "25.35", "B": "31.21", "a": "25.36", "A": "40.66",
+					})
+				}
+				return ret
+			}),
+			wstest.On(wstest.Has("method"), func(m *wstest.Message) []interface{} {
+				return []interface{}{ack(m)}
+			}),
+		)
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	gen := &testDiffGen{
+		testGen: testGen{addr: srv.URL},
+		symbols: []string{"BTCUSDT", "ETHUSDT", "LTCUSDT"},
+		notify:  make(chan struct{}),
+	}
+	pool := NewPool(&testPoolGen{gen}, &PoolConfig{MaxChannels: 2, Keeper: KeeperConfig{BatchSize: 2}})
+	go pool.Loop(ctx)
+	data := pool.Data()
+
+	//notify of all connections are merged
+	symbols := map[string]bool{}
+	for len(symbols) != 3 {
+		select {
+		case d := <-data:
+			symbols[d.(*exchange.WSNotify).Data.(*swap.BookTickerNotify).Symbol] = true
+		case <-ctx.Done():
+			t.Fatalf("wait notify timeout %v", symbols)
+		}
+	}
+	if shards := pool.Shards(); len(shards) != 2 || len(shards[0]) != 2 || len(shards[1]) != 1 {
+		t.Errorf("bad shards %v", shards)
+	}
+
+	gen.update("ETHUSDT", "LTCUSDT")
+	waitShards := func(expect ...int) {
+		for {
+			shards := pool.Shards()
+			ok := len(shards) == len(expect)
+			for i := 0; ok && i < len(shards); i++ {
+				ok = len(shards[i]) == expect[i]
+			}
+			if ok {
+				return
+			}
+			select {
+			case <-time.After(time.Millisecond * 10):
+			case <-ctx.Done():
+				t.Fatalf("wait shards %v timeout got %v", expect, shards)
+			}
+		}
+	}
+	waitShards(1, 1)
+
+	//connections are compacted after reconnect
+	for len(data) != 0 {
+		<-data
+	}
+	srv.Drop()
+	symbols = map[string]bool{}
+	for len(symbols) != 2 {
+		select {
+		case d := <-data:
+			symbols[d.(*exchange.WSNotify).Data.(*swap.BookTickerNotify).Symbol] = true
+		case <-ctx.Done():
+			t.Fatalf("wait notify after reconnect timeout %v", symbols)
+		}
+	}
+	waitShards(2)
+
+	pool.Close()
+	select {
+	case <-pool.Done():
+	case <-ctx.Done():
+		t.Fatalf("wait pool done timeout")
+	}
+	for range data {
+	}
+}
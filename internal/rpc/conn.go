@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/NadiaSama/ccexgo/misc/metrics"
 	"github.com/pkg/errors"
 )
 
@@ -32,11 +34,15 @@ type (
 
 	connection struct {
 		stream    Stream
+		heartbeat *Heartbeat
 		pending   map[string]chan *rpcCall
 		done      chan struct{}
 		err       atomic.Value
+		failOnce  sync.Once
 		streamMu  sync.Mutex
 		pendingMu sync.Mutex
+		//active unix nano time of the last message
+		active int64
 	}
 
 	rpcCall struct {
@@ -50,16 +56,22 @@ var (
 )
 
 func NewConn(stream Stream) Conn {
+	return NewConnWithHeartbeat(stream, nil)
+}
+
+//NewConnWithHeartbeat create Conn which keep the stream alive with hb. the
+//heartbeat is disabled if hb is nil
+func NewConnWithHeartbeat(stream Stream, hb *Heartbeat) Conn {
 	return &connection{
-		stream:  stream,
-		pending: make(map[string]chan *rpcCall),
-		done:    make(chan struct{}),
+		stream:    stream,
+		heartbeat: hb,
+		pending:   make(map[string]chan *rpcCall),
+		done:      make(chan struct{}),
 	}
 }
 
 //Call build param and store result in dest field. if dest is nil only write the param into stream
-func (c *connection) Call(ctx context.Context, id string, method string, params interface{}, dest interface{}) error {
-	var err error
+func (c *connection) Call(ctx context.Context, id string, method string, params interface{}, dest interface{}) (err error) {
 	var rchan chan *rpcCall
 	call := NewCall(id, method, params)
 
@@ -71,9 +83,20 @@ func (c *connection) Call(ctx context.Context, id string, method string, params
 
 		defer func() {
 			c.pendingMu.Lock()
-			delete(c.pending, call.id)
+			if c.pending[call.id] == rchan {
+				delete(c.pending, call.id)
+			}
 			c.pendingMu.Unlock()
 		}()
+
+		start := time.Now()
+		defer func() {
+			status := "ok"
+			if err != nil {
+				status = "error"
+			}
+			metrics.ObserveSince(metrics.Default(), metrics.RPCCallSeconds, start, "method", method, "status", status)
+		}()
 	}
 
 	if err = c.write(call); err != nil {
@@ -134,12 +157,22 @@ func (c *connection) write(call *Call) error {
 	return c.stream.Write(call)
 }
 
-//save err value and close stream
+//save the first err value and close stream
 func (c *connection) fail(err error) {
-	c.err.Store(err)
+	c.failOnce.Do(func() {
+		c.err.Store(err)
+	})
 	c.stream.Close()
 }
 
+func (c *connection) touch() {
+	atomic.StoreInt64(&c.active, time.Now().UnixNano())
+}
+
+func (c *connection) lastActive() int64 {
+	return atomic.LoadInt64(&c.active)
+}
+
 //close all pending rpcCall channel
 func (c *connection) clear() {
 	c.pendingMu.Lock()
@@ -162,22 +195,40 @@ func (c *connection) handleMessages(ctx context.Context, handler Handler) {
 		}
 	}()
 
+	c.touch()
+	if c.heartbeat != nil {
+		if fs, ok := c.stream.(FrameStream); ok {
+			fs.SetPongHandler(c.touch)
+		}
+		go c.keepalive()
+	}
+
 	for {
 		response, err := c.stream.Read()
+		c.touch()
 		if err != nil {
 			if errors.Is(err, &StreamError{}) {
 				c.fail(err)
 				return
 			}
+			if errors.Is(err, &MsgError{}) {
+				metrics.Default().Add(metrics.RPCDecodeErrors, 1)
+			}
 		}
 		switch msg := response.(type) {
 		case *Result:
+			//only the first result is delivered, a duplicate id must not
+			//block the read loop
 			c.pendingMu.Lock()
 			rchan, ok := c.pending[msg.ID]
+			delete(c.pending, msg.ID)
 			c.pendingMu.Unlock()
 			if ok {
-				rchan <- &rpcCall{
+				select {
+				case rchan <- &rpcCall{
 					result: msg,
+				}:
+				default:
 				}
 			}
 			break
@@ -144,3 +144,53 @@ func TestClose(t *testing.T) {
 		t.Fatalf("context close timeout")
 	}
 }
+
+type (
+	testStreamD struct {
+		resp chan Response
+		dup  int
+	}
+)
+
+func (tsd *testStreamD) Read() (Response, error) {
+	r, ok := <-tsd.resp
+	if !ok {
+		return nil, NewStreamError(errors.New("closed"))
+	}
+	return r, nil
+}
+
+func (tsd *testStreamD) Write(req Request) error {
+	call := req.(*Call)
+	for i := 0; i < tsd.dup; i++ {
+		tsd.resp <- &Result{ID: call.id, Result: json.RawMessage("[" + call.id + "]")}
+	}
+	return nil
+}
+
+func (tsd *testStreamD) Close() error {
+	return nil
+}
+
+func TestCallDuplicateResult(t *testing.T) {
+	stream := &testStreamD{
+		resp: make(chan Response, 8),
+		dup:  3,
+	}
+
+	conn := NewConn(stream)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn.Run(ctx, nil)
+	defer conn.Close()
+
+	for i := 1; i < 3; i++ {
+		var arr []int
+		if err := conn.Call(ctx, strconv.Itoa(i), "", nil, &arr); err != nil {
+			t.Fatalf("call fail %s", err.Error())
+		}
+		if arr[0] != i {
+			t.Errorf("bad value %v", arr)
+		}
+	}
+}
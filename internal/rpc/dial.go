@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+type (
+	//DialConfig config how websocket stream is dialed and how frames are
+	//read and written, zero fields use the gorilla websocket default
+	DialConfig struct {
+		//Header is sent with the handshake request
+		Header http.Header
+		//Proxy return proxy url of the request, http.ProxyFromEnvironment
+		//is used if nil
+		Proxy func(*http.Request) (*url.URL, error)
+		//TLSConfig of wss connection
+		TLSConfig *tls.Config
+		//HandshakeTimeout of the dial, default 45s
+		HandshakeTimeout time.Duration
+		//EnableCompression negotiate permessage-deflate with server
+		EnableCompression bool
+		//ReadLimit max size of a message read from server, the stream fail
+		//with StreamError if exceeded. no limit if zero
+		ReadLimit int64
+		//WriteTimeout deadline of each write, no deadline if zero
+		WriteTimeout time.Duration
+	}
+
+	//DialOption config DialConfig
+	DialOption func(*DialConfig)
+)
+
+const (
+	defaultHandshakeTimeout = time.Second * 45
+	defaultControlTimeout   = time.Second * 10
+)
+
+//WithHeader add header to the handshake request
+func WithHeader(header http.Header) DialOption {
+	return func(c *DialConfig) {
+		if c.Header == nil {
+			c.Header = make(http.Header)
+		}
+		for k, v := range header {
+			c.Header[k] = append(c.Header[k], v...)
+		}
+	}
+}
+
+//WithProxy dial via proxy returned by fn
+func WithProxy(fn func(*http.Request) (*url.URL, error)) DialOption {
+	return func(c *DialConfig) {
+		c.Proxy = fn
+	}
+}
+
+//WithProxyURL dial via proxy u such as http://127.0.0.1:1080
+func WithProxyURL(u *url.URL) DialOption {
+	return WithProxy(http.ProxyURL(u))
+}
+
+//WithTLSConfig use cfg for wss connection
+func WithTLSConfig(cfg *tls.Config) DialOption {
+	return func(c *DialConfig) {
+		c.TLSConfig = cfg
+	}
+}
+
+//WithHandshakeTimeout set timeout of the websocket handshake
+func WithHandshakeTimeout(d time.Duration) DialOption {
+	return func(c *DialConfig) {
+		c.HandshakeTimeout = d
+	}
+}
+
+//WithCompression enable permessage-deflate compression
+func WithCompression(enable bool) DialOption {
+	return func(c *DialConfig) {
+		c.EnableCompression = enable
+	}
+}
+
+//WithReadLimit set max size of message read from server
+func WithReadLimit(limit int64) DialOption {
+	return func(c *DialConfig) {
+		c.ReadLimit = limit
+	}
+}
+
+//WithWriteTimeout set deadline of each write
+func WithWriteTimeout(d time.Duration) DialOption {
+	return func(c *DialConfig) {
+		c.WriteTimeout = d
+	}
+}
+
+//NewDialConfig return DialConfig with opts applied
+func NewDialConfig(opts ...DialOption) *DialConfig {
+	ret := &DialConfig{}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return ret
+}
+
+func (c *DialConfig) dialer() *websocket.Dialer {
+	ret := &websocket.Dialer{
+		Proxy:             c.Proxy,
+		TLSClientConfig:   c.TLSConfig,
+		HandshakeTimeout:  c.HandshakeTimeout,
+		EnableCompression: c.EnableCompression,
+	}
+	if ret.Proxy == nil {
+		ret.Proxy = http.ProxyFromEnvironment
+	}
+	if ret.HandshakeTimeout == 0 {
+		ret.HandshakeTimeout = defaultHandshakeTimeout
+	}
+	return ret
+}
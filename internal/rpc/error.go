@@ -46,6 +46,10 @@ func (ce *StreamError) Is(err error) bool {
 	return ok
 }
 
+func (ce *StreamError) Unwrap() error {
+	return ce.Err
+}
+
 func (ce *StreamError) Error() string {
 	return fmt.Sprintf("stream error: %s", ce.Err.Error())
 }
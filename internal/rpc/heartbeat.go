@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	//Heartbeat keep connection alive and detect dead connection
+	Heartbeat struct {
+		//Interval between two ping, no ping is sent if Interval or Ping is zero
+		Interval time.Duration
+		Ping     Ping
+		//IdleTimeout fail the connection with StreamError if no message or
+		//pong frame is received within IdleTimeout, disabled if zero
+		IdleTimeout time.Duration
+	}
+
+	//Ping send a heartbeat message through stream. the stream write lock is
+	//held while Ping is called
+	Ping func(s Stream) error
+
+	//FrameStream is implemented by stream which can write websocket frame
+	//directly such as the websocket stream
+	FrameStream interface {
+		Stream
+		//WritePing send a ping control frame
+		WritePing() error
+		//WriteText send data as text frame without encoding
+		WriteText(data []byte) error
+		//SetPongHandler set h which is called when pong frame is received
+		SetPongHandler(h func())
+	}
+)
+
+var (
+	//ErrIdleTimeout no message is received within Heartbeat.IdleTimeout
+	ErrIdleTimeout = errors.New("idle timeout")
+	//ErrFrameUnsupport the stream do not implement FrameStream
+	ErrFrameUnsupport = errors.New("stream do not support websocket frame")
+)
+
+//PingControl send websocket ping control frame, the pong frame reply by
+//server is counted as activity
+func PingControl(s Stream) error {
+	fs, ok := s.(FrameStream)
+	if !ok {
+		return ErrFrameUnsupport
+	}
+	return fs.WritePing()
+}
+
+//PingText return Ping which send text as is, such as "ping" of okex
+func PingText(text string) Ping {
+	return func(s Stream) error {
+		fs, ok := s.(FrameStream)
+		if !ok {
+			return ErrFrameUnsupport
+		}
+		return fs.WriteText([]byte(text))
+	}
+}
+
+//PingCall return Ping which send a request encoded by the codec. reply of
+//the request is ignored if no Call is waiting for id
+func PingCall(id string, method string, params interface{}) Ping {
+	return func(s Stream) error {
+		return s.Write(NewCall(id, method, params))
+	}
+}
+
+//keepalive send ping and check idle timeout until the connection is done
+func (c *connection) keepalive() {
+	hb := c.heartbeat
+	var pingC, idleC <-chan time.Time
+	if hb.Interval > 0 && hb.Ping != nil {
+		ticker := time.NewTicker(hb.Interval)
+		defer ticker.Stop()
+		pingC = ticker.C
+	}
+
+	var timer *time.Timer
+	if hb.IdleTimeout > 0 {
+		timer = time.NewTimer(hb.IdleTimeout)
+		defer timer.Stop()
+		idleC = timer.C
+	}
+
+	for {
+		select {
+		case <-c.done:
+			return
+
+		case <-pingC:
+			c.streamMu.Lock()
+			err := hb.Ping(c.stream)
+			c.streamMu.Unlock()
+			if err != nil && errors.Is(err, &StreamError{}) {
+				c.fail(err)
+				return
+			}
+
+		case <-idleC:
+			idle := time.Since(time.Unix(0, c.lastActive()))
+			if idle >= hb.IdleTimeout {
+				c.fail(NewStreamError(errors.WithMessagef(ErrIdleTimeout, "no message in %s", idle)))
+				return
+			}
+			timer.Reset(hb.IdleTimeout - idle)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+)
+
+func TestHeartbeat(t *testing.T) {
+	srv := wstest.NewServer(nil)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	dial := func(hb *Heartbeat) (Conn, *wstest.Conn) {
+		stream, err := NewWebsocketStream(srv.URL, testCodec{})
+		if err != nil {
+			t.Fatalf("create stream fail %s", err.Error())
+		}
+		conn := NewConnWithHeartbeat(stream, hb)
+		conn.Run(ctx, make(testHandler, 16))
+		sc, err := srv.Accept(ctx)
+		if err != nil {
+			t.Fatalf("accept fail %s", err.Error())
+		}
+		return conn, sc
+	}
+
+	//pong of control frame keep the quiet connection alive
+	conn, _ := dial(&Heartbeat{Interval: time.Millisecond * 20, Ping: PingControl, IdleTimeout: time.Millisecond * 100})
+	select {
+	case <-conn.Done():
+		t.Fatalf("connection fail %v", conn.Error())
+	case <-time.After(time.Millisecond * 300):
+	}
+	conn.Close()
+
+	conn, sc := dial(&Heartbeat{Interval: time.Millisecond * 20, Ping: PingText("ping"), IdleTimeout: time.Millisecond * 100})
+	msg, err := sc.Read(ctx)
+	if err != nil || string(msg.Data) != "ping" {
+		t.Fatalf("bad ping %v %v", msg, err)
+	}
+	select {
+	case <-conn.Done():
+	case <-ctx.Done():
+		t.Fatalf("wait idle timeout fail")
+	}
+	if err := conn.Error(); !errors.Is(err, &StreamError{}) || !errors.Is(err, ErrIdleTimeout) {
+		t.Errorf("expect idle timeout got %v", err)
+	}
+}
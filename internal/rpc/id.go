@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+type (
+	//IDGen generate increasing numeric request id so replies of concurrent
+	//calls do not clash. the zero value is ready to use
+	IDGen struct {
+		seq int64
+	}
+)
+
+var (
+	defaultIDGen IDGen
+)
+
+//Next return the next id which start from "1"
+func (g *IDGen) Next() string {
+	return strconv.FormatInt(atomic.AddInt64(&g.seq, 1), 10)
+}
+
+//NextID return id of the process wide IDGen, it is used by adapters whose
+//request id is chosen by client
+func NextID() string {
+	return defaultIDGen.Next()
+}
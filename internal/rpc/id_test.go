@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestIDGen(t *testing.T) {
+	var (
+		gen IDGen
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+	)
+	ids := make(map[string]bool)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				id := gen.Next()
+				mu.Lock()
+				ids[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(ids) != 800 || !ids["1"] || !ids["800"] {
+		t.Errorf("bad ids count=%d", len(ids))
+	}
+}
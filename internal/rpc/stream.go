@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"time"
+
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 )
@@ -20,21 +22,28 @@ type (
 	}
 
 	websocketStream struct {
-		conn  *websocket.Conn
-		codec Codec
+		conn         *websocket.Conn
+		codec        Codec
+		writeTimeout time.Duration
 	}
 )
 
-//NewWebsocketStream create a new websocket stream with specific codec
-func NewWebsocketStream(addr string, codec Codec) (Stream, error) {
-	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+//NewWebsocketStream create a new websocket stream with specific codec, the
+//stream is dialed with opts
+func NewWebsocketStream(addr string, codec Codec, opts ...DialOption) (Stream, error) {
+	cfg := NewDialConfig(opts...)
+	conn, _, err := cfg.dialer().Dial(addr, cfg.Header)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "websocket conn create fail")
 	}
+	if cfg.ReadLimit > 0 {
+		conn.SetReadLimit(cfg.ReadLimit)
+	}
 
 	return &websocketStream{
-		conn:  conn,
-		codec: codec,
+		conn:         conn,
+		codec:        codec,
+		writeTimeout: cfg.WriteTimeout,
 	}, nil
 }
 
@@ -62,12 +71,39 @@ func (ws *websocketStream) Write(req Request) error {
 		}
 		return NewMsgError(msg, err)
 	}
-	if err := ws.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+	return ws.WriteText(msg)
+}
+
+func (ws *websocketStream) WritePing() error {
+	timeout := ws.writeTimeout
+	if timeout == 0 {
+		timeout = defaultControlTimeout
+	}
+	if err := ws.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(timeout)); err != nil {
+		return NewStreamError(err)
+	}
+	return nil
+}
+
+func (ws *websocketStream) WriteText(data []byte) error {
+	if ws.writeTimeout > 0 {
+		if err := ws.conn.SetWriteDeadline(time.Now().Add(ws.writeTimeout)); err != nil {
+			return NewStreamError(err)
+		}
+	}
+	if err := ws.conn.WriteMessage(websocket.TextMessage, data); err != nil {
 		return NewStreamError(err)
 	}
 	return nil
 }
 
+func (ws *websocketStream) SetPongHandler(h func()) {
+	ws.conn.SetPongHandler(func(string) error {
+		h()
+		return nil
+	})
+}
+
 func (ws *websocketStream) Close() error {
 	return ws.conn.Close()
 }
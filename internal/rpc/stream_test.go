@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+)
+
+type (
+	testCodec struct{}
+
+	testFrame struct {
+		ID     string          `json:"id,omitempty"`
+		Method string          `json:"method,omitempty"`
+		Params interface{}     `json:"params,omitempty"`
+		Result json.RawMessage `json:"result,omitempty"`
+	}
+
+	testHandler chan *Notify
+)
+
+func (testCodec) Encode(req Request) ([]byte, error) {
+	return json.Marshal(&testFrame{ID: req.ID(), Method: req.Method(), Params: req.Params()})
+}
+
+func (testCodec) Decode(raw []byte) (Response, error) {
+	var f testFrame
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, err
+	}
+	if f.ID != "" {
+		return &Result{ID: f.ID, Result: f.Result}, nil
+	}
+	return &Notify{Method: f.Method, Params: f.Params}, nil
+}
+
+func (th testHandler) Handle(ctx context.Context, n *Notify) {
+	th <- n
+}
+
+func TestWebsocketStream(t *testing.T) {
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Field("method", "sub"), func(m *wstest.Message) []interface{} {
+				return []interface{}{
+					map[string]interface{}{"id": m.Get("id"), "result": true},
+					map[string]interface{}{"method": "trade", "params": "t1"},
+				}
+			}),
+		)
+	})
+	defer srv.Close()
+
+	stream, err := NewWebsocketStream(srv.URL, testCodec{})
+	if err != nil {
+		t.Fatalf("create stream fail %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	handler := make(testHandler, 1)
+	conn := NewConn(stream)
+	conn.Run(ctx, handler)
+
+	var ok bool
+	if err := conn.Call(ctx, "1", "sub", nil, &ok); err != nil || !ok {
+		t.Fatalf("call fail %v %v", err, ok)
+	}
+	select {
+	case n := <-handler:
+		if n.Method != "trade" || n.Params != "t1" {
+			t.Errorf("bad notify %+v", n)
+		}
+	case <-ctx.Done():
+		t.Fatalf("wait notify timeout")
+	}
+
+	srv.Drop()
+	select {
+	case <-conn.Done():
+	case <-ctx.Done():
+		t.Fatalf("wait conn done timeout")
+	}
+	if err := conn.Error(); !errors.Is(err, &StreamError{}) {
+		t.Errorf("expect stream error got %v", err)
+	}
+}
+
+func TestWebsocketStreamDialOptions(t *testing.T) {
+	srv := wstest.NewServer(nil)
+	defer srv.Close()
+
+	header := http.Header{}
+	header.Set("X-Api-Key", "key")
+	stream, err := NewWebsocketStream(srv.URL, testCodec{},
+		WithHeader(header),
+		WithHandshakeTimeout(time.Second),
+		WithCompression(true),
+		WithReadLimit(64),
+		WithWriteTimeout(time.Second),
+	)
+	if err != nil {
+		t.Fatalf("create stream fail %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	sc, err := srv.Accept(ctx)
+	if err != nil {
+		t.Fatalf("accept fail %s", err.Error())
+	}
+	if v := sc.Header().Get("X-Api-Key"); v != "key" {
+		t.Errorf("bad header %s", v)
+	}
+
+	conn := NewConn(stream)
+	conn.Run(ctx, make(testHandler, 1))
+	if err := sc.Write(map[string]interface{}{"method": "trade", "params": strings.Repeat("t", 128)}); err != nil {
+		t.Fatalf("write fail %s", err.Error())
+	}
+	select {
+	case <-conn.Done():
+	case <-ctx.Done():
+		t.Fatalf("wait conn done timeout")
+	}
+	if err := conn.Error(); !errors.Is(err, &StreamError{}) {
+		t.Errorf("expect stream error got %v", err)
+	}
+}
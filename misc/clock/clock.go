@@ -0,0 +1,123 @@
+//Package clock estimate the offset between local clock and exchange server
+//clock so signed requests carry timestamp of the server. a Clock is
+//created with the server time Source of an exchange client, synced
+//periodically by Run and set to the client via SetClock
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ctxlog"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+)
+
+type (
+	//Source return current time of the exchange server
+	Source func(ctx context.Context) (time.Time, error)
+
+	//Clock keep the offset of server clock. a nil *Clock is valid and
+	//return local time
+	Clock struct {
+		source Source
+		mu     sync.RWMutex
+		offset time.Duration
+		rtt    time.Duration
+		synced time.Time
+	}
+)
+
+const (
+	//DefaultInterval between two sync of Run
+	DefaultInterval = time.Minute
+)
+
+//New create Clock which query server time via source, the offset is zero
+//until Sync success
+func New(source Source) *Clock {
+	return &Clock{
+		source: source,
+	}
+}
+
+//Sync query server time once. the server time is assumed to be sampled at
+//the middle of the round trip, so the error of offset is at most rtt/2
+func (c *Clock) Sync(ctx context.Context) error {
+	start := time.Now()
+	st, err := c.source(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "get server time fail")
+	}
+	end := time.Now()
+
+	rtt := end.Sub(start)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rtt = rtt
+	c.offset = st.Sub(start.Add(rtt / 2))
+	c.synced = end
+	return nil
+}
+
+//Run sync every interval until ctx is done, the first sync is done
+//immediately. the last offset is kept if sync fail
+func (c *Clock) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	logger := ctxlog.GetSafeLog(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Sync(ctx); err != nil && ctx.Err() == nil {
+			level.Warn(logger).Log("message", "sync clock fail", "error", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+//Now return current server time estimated with the offset
+func (c *Clock) Now() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return time.Now().Add(c.Offset())
+}
+
+//Offset return server time minus local time, positive offset means the
+//local clock is behind the server
+func (c *Clock) Offset() time.Duration {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offset
+}
+
+//RTT return round trip of the last sync
+func (c *Clock) RTT() time.Duration {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.rtt
+}
+
+//Synced return local time of the last success sync, zero if never synced
+func (c *Clock) Synced() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.synced
+}
@@ -0,0 +1,68 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	var nilClock *Clock
+	if nilClock.Offset() != 0 || time.Since(nilClock.Now()) > time.Second {
+		t.Errorf("nil clock should use local time")
+	}
+
+	fail := false
+	c := New(func(ctx context.Context) (time.Time, error) {
+		if fail {
+			return time.Time{}, errors.New("fail")
+		}
+		time.Sleep(time.Millisecond * 10)
+		return time.Now().Add(-time.Minute), nil
+	})
+	if err := c.Sync(context.Background()); err != nil {
+		t.Fatalf("sync fail %v", err)
+	}
+	if d := c.Offset() + time.Minute; d < -time.Millisecond*20 || d > time.Millisecond*20 {
+		t.Errorf("bad offset %s", c.Offset())
+	}
+	if c.RTT() < time.Millisecond*10 || c.Synced().IsZero() {
+		t.Errorf("bad rtt %s synced %s", c.RTT(), c.Synced())
+	}
+	if d := time.Until(c.Now()) + time.Minute; d < -time.Millisecond*20 || d > time.Millisecond*20 {
+		t.Errorf("bad now %s", c.Now())
+	}
+
+	//offset is kept when sync fail
+	offset := c.Offset()
+	fail = true
+	if err := c.Sync(context.Background()); err == nil {
+		t.Errorf("expect sync fail")
+	}
+	if c.Offset() != offset {
+		t.Errorf("offset changed %s", c.Offset())
+	}
+}
+
+func TestRun(t *testing.T) {
+	synced := make(chan struct{}, 4)
+	c := New(func(ctx context.Context) (time.Time, error) {
+		synced <- struct{}{}
+		return time.Now().Add(time.Second), nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go c.Run(ctx, time.Millisecond*10)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-synced:
+		case <-ctx.Done():
+			t.Fatalf("wait sync timeout")
+		}
+	}
+	if c.Offset() < time.Millisecond*900 {
+		t.Errorf("bad offset %s", c.Offset())
+	}
+}
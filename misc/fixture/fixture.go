@@ -0,0 +1,278 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NadiaSama/ccexgo/misc/request"
+	"github.com/pkg/errors"
+)
+
+type (
+	//Fixture a captured rest request and its response
+	Fixture struct {
+		Method string            `json:"method"`
+		Path   string            `json:"path"`
+		Query  map[string]string `json:"query,omitempty"`
+		Status int               `json:"status"`
+		Header map[string]string `json:"header,omitempty"`
+		//Body response body if it's valid json otherwise Text is used
+		Body json.RawMessage `json:"body,omitempty"`
+		Text string          `json:"text,omitempty"`
+	}
+
+	//Transport replay fixtures as http.RoundTripper. request is matched by
+	//method, path and the query of fixture. each fixture is replayed once
+	//in file name order, the last matched fixture is replayed repeatedly
+	Transport struct {
+		mu       sync.Mutex
+		fixtures []*Fixture
+		used     map[*Fixture]bool
+	}
+)
+
+const (
+	//RecordEnv if the environment variable is set Options record fixtures
+	//from live exchange instead of replaying
+	RecordEnv = "CCEXGO_RECORD"
+)
+
+var (
+	//volatileKeys query keys which change every request and are not recorded
+	volatileKeys = map[string]struct{}{
+		"timestamp": {}, "signature": {}, "recvwindow": {},
+		"accesskeyid": {}, "signaturemethod": {}, "signatureversion": {},
+	}
+	//recordHeaders response headers which are recorded
+	recordHeaders = []string{"Content-Type", "X-Mbx-Used-Weight-1m", "Ratelimit-Remaining", "Retry-After"}
+
+	nameReplacer = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+)
+
+//Options return rest client options which replay fixtures in dir, or record
+//responses from live exchange into dir if RecordEnv is set
+func Options(dir string) ([]request.Option, error) {
+	if os.Getenv(RecordEnv) != "" {
+		return []request.Option{request.WithInterceptors(Recorder(dir))}, nil
+	}
+
+	tr, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	return []request.Option{request.WithTransport(tr)}, nil
+}
+
+//Load read all *.json fixtures in dir
+func Load(dir string) (*Transport, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	ret := &Transport{
+		used: map[*Fixture]bool{},
+	}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, errors.WithMessagef(err, "decode fixture %s fail", f)
+		}
+		ret.fixtures = append(ret.fixtures, &fixture)
+	}
+	return ret, nil
+}
+
+//Add append fixtures to the transport
+func (t *Transport) Add(fixtures ...*Fixture) *Transport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fixtures = append(t.fixtures, fixtures...)
+	return t
+}
+
+//RoundTrip implement http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var last *Fixture
+	for _, f := range t.fixtures {
+		if !f.match(req) {
+			continue
+		}
+		last = f
+		if !t.used[f] {
+			t.used[f] = true
+			return f.response(req), nil
+		}
+	}
+	if last != nil {
+		return last.response(req), nil
+	}
+	return nil, errors.Errorf("no fixture match %s %s", req.Method, req.URL.String())
+}
+
+//NewServer serve the fixtures via httptest server which can be used with
+//request.WithBaseURL. the caller should Close the server
+func (t *Transport) NewServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := t.RoundTrip(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		defer resp.Body.Close()
+
+		for k, v := range resp.Header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}))
+}
+
+//Recorder return interceptor which store each response as a fixture in dir.
+//file name is generated from method, path and a sequence number. the request
+//fail if the fixture can not be written
+func Recorder(dir string) request.Interceptor {
+	var (
+		mu  sync.Mutex
+		seq int
+	)
+	return func(next request.Handler) request.Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil || resp == nil || resp.Body == nil {
+				return resp, err
+			}
+
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			mu.Lock()
+			seq++
+			name := fmt.Sprintf("%03d_%s_%s.json", seq, req.Method, strings.Trim(nameReplacer.ReplaceAllString(req.URL.Path, "_"), "_"))
+			mu.Unlock()
+
+			if err := write(filepath.Join(dir, name), NewFixture(req, resp, body)); err != nil {
+				return nil, errors.WithMessagef(err, "record fixture %s fail", name)
+			}
+			return resp, nil
+		}
+	}
+}
+
+func write(path string, f *Fixture) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+//NewFixture create fixture from request and response. volatile query keys
+//such as timestamp and signature are dropped
+func NewFixture(req *http.Request, resp *http.Response, body []byte) *Fixture {
+	ret := &Fixture{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Status: resp.StatusCode,
+	}
+
+	for k, v := range req.URL.Query() {
+		if _, ok := volatileKeys[strings.ToLower(k)]; ok || len(v) == 0 {
+			continue
+		}
+		if ret.Query == nil {
+			ret.Query = map[string]string{}
+		}
+		ret.Query[k] = v[0]
+	}
+
+	for _, h := range recordHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			if ret.Header == nil {
+				ret.Header = map[string]string{}
+			}
+			ret.Header[h] = v
+		}
+	}
+
+	if json.Valid(body) {
+		ret.Body = json.RawMessage(body)
+	} else {
+		ret.Text = string(body)
+	}
+	return ret
+}
+
+func (f *Fixture) match(req *http.Request) bool {
+	if f.Method != req.Method || f.Path != req.URL.Path {
+		return false
+	}
+
+	query := req.URL.Query()
+	for k, v := range f.Query {
+		if query.Get(k) != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Fixture) response(req *http.Request) *http.Response {
+	body := []byte(f.Text)
+	if len(f.Body) != 0 {
+		body = f.Body
+	}
+
+	header := http.Header{}
+	for k, v := range f.Header {
+		header.Set(k, v)
+	}
+	if header.Get("Content-Type") == "" && len(f.Body) != 0 {
+		header.Set("Content-Type", "application/json")
+	}
+
+	status := f.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
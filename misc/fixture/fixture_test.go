@@ -0,0 +1,114 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+func TestTransport(t *testing.T) {
+	tr := (&Transport{used: map[*Fixture]bool{}}).Add(
+		&Fixture{Method: http.MethodGet, Path: "/order", Query: map[string]string{"id": "1"}, Body: []byte(`{"id":1}`)},
+		&Fixture{Method: http.MethodGet, Path: "/order", Query: map[string]string{"id": "1"}, Body: []byte(`{"id":2}`)},
+		&Fixture{Method: http.MethodDelete, Path: "/order", Status: http.StatusBadRequest, Text: "bad"},
+	)
+	hc := &http.Client{Transport: tr}
+
+	expects := []struct {
+		method string
+		url    string
+		status int
+		body   string
+	}{
+		{http.MethodGet, "https://api.test/order?id=1&timestamp=1", http.StatusOK, `{"id":1}`},
+		{http.MethodGet, "https://api.test/order?id=1&timestamp=2", http.StatusOK, `{"id":2}`},
+		{http.MethodGet, "https://api.test/order?id=1&timestamp=3", http.StatusOK, `{"id":2}`},
+		{http.MethodDelete, "https://api.test/order", http.StatusBadRequest, "bad"},
+	}
+	for i, e := range expects {
+		req, _ := http.NewRequest(e.method, e.url, nil)
+		resp, err := hc.Do(req)
+		if err != nil {
+			t.Fatalf("%d request fail %s", i, err.Error())
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != e.status || string(body) != e.body {
+			t.Errorf("%d bad response %d %s", i, resp.StatusCode, string(body))
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.test/order?id=2", nil)
+	if _, err := hc.Do(req); err == nil {
+		t.Errorf("expect no fixture match")
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fixture")
+	if err != nil {
+		t.Fatalf("create temp dir fail %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	live := (&Transport{used: map[*Fixture]bool{}}).Add(&Fixture{
+		Method: http.MethodGet,
+		Path:   "/api/v3/time",
+		Header: map[string]string{"X-Mbx-Used-Weight-1m": "1"},
+		Body:   []byte(`{"serverTime":1}`),
+	})
+	srv := live.NewServer()
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v3/time?symbol=BTCUSDT&timestamp=1&signature=abc", nil)
+	resp, err := request.Send(srv.Client(), req, Recorder(dir))
+	if err != nil {
+		t.Fatalf("record fail %s", err.Error())
+	}
+	resp.Body.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 || filepath.Base(files[0]) != "001_GET_api_v3_time.json" {
+		t.Fatalf("bad record files %v", files)
+	}
+
+	tr, err := Load(dir)
+	if err != nil {
+		t.Fatalf("load fixture fail %s", err.Error())
+	}
+	f := tr.fixtures[0]
+	var body bytes.Buffer
+	json.Compact(&body, f.Body)
+	if len(f.Query) != 1 || f.Query["symbol"] != "BTCUSDT" || f.Header["X-Mbx-Used-Weight-1m"] != "1" ||
+		body.String() != `{"serverTime":1}` {
+		t.Errorf("bad fixture %+v", f)
+	}
+}
+
+func TestRecordFail(t *testing.T) {
+	f, err := ioutil.TempFile("", "fixture")
+	if err != nil {
+		t.Fatalf("create temp file fail %s", err.Error())
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	live := (&Transport{used: map[*Fixture]bool{}}).Add(&Fixture{
+		Method: http.MethodGet,
+		Path:   "/api/v3/time",
+		Body:   []byte(`{"serverTime":1}`),
+	})
+	srv := live.NewServer()
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v3/time", nil)
+	if _, err := request.Send(srv.Client(), req, Recorder(filepath.Join(f.Name(), "fixture"))); err == nil {
+		t.Errorf("expect record fail")
+	}
+}
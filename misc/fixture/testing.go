@@ -0,0 +1,33 @@
+package fixture
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NadiaSama/ccexgo/misc/request"
+)
+
+const (
+	//Dir default fixture directory of adapter tests
+	Dir = "testdata/fixture"
+)
+
+//TestOptions return Options of dir, the test fail if the fixtures can not
+//be loaded
+func TestOptions(t testing.TB, dir string) []request.Option {
+	t.Helper()
+	opts, err := Options(dir)
+	if err != nil {
+		t.Fatalf("load fixture fail %s", err.Error())
+	}
+	return opts
+}
+
+//MustLoad call load such as LoadSymbols of the client created with
+//TestOptions, the test fail if load return error
+func MustLoad(t testing.TB, load func(context.Context) error) {
+	t.Helper()
+	if err := load(context.Background()); err != nil {
+		t.Fatalf("load symbols fail %s", err.Error())
+	}
+}
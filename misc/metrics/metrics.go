@@ -0,0 +1,228 @@
+//Package metrics define a small metrics interface which is used by rest
+//clients, websocket connections and keepers. the default implementation
+//publish metrics via expvar under the "ccexgo" key, call SetDefault to use
+//other backend or Nop to disable
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	//Metrics record counters and histograms. labels are key value pairs
+	Metrics interface {
+		//Add add delta to the counter name
+		Add(name string, delta int64, labels ...string)
+		//Observe record value into the histogram name, durations are
+		//observed in seconds
+		Observe(name string, value float64, labels ...string)
+	}
+
+	nop struct{}
+
+	//Expvar implement Metrics with expvar
+	Expvar struct {
+		counters   *expvar.Map
+		histograms *expvar.Map
+		buckets    []float64
+		mu         sync.Mutex
+	}
+
+	histogram struct {
+		mu      sync.Mutex
+		bounds  []float64
+		buckets []int64
+		count   int64
+		sum     float64
+	}
+
+	//holder keep Metrics of different types in atomic.Value
+	holder struct {
+		m Metrics
+	}
+)
+
+const (
+	//RESTRequestSeconds rest request latency, labels endpoint template, method,
+	//status
+	RESTRequestSeconds = "rest_request_seconds"
+	//RPCCallSeconds websocket call round trip, labels method, status
+	RPCCallSeconds = "rpc_call_seconds"
+	//RPCDecodeErrors websocket messages which can not be decoded
+	RPCDecodeErrors = "rpc_decode_errors_total"
+	//WSMessages websocket notify received, labels exchange, channel
+	WSMessages = "ws_messages_total"
+	//WSDropped websocket notify dropped, labels exchange, channel
+	WSDropped = "ws_dropped_total"
+	//KeeperReconnects reconnect of Keeper after connection fail
+	KeeperReconnects = "keeper_reconnects_total"
+)
+
+var (
+	//Nop discard all metrics
+	Nop Metrics = nop{}
+
+	//DefaultBuckets upper bounds of histogram buckets in seconds
+	DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+	defaultOnce    sync.Once
+	defaultMetrics atomic.Value
+
+	expvarsMu sync.Mutex
+	expvars   = map[string]*Expvar{}
+)
+
+//Default return the default Metrics, the expvar implementation published
+//as "ccexgo" is created on first use
+func Default() Metrics {
+	defaultOnce.Do(func() {
+		if defaultMetrics.Load() == nil {
+			defaultMetrics.Store(holder{m: NewExpvar("ccexgo")})
+		}
+	})
+	return defaultMetrics.Load().(holder).m
+}
+
+//SetDefault replace the default Metrics
+func SetDefault(m Metrics) {
+	defaultMetrics.Store(holder{m: m})
+}
+
+//ObserveSince observe seconds elapsed since start into histogram name
+func ObserveSince(m Metrics, name string, start time.Time, labels ...string) {
+	m.Observe(name, time.Since(start).Seconds(), labels...)
+}
+
+func (nop) Add(name string, delta int64, labels ...string)       {}
+func (nop) Observe(name string, value float64, labels ...string) {}
+
+//NewExpvar create Expvar published with name which contain "counters" and
+//"histograms" maps. a reused name return the Expvar created before, it panics
+//if name is published by other expvar.Var
+func NewExpvar(name string) *Expvar {
+	expvarsMu.Lock()
+	defer expvarsMu.Unlock()
+	if ret, ok := expvars[name]; ok {
+		return ret
+	}
+
+	ret := &Expvar{
+		counters:   new(expvar.Map).Init(),
+		histograms: new(expvar.Map).Init(),
+		buckets:    DefaultBuckets,
+	}
+	root := expvar.NewMap(name)
+	root.Set("counters", ret.counters)
+	root.Set("histograms", ret.histograms)
+	expvars[name] = ret
+	return ret
+}
+
+//Add implement Metrics
+func (e *Expvar) Add(name string, delta int64, labels ...string) {
+	e.counters.Add(Key(name, labels...), delta)
+}
+
+//Observe implement Metrics
+func (e *Expvar) Observe(name string, value float64, labels ...string) {
+	key := Key(name, labels...)
+	e.mu.Lock()
+	h, ok := e.histograms.Get(key).(*histogram)
+	if !ok {
+		h = &histogram{
+			bounds:  e.buckets,
+			buckets: make([]int64, len(e.buckets)+1),
+		}
+		e.histograms.Set(key, h)
+	}
+	e.mu.Unlock()
+	h.observe(value)
+}
+
+//Counter return value of the counter
+func (e *Expvar) Counter(name string, labels ...string) int64 {
+	if v, ok := e.counters.Get(Key(name, labels...)).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+//Histogram return count and sum of observed values of the histogram
+func (e *Expvar) Histogram(name string, labels ...string) (int64, float64) {
+	h, ok := e.histograms.Get(Key(name, labels...)).(*histogram)
+	if !ok {
+		return 0, 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count, h.sum
+}
+
+//Key return name with labels in form name{k1="v1",k2="v2"}
+func Key(name string, labels ...string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i := 0; i+1 < len(labels); i += 2 {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(labels[i])
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[i+1]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func (h *histogram) observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i := 0
+	for i < len(h.bounds) && v > h.bounds[i] {
+		i++
+	}
+	h.buckets[i]++
+	h.count++
+	h.sum += v
+}
+
+//String implement expvar.Var, buckets are cumulative like prometheus
+func (h *histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"count":`)
+	buf.WriteString(strconv.FormatInt(h.count, 10))
+	buf.WriteString(`,"sum":`)
+	sum, _ := json.Marshal(h.sum)
+	buf.Write(sum)
+	buf.WriteString(`,"buckets":{`)
+	var total int64
+	for i, n := range h.buckets {
+		total += n
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		le := "+Inf"
+		if i < len(h.bounds) {
+			le = strconv.FormatFloat(h.bounds[i], 'g', -1, 64)
+		}
+		buf.WriteString(strconv.Quote(le))
+		buf.WriteByte(':')
+		buf.WriteString(strconv.FormatInt(total, 10))
+	}
+	buf.WriteString("}}")
+	return buf.String()
+}
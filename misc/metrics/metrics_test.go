@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+)
+
+//testName return unique expvar name so tests can be run repeatedly
+func testName(prefix string) string {
+	return fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
+}
+
+func TestExpvar(t *testing.T) {
+	name := testName("ccexgo_test")
+	m := NewExpvar(name)
+	m.Add(WSMessages, 1, "exchange", "binance", "channel", "bookTicker")
+	m.Add(WSMessages, 2, "exchange", "binance", "channel", "bookTicker")
+	m.Observe(RESTRequestSeconds, 0.003, "endpoint", "/api/v3/order", "method", "POST", "status", "200")
+	m.Observe(RESTRequestSeconds, 0.2, "endpoint", "/api/v3/order", "method", "POST", "status", "200")
+
+	if v := m.Counter(WSMessages, "exchange", "binance", "channel", "bookTicker"); v != 3 {
+		t.Errorf("bad counter %d", v)
+	}
+	if count, sum := m.Histogram(RESTRequestSeconds, "endpoint", "/api/v3/order", "method", "POST", "status", "200"); count != 2 || sum != 0.203 {
+		t.Errorf("bad histogram %d %f", count, sum)
+	}
+
+	var published struct {
+		Counters   map[string]int64 `json:"counters"`
+		Histograms map[string]struct {
+			Count   int64            `json:"count"`
+			Buckets map[string]int64 `json:"buckets"`
+		} `json:"histograms"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &published); err != nil {
+		t.Fatalf("decode expvar fail %s", err.Error())
+	}
+	if published.Counters[`ws_messages_total{exchange="binance",channel="bookTicker"}`] != 3 {
+		t.Errorf("bad published counters %v", published.Counters)
+	}
+	h := published.Histograms[`rest_request_seconds{endpoint="/api/v3/order",method="POST",status="200"}`]
+	if h.Count != 2 || h.Buckets["0.001"] != 0 || h.Buckets["0.005"] != 1 || h.Buckets["0.25"] != 2 || h.Buckets["+Inf"] != 2 {
+		t.Errorf("bad published histogram %+v", h)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	m := NewExpvar(testName("ccexgo_default_test"))
+	SetDefault(m)
+	defer SetDefault(Nop)
+
+	Default().Add(KeeperReconnects, 1)
+	if m.Counter(KeeperReconnects) != 1 {
+		t.Errorf("default metrics is not replaced")
+	}
+}
+
+func TestExpvarReuse(t *testing.T) {
+	name := testName("ccexgo_reuse_test")
+	m := NewExpvar(name)
+	m.Add(WSDropped, 1)
+	if m2 := NewExpvar(name); m2 != m || m2.Counter(WSDropped) != 1 {
+		t.Errorf("expvar is not reused")
+	}
+}
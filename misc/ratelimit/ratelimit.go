@@ -0,0 +1,333 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/pkg/errors"
+)
+
+type (
+	//Policy specific the Limiter behaviour when budget is exhausted
+	Policy int
+
+	//Bucket is a fixed window request budget. a bucket can be shared by
+	//multiple Limiter, e.g. per-IP bucket shared by all clients of an
+	//exchange while per-account bucket owned by one client
+	Bucket struct {
+		name     string
+		limit    int
+		interval time.Duration
+		mu       sync.Mutex
+		used     int
+		reset    time.Time
+	}
+
+	//SharedBuckets keep one bucket for each key, e.g. the per-IP bucket of
+	//each api host which is shared by all clients of the host
+	SharedBuckets struct {
+		name     string
+		limit    int
+		interval time.Duration
+		mu       sync.Mutex
+		buckets  map[string]*Bucket
+	}
+
+	//Cost the weight a request consume from bucket
+	Cost struct {
+		Bucket string
+		Weight int
+	}
+
+	//Limiter throttle rest requests of an exchange client. a nil *Limiter
+	//is valid and do not throttle. the Set methods are not concurrent safe
+	//and should be called before the limiter is used
+	Limiter struct {
+		policy        Policy
+		buckets       map[string]*Bucket
+		weights       map[string][]Cost
+		defaults      []Cost
+		usedHeaders   map[string]string
+		remainHeaders map[string]string
+	}
+
+	//ErrLimited is returned by Wait when the request is rejected locally
+	ErrLimited struct {
+		Bucket string
+		Wait   time.Duration
+	}
+)
+
+const (
+	//PolicyBlock wait until the budget is available or ctx is done
+	PolicyBlock Policy = iota
+	//PolicyFailFast return *ErrLimited immediately
+	PolicyFailFast
+)
+
+var (
+	//buckets may be shared by limiters, reserve weight across them with
+	//one lock to avoid partial reservation and lock ordering problem
+	reserveMu sync.Mutex
+	now       = time.Now
+)
+
+//NewBucket create a bucket which allow limit weight every interval
+func NewBucket(name string, limit int, interval time.Duration) *Bucket {
+	return &Bucket{
+		name:     name,
+		limit:    limit,
+		interval: interval,
+	}
+}
+
+//NewSharedBuckets create SharedBuckets whose buckets are created with
+//name, limit and interval
+func NewSharedBuckets(name string, limit int, interval time.Duration) *SharedBuckets {
+	return &SharedBuckets{
+		name:     name,
+		limit:    limit,
+		interval: interval,
+		buckets:  make(map[string]*Bucket),
+	}
+}
+
+//Get return the bucket of key, the bucket is created if not exist
+func (sb *SharedBuckets) Get(key string) *Bucket {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	b, ok := sb.buckets[key]
+	if !ok {
+		b = NewBucket(sb.name, sb.limit, sb.interval)
+		sb.buckets[key] = b
+	}
+	return b
+}
+
+//Name return bucket name
+func (b *Bucket) Name() string {
+	return b.name
+}
+
+//Used return used weight and limit of current window
+func (b *Bucket) Used() (used int, limit int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.roll(now())
+	return b.used, b.limit
+}
+
+func (b *Bucket) roll(t time.Time) {
+	if t.Before(b.reset) {
+		return
+	}
+	b.used = 0
+	b.reset = t.Truncate(b.interval).Add(b.interval)
+}
+
+//available return zero if weight can be consumed or the duration until the
+//next window. b.mu must be held
+func (b *Bucket) available(t time.Time, weight int) time.Duration {
+	b.roll(t)
+	if b.used+weight <= b.limit {
+		return 0
+	}
+	return b.reset.Sub(t)
+}
+
+func (b *Bucket) set(used int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.roll(now())
+	b.used = used
+}
+
+//NewLimiter create limiter with policy and buckets. Cost.Bucket must be one
+//of the bucket names
+func NewLimiter(policy Policy, buckets ...*Bucket) *Limiter {
+	ret := &Limiter{
+		policy:        policy,
+		buckets:       make(map[string]*Bucket, len(buckets)),
+		weights:       map[string][]Cost{},
+		usedHeaders:   map[string]string{},
+		remainHeaders: map[string]string{},
+	}
+	for _, b := range buckets {
+		ret.buckets[b.name] = b
+	}
+	return ret
+}
+
+//SetPolicy change the limiter policy
+func (l *Limiter) SetPolicy(policy Policy) *Limiter {
+	l.policy = policy
+	return l
+}
+
+//SetWeight specific costs of endPoint. method can be empty which match
+//any http method
+func (l *Limiter) SetWeight(method string, endPoint string, costs ...Cost) *Limiter {
+	l.weights[weightKey(method, endPoint)] = costs
+	return l
+}
+
+//SetDefault specific costs of endPoint which is not configured by SetWeight
+func (l *Limiter) SetDefault(costs ...Cost) *Limiter {
+	l.defaults = costs
+	return l
+}
+
+//SetUsedHeader update bucket used weight with response header value
+func (l *Limiter) SetUsedHeader(header string, bucket string) *Limiter {
+	l.usedHeaders[http.CanonicalHeaderKey(header)] = bucket
+	return l
+}
+
+//SetRemainHeader update bucket used weight with limit minus response header value
+func (l *Limiter) SetRemainHeader(header string, bucket string) *Limiter {
+	l.remainHeaders[http.CanonicalHeaderKey(header)] = bucket
+	return l
+}
+
+//Bucket return bucket with name or nil
+func (l *Limiter) Bucket(name string) *Bucket {
+	if l == nil {
+		return nil
+	}
+	return l.buckets[name]
+}
+
+//Wait consume weight of the request from buckets. if the budget is exhausted
+//Wait block until it's available or return *ErrLimited according to policy
+func (l *Limiter) Wait(ctx context.Context, method string, endPoint string) error {
+	if l == nil {
+		return nil
+	}
+
+	costs, ok := l.weights[weightKey(method, endPoint)]
+	if !ok {
+		costs, ok = l.weights[weightKey("", endPoint)]
+	}
+	if !ok {
+		costs = l.defaults
+	}
+	if len(costs) == 0 {
+		return nil
+	}
+
+	weights := map[string]int{}
+	for _, c := range costs {
+		b, ok := l.buckets[c.Bucket]
+		if !ok {
+			return errors.Errorf("unknown rate limit bucket '%s'", c.Bucket)
+		}
+		weights[c.Bucket] += c.Weight
+		if weights[c.Bucket] > b.limit {
+			return errors.Errorf("weight %d of %s exceed bucket '%s' limit %d", weights[c.Bucket], endPoint, b.name, b.limit)
+		}
+	}
+
+	for {
+		bucket, wait := l.reserve(costs)
+		if wait == 0 {
+			return nil
+		}
+
+		if l.policy == PolicyFailFast {
+			return &ErrLimited{Bucket: bucket, Wait: wait}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+//Observe update buckets with response headers
+func (l *Limiter) Observe(header http.Header) {
+	if l == nil {
+		return
+	}
+
+	for h, name := range l.usedHeaders {
+		if v, err := strconv.Atoi(header.Get(h)); err == nil {
+			if b, ok := l.buckets[name]; ok {
+				b.set(v)
+			}
+		}
+	}
+
+	for h, name := range l.remainHeaders {
+		if v, err := strconv.Atoi(header.Get(h)); err == nil {
+			if b, ok := l.buckets[name]; ok {
+				b.set(b.limit - v)
+			}
+		}
+	}
+}
+
+//reserve consume all costs or nothing. the max wait duration and the bucket
+//name are returned if any bucket is exhausted
+func (l *Limiter) reserve(costs []Cost) (string, time.Duration) {
+	reserveMu.Lock()
+	defer reserveMu.Unlock()
+
+	weights := map[*Bucket]int{}
+	for _, c := range costs {
+		weights[l.buckets[c.Bucket]] += c.Weight
+	}
+	for b := range weights {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+	}
+
+	var (
+		t         = now()
+		maxWait   time.Duration
+		exhausted string
+	)
+	for b, w := range weights {
+		if wait := b.available(t, w); wait > maxWait {
+			maxWait = wait
+			exhausted = b.name
+		}
+	}
+	if maxWait > 0 {
+		return exhausted, maxWait
+	}
+
+	for b, w := range weights {
+		b.used += w
+	}
+	return "", 0
+}
+
+func weightKey(method string, endPoint string) string {
+	return fmt.Sprintf("%s %s", method, endPoint)
+}
+
+func (el *ErrLimited) Error() string {
+	return fmt.Sprintf("rate limit bucket '%s' exhausted retry after %s", el.Bucket, el.Wait)
+}
+
+//RetryAfter return the duration until the bucket is available
+func (el *ErrLimited) RetryAfter() time.Duration {
+	return el.Wait
+}
+
+//Is match any *ErrLimited and exchange.ErrRateLimited
+func (el *ErrLimited) Is(target error) bool {
+	if _, ok := target.(*ErrLimited); ok {
+		return true
+	}
+	return target == exchange.ErrRateLimited
+}
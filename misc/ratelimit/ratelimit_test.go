@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
+func TestLimiter(t *testing.T) {
+	cur := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return cur }
+	defer func() { now = time.Now }()
+
+	ip := NewBucket("ip", 10, time.Minute)
+	orders := NewBucket("orders", 2, time.Second)
+	l := NewLimiter(PolicyFailFast, ip, orders).
+		SetDefault(Cost{"ip", 1}).
+		SetWeight(http.MethodPost, "/order", Cost{"ip", 1}, Cost{"orders", 1}).
+		SetWeight("", "/depth", Cost{"ip", 5}).
+		SetUsedHeader("X-Used-Weight", "ip")
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := l.Wait(ctx, http.MethodPost, "/order"); err != nil {
+			t.Fatalf("wait order fail %s", err.Error())
+		}
+	}
+	err := l.Wait(ctx, http.MethodPost, "/order")
+	var el *ErrLimited
+	if !errors.As(err, &el) || el.Bucket != "orders" || el.Wait != time.Second {
+		t.Fatalf("expect orders limited got %v", err)
+	}
+	if !errors.Is(err, exchange.ErrRateLimited) {
+		t.Errorf("ErrLimited should be ErrRateLimited")
+	}
+	//rejected request should not consume other buckets
+	if used, _ := ip.Used(); used != 2 {
+		t.Errorf("bad ip used %d", used)
+	}
+
+	if err := l.Wait(ctx, http.MethodGet, "/depth"); err != nil {
+		t.Fatalf("wait depth fail %s", err.Error())
+	}
+	if used, _ := ip.Used(); used != 7 {
+		t.Errorf("bad ip used %d", used)
+	}
+
+	l.Observe(http.Header{"X-Used-Weight": []string{"9"}})
+	if err := l.Wait(ctx, http.MethodGet, "/depth"); err == nil {
+		t.Errorf("ip weight should be exhausted")
+	}
+	if err := l.Wait(ctx, http.MethodGet, "/ticker"); err != nil {
+		t.Errorf("default weight should be available %s", err.Error())
+	}
+
+	cur = cur.Add(time.Minute)
+	if err := l.Wait(ctx, http.MethodGet, "/depth"); err != nil {
+		t.Errorf("window should be reset %s", err.Error())
+	}
+
+	var nl *Limiter
+	if err := nl.Wait(ctx, http.MethodGet, "/depth"); err != nil {
+		t.Errorf("nil limiter should not limit %s", err.Error())
+	}
+}
+
+func TestLimiterBlock(t *testing.T) {
+	l := NewLimiter(PolicyBlock, NewBucket("ip", 1, time.Millisecond*50)).SetDefault(Cost{"ip", 1})
+	ctx := context.Background()
+
+	if err := l.Wait(ctx, http.MethodGet, "/"); err != nil {
+		t.Fatalf("wait fail %s", err.Error())
+	}
+	if err := l.Wait(ctx, http.MethodGet, "/"); err != nil {
+		t.Fatalf("block wait fail %s", err.Error())
+	}
+
+	l = NewLimiter(PolicyBlock, NewBucket("ip", 1, time.Hour)).SetDefault(Cost{"ip", 1})
+	if err := l.Wait(ctx, http.MethodGet, "/"); err != nil {
+		t.Fatalf("wait fail %s", err.Error())
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.Wait(cctx, http.MethodGet, "/"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expect canceled got %v", err)
+	}
+}
+
+func TestSharedBuckets(t *testing.T) {
+	sb := NewSharedBuckets("weight", 10, time.Minute)
+	main := sb.Get("api.binance.com")
+	if main != sb.Get("api.binance.com") {
+		t.Errorf("bucket of same key should be shared")
+	}
+	test := sb.Get("testnet.binance.vision")
+	if test == main {
+		t.Errorf("bucket of different key should not be shared")
+	}
+	if test.Name() != "weight" {
+		t.Errorf("bad bucket name %s", test.Name())
+	}
+	if _, limit := test.Used(); limit != 10 {
+		t.Errorf("bad bucket limit %d", limit)
+	}
+}
@@ -13,6 +13,8 @@ func init() {
 	client = http.DefaultClient
 }
 
+//SetClient replace the default client which is used by RestClients without
+//their own client
 func SetClient(nc *http.Client) {
 	client = nc
 }
@@ -27,9 +29,15 @@ func Do(ctx context.Context, req *http.Request, f func(*http.Response, error) er
 
 //DoReqWithCtx like Do except the ctx extract from req.Context()
 func DoReqWithCtx(req *http.Request, f func(*http.Response, error) error) error {
+	return DoWithClient(nil, req, f)
+}
+
+//DoWithClient like DoReqWithCtx except the request is send via hc through
+//interceptors. the default client is used if hc is nil
+func DoWithClient(hc *http.Client, req *http.Request, f func(*http.Response, error) error, interceptors ...Interceptor) error {
 	ctx := req.Context()
 	c := make(chan error, 1)
-	go func() { c <- f(client.Do(req)) }()
+	go func() { c <- f(Send(hc, req, interceptors...)) }()
 	select {
 	case <-ctx.Done():
 		<-c
@@ -0,0 +1,30 @@
+package request
+
+import "context"
+
+type (
+	endpointKey struct{}
+)
+
+const (
+	//unknownEndpoint label of request without endpoint template
+	unknownEndpoint = "unknown"
+)
+
+//WithEndpoint return ctx which carry the logical endpoint template of the
+//request such as /api/spot/v3/orders/{order_id}. the template instead of url
+//path which may contain ids is used as metrics label. an existing template
+//of ctx is kept, so the caller which format ids into path set the template
+//before the adapter set the path as default
+func WithEndpoint(ctx context.Context, template string) context.Context {
+	if Endpoint(ctx) != "" {
+		return ctx
+	}
+	return context.WithValue(ctx, endpointKey{}, template)
+}
+
+//Endpoint return the endpoint template of ctx, empty if not set
+func Endpoint(ctx context.Context) string {
+	template, _ := ctx.Value(endpointKey{}).(string)
+	return template
+}
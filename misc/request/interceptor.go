@@ -0,0 +1,183 @@
+package request
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/ctxlog"
+	"github.com/NadiaSama/ccexgo/misc/metrics"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+type (
+	//Handler send the signed http request and return the response
+	Handler func(req *http.Request) (*http.Response, error)
+
+	//Interceptor wrap Handler to run code around every rest request. the
+	//interceptor may short-circuit the request by not calling next
+	Interceptor func(next Handler) Handler
+)
+
+const (
+	redacted = "***"
+)
+
+var (
+	//sensitiveKeys header and query keys which are redacted by Logging
+	sensitiveKeys = []string{
+		"X-MBX-APIKEY", "signature",
+		"OK-ACCESS-KEY", "OK-ACCESS-SIGN", "OK-ACCESS-PASSPHRASE",
+		"AccessKeyId", "Signature",
+		"FTX-KEY", "FTX-SIGN",
+		"Authorization",
+	}
+)
+
+//Chain wrap h with interceptors. the first interceptor is the outermost
+func Chain(h Handler, interceptors ...Interceptor) Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		h = interceptors[i](h)
+	}
+	return h
+}
+
+//Send issue req via hc through interceptors. the default client is used if
+//hc is nil. the latency is recorded by metrics.Default with the endpoint
+//template of req context as label, see WithEndpoint
+func Send(hc *http.Client, req *http.Request, interceptors ...Interceptor) (*http.Response, error) {
+	if hc == nil {
+		hc = client
+	}
+	start := time.Now()
+	resp, err := Chain(hc.Do, interceptors...)(req)
+	status := "error"
+	if resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	endpoint := Endpoint(req.Context())
+	if endpoint == "" {
+		endpoint = unknownEndpoint
+	}
+	metrics.ObserveSince(metrics.Default(), metrics.RESTRequestSeconds, start,
+		"endpoint", endpoint, "method", req.Method, "status", status)
+	return resp, err
+}
+
+//Logging log method, redacted url and headers, status and latency of each
+//request with debug level. the logger bind with request context via ctxlog
+//is used if logger is nil. keys in redact are redacted in addition to api
+//key and signature of supported exchanges
+func Logging(logger log.Logger, redact ...string) Interceptor {
+	keys := map[string]struct{}{}
+	for _, k := range append(append([]string{}, sensitiveKeys...), redact...) {
+		keys[strings.ToLower(k)] = struct{}{}
+	}
+
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			l := logger
+			if l == nil {
+				l = ctxlog.GetSafeLog(req.Context())
+			}
+
+			start := time.Now()
+			resp, err := next(req)
+			kvs := []interface{}{
+				"method", req.Method,
+				"url", redactURL(req.URL, keys),
+				"header", redactHeader(req.Header, keys),
+				"latency", time.Since(start),
+			}
+			if resp != nil {
+				kvs = append(kvs, "status", resp.StatusCode)
+			}
+			if err != nil {
+				kvs = append(kvs, "err", err)
+			}
+			level.Debug(l).Log(kvs...)
+			return resp, err
+		}
+	}
+}
+
+//Latency calls observe with the duration and status code of each request.
+//status is 0 if the request fail without response
+func Latency(observe func(req *http.Request, latency time.Duration, status int, err error)) Interceptor {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			observe(req, time.Since(start), status, err)
+			return resp, err
+		}
+	}
+}
+
+//Capture calls f with the raw response body of each request. the body is
+//restored so the client can still decode it
+func Capture(f func(req *http.Request, resp *http.Response, body []byte)) Interceptor {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil || resp == nil || resp.Body == nil {
+				return resp, err
+			}
+
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			f(req, resp, body)
+			return resp, nil
+		}
+	}
+}
+
+//Fault calls inject before sending each request. if inject return a response
+//or an error the request is not sent and the result is returned instead
+func Fault(inject func(req *http.Request) (*http.Response, error)) Interceptor {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			if resp, err := inject(req); resp != nil || err != nil {
+				return resp, err
+			}
+			return next(req)
+		}
+	}
+}
+
+func redactURL(u *url.URL, keys map[string]struct{}) string {
+	ru := *u
+	values := ru.Query()
+	for k := range values {
+		if _, ok := keys[strings.ToLower(k)]; ok {
+			values.Set(k, redacted)
+		}
+	}
+	ru.RawQuery = values.Encode()
+	return ru.String()
+}
+
+func redactHeader(header http.Header, keys map[string]struct{}) http.Header {
+	ret := http.Header{}
+	for k, v := range header {
+		if _, ok := keys[strings.ToLower(k)]; ok {
+			ret[k] = []string{redacted}
+			continue
+		}
+		ret[k] = v
+	}
+	return ret
+}
@@ -0,0 +1,141 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/misc/metrics"
+	"github.com/go-kit/log"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+	mark := func(name string) Interceptor {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next(req)
+			}
+		}
+	}
+
+	h := Chain(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "handler")
+		return &http.Response{StatusCode: 200}, nil
+	}, mark("a"), mark("b"))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.binance.com/api/v3/time", nil)
+	if _, err := h(req); err != nil {
+		t.Fatalf("handler fail %v", err)
+	}
+	if strings.Join(order, ",") != "a,b,handler" {
+		t.Errorf("bad order %v", order)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	var (
+		buf      bytes.Buffer
+		captured []byte
+		latency  time.Duration
+		status   int
+	)
+	interceptors := []Interceptor{
+		Logging(log.NewLogfmtLogger(&buf), "secret"),
+		Latency(func(req *http.Request, d time.Duration, s int, err error) {
+			latency = d
+			status = s
+		}),
+		Capture(func(req *http.Request, resp *http.Response, body []byte) {
+			captured = body
+		}),
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api?symbol=BTCUSDT&signature=abc&secret=x", nil)
+	req.Header.Set("X-MBX-APIKEY", "key")
+	err := DoWithClient(nil, req, func(resp *http.Response, err error) error {
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if string(body) != `{"ok":true}` {
+			return errors.New("body is not restored")
+		}
+		return nil
+	}, interceptors...)
+	if err != nil {
+		t.Fatalf("request fail %v", err)
+	}
+
+	if string(captured) != `{"ok":true}` {
+		t.Errorf("bad captured body %s", captured)
+	}
+	if status != 200 || latency <= 0 {
+		t.Errorf("bad latency %s status %d", latency, status)
+	}
+	out := buf.String()
+	if strings.Contains(out, "abc") || strings.Contains(out, "key]") || strings.Contains(out, "secret=x") {
+		t.Errorf("secret is not redacted %s", out)
+	}
+	if !strings.Contains(out, "symbol=BTCUSDT") || !strings.Contains(out, "status=200") {
+		t.Errorf("bad log %s", out)
+	}
+
+	injected := errors.New("injected")
+	req, _ = http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+	_, err = Send(nil, req, Fault(func(req *http.Request) (*http.Response, error) {
+		return nil, injected
+	}))
+	if err != injected {
+		t.Errorf("expect injected error got %v", err)
+	}
+}
+
+func TestSendMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	m := metrics.NewExpvar(fmt.Sprintf("ccexgo_request_test_%d", time.Now().UnixNano()))
+	metrics.SetDefault(m)
+	defer metrics.SetDefault(metrics.Nop)
+
+	ctx := WithEndpoint(context.Background(), "/api/v3/orders/{order_id}")
+	//the template set by caller is kept
+	ctx = WithEndpoint(ctx, "/api/v3/orders/1")
+	for _, id := range []string{"1", "2"} {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v3/orders/"+id, nil)
+		if _, err := Send(nil, req); err != nil {
+			t.Fatalf("send fail %s", err.Error())
+		}
+	}
+	if count, _ := m.Histogram(metrics.RESTRequestSeconds, "endpoint", "/api/v3/orders/{order_id}", "method", "GET", "status", "418"); count != 2 {
+		t.Errorf("latency is not recorded")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v3/time", nil)
+	if _, err := Send(nil, req); err != nil {
+		t.Fatalf("send fail %s", err.Error())
+	}
+	if count, _ := m.Histogram(metrics.RESTRequestSeconds, "endpoint", "unknown", "method", "GET", "status", "418"); count != 1 {
+		t.Errorf("request without template should be labeled unknown")
+	}
+}
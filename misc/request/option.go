@@ -0,0 +1,102 @@
+package request
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
+type (
+	//Config per client http settings. zero value use the package default
+	//client and the exchange default base url
+	Config struct {
+		Client       *http.Client
+		BaseURL      string
+		Interceptors []Interceptor
+	}
+
+	//Option configure RestClient http settings
+	Option func(*Config)
+)
+
+//NewConfig apply opts to an empty Config
+func NewConfig(opts ...Option) *Config {
+	ret := &Config{}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return ret
+}
+
+//WithClient send requests via c instead of the package default client. nil
+//c is ignored
+func WithClient(c *http.Client) Option {
+	return func(cfg *Config) {
+		if c != nil {
+			cfg.Client = c
+		}
+	}
+}
+
+//WithTransport send requests via a client with rt as transport. the timeout
+//of the client set by WithClient is kept
+func WithTransport(rt http.RoundTripper) Option {
+	return func(cfg *Config) {
+		if rt == nil {
+			return
+		}
+		c := &http.Client{Transport: rt}
+		if cfg.Client != nil {
+			nc := *cfg.Client
+			nc.Transport = rt
+			c = &nc
+		}
+		cfg.Client = c
+	}
+}
+
+//WithBaseURL override the exchange rest api base url e.g. http://127.0.0.1:8080
+//which is useful to point client at a local test server. empty u is ignored
+func WithBaseURL(u string) Option {
+	return func(cfg *Config) {
+		if u != "" {
+			cfg.BaseURL = strings.TrimSuffix(u, "/")
+		}
+	}
+}
+
+//WithInterceptors append interceptors which run around every request
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(cfg *Config) {
+		cfg.Interceptors = append(cfg.Interceptors, interceptors...)
+	}
+}
+
+//ConfigOptions return options from exchange registry Config
+func ConfigOptions(cfg *exchange.Config) []Option {
+	return []Option{
+		WithClient(cfg.HTTPClient),
+		WithBaseURL(cfg.BaseURL),
+	}
+}
+
+//SchemeHost split BaseURL into scheme and host, defaults are returned if
+//BaseURL is empty. BaseURL without scheme is treated as host
+func (c *Config) SchemeHost(scheme string, host string) (string, string) {
+	if c.BaseURL == "" {
+		return scheme, host
+	}
+	if i := strings.Index(c.BaseURL, "://"); i != -1 {
+		return c.BaseURL[:i], c.BaseURL[i+3:]
+	}
+	return scheme, c.BaseURL
+}
+
+//Prefix return BaseURL or def if BaseURL is empty
+func (c *Config) Prefix(def string) string {
+	if c.BaseURL == "" {
+		return def
+	}
+	return c.BaseURL
+}
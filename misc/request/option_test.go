@@ -0,0 +1,34 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestConfig(t *testing.T) {
+	cfg := NewConfig()
+	if cfg.Client != nil {
+		t.Errorf("expect nil client")
+	}
+	if s, h := cfg.SchemeHost("https", "api.binance.com"); s != "https" || h != "api.binance.com" {
+		t.Errorf("bad default scheme host %s %s", s, h)
+	}
+
+	rt := &http.Transport{}
+	cfg = NewConfig(WithClient(&http.Client{Timeout: time.Second}), WithTransport(rt), WithBaseURL("http://127.0.0.1:8080/"))
+	if cfg.Client.Timeout != time.Second || cfg.Client.Transport != rt {
+		t.Errorf("bad client %v", cfg.Client)
+	}
+	if s, h := cfg.SchemeHost("https", "api.binance.com"); s != "http" || h != "127.0.0.1:8080" {
+		t.Errorf("bad scheme host %s %s", s, h)
+	}
+	if p := cfg.Prefix("https://ftx.com/api"); p != "http://127.0.0.1:8080" {
+		t.Errorf("bad prefix %s", p)
+	}
+
+	cfg = NewConfig(WithBaseURL("localhost:8080"))
+	if s, h := cfg.SchemeHost("https", "api.binance.com"); s != "https" || h != "localhost:8080" {
+		t.Errorf("bad scheme host %s %s", s, h)
+	}
+}
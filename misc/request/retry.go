@@ -0,0 +1,246 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
+type (
+	//RetryPolicy retry transient rest request failures with jittered
+	//exponential backoff. a nil *RetryPolicy is valid and do one attempt
+	RetryPolicy struct {
+		//MaxAttempts total attempts include the first one
+		MaxAttempts int
+		//BaseDelay backoff before the second attempt which is doubled for
+		//each following attempt
+		BaseDelay time.Duration
+		//MaxDelay upper bound of backoff. retry is abandoned if Retry-After
+		//exceed MaxDelay
+		MaxDelay time.Duration
+		//Jitter randomize backoff in [delay*(1-Jitter), delay], range [0, 1]
+		Jitter float64
+		//OrderEntry report whether the request create or modify order. order
+		//entry requests are only retried if it's rejected before processed by
+		//the exchange. default treat all requests except GET, HEAD and OPTIONS
+		//as order entry
+		OrderEntry func(method string, endPoint string) bool
+	}
+
+	//ResponseError wrap the error caused by a http response with status code
+	//and Retry-After header
+	ResponseError struct {
+		Err        error
+		StatusCode int
+		retryAfter time.Duration
+	}
+
+	retryAfter interface {
+		RetryAfter() time.Duration
+	}
+)
+
+var (
+	sleep = defaultSleep
+)
+
+//NewRetryPolicy return policy with 3 attempts and backoff from 200ms to 5s
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+//NewResponseError wrap err with status code and Retry-After header of resp
+func NewResponseError(err error, resp *http.Response) error {
+	return &ResponseError{
+		Err:        err,
+		StatusCode: resp.StatusCode,
+		retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+func (re *ResponseError) Error() string {
+	return re.Err.Error()
+}
+
+func (re *ResponseError) Unwrap() error {
+	return re.Err
+}
+
+//Cause make the wrapped error visible to pkg/errors.Cause
+func (re *ResponseError) Cause() error {
+	return re.Err
+}
+
+//RetryAfter return the duration specific by Retry-After header, zero if absent
+func (re *ResponseError) RetryAfter() time.Duration {
+	return re.retryAfter
+}
+
+//Retry calls f until it succeeds, the error is not retryable, attempts are
+//exhausted or ctx deadline is not enough for the next backoff. the last error
+//is returned with *ResponseError unwrapped
+func (rp *RetryPolicy) Retry(ctx context.Context, method string, endPoint string, f func() error) error {
+	err := f()
+	if rp == nil {
+		return unwrapResponse(err)
+	}
+
+	orderEntry := rp.isOrderEntry(method, endPoint)
+	for attempt := 1; err != nil && attempt < rp.MaxAttempts; attempt++ {
+		if ctx.Err() != nil {
+			break
+		}
+		if orderEntry && !Rejected(err) || !orderEntry && !Transient(err) {
+			break
+		}
+
+		delay, ok := rp.backoff(attempt, err)
+		if !ok {
+			break
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			break
+		}
+		if e := sleep(ctx, delay); e != nil {
+			break
+		}
+		err = f()
+	}
+	return unwrapResponse(err)
+}
+
+//Transient report whether err is likely to succeed on retry: network errors,
+//http 5xx and 429 responses, exchange rate limit and overload errors
+func Transient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if Rejected(err) || errors.Is(err, exchange.ErrOverloaded) {
+		return true
+	}
+
+	var re *ResponseError
+	if errors.As(err, &re) && re.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+
+	var ne net.Error
+	if errors.As(err, &ne) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+//Rejected report whether err indicate the request is not processed by the
+//exchange and is safe to resend even it's not idempotent: rate limited and
+//connection fail
+func Rejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, exchange.ErrRateLimited) {
+		return true
+	}
+
+	var re *ResponseError
+	if errors.As(err, &re) && re.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	var oe *net.OpError
+	return errors.As(err, &oe) && oe.Op == "dial"
+}
+
+func (rp *RetryPolicy) isOrderEntry(method string, endPoint string) bool {
+	if rp.OrderEntry != nil {
+		return rp.OrderEntry(method, endPoint)
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+func (rp *RetryPolicy) backoff(attempt int, err error) (time.Duration, bool) {
+	delay := rp.BaseDelay << uint(attempt-1)
+	if rp.BaseDelay > 0 && delay <= 0 || rp.MaxDelay > 0 && delay > rp.MaxDelay {
+		delay = rp.MaxDelay
+	}
+	if rp.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * rp.Jitter * float64(delay))
+	}
+
+	var ra retryAfter
+	if errors.As(err, &ra) {
+		if d := ra.RetryAfter(); d > delay {
+			if rp.MaxDelay > 0 && d > rp.MaxDelay {
+				return 0, false
+			}
+			delay = d
+		}
+	}
+	return delay, true
+}
+
+//ReplayBody read body into memory and return a function which create a new
+//reader of the content for each attempt. nil body is replayed as nil
+func ReplayBody(body io.Reader) (func() io.Reader, error) {
+	if body == nil {
+		return func() io.Reader { return nil }, nil
+	}
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return func() io.Reader { return bytes.NewReader(data) }, nil
+}
+
+func defaultSleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func unwrapResponse(err error) error {
+	if re, ok := err.(*ResponseError); ok {
+		return re.Err
+	}
+	return err
+}
+
+//parseRetryAfter parse Retry-After header in seconds or http date format
+func parseRetryAfter(val string) time.Duration {
+	if val == "" {
+		return 0
+	}
+	if sec, err := strconv.Atoi(val); err == nil && sec > 0 {
+		return time.Duration(sec) * time.Second
+	}
+	if t, err := http.ParseTime(val); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
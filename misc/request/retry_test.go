@@ -0,0 +1,109 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+)
+
+func TestRetry(t *testing.T) {
+	var delays []time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	defer func() {
+		sleep = defaultSleep
+	}()
+
+	rp := &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   time.Second,
+		MaxDelay:    3 * time.Second,
+	}
+	status := func(code int, header http.Header) error {
+		if header == nil {
+			header = http.Header{}
+		}
+		return NewResponseError(errors.New("bad status"), &http.Response{StatusCode: code, Header: header})
+	}
+	attempt := func(errs ...error) (func() error, *int) {
+		called := 0
+		return func() error {
+			called++
+			if called > len(errs) {
+				return nil
+			}
+			return errs[called-1]
+		}, &called
+	}
+
+	f, called := attempt(status(502, nil), &net.OpError{Op: "read", Err: errors.New("reset")}, status(503, nil))
+	if err := rp.Retry(context.Background(), http.MethodGet, "/", f); err != nil || *called != 4 {
+		t.Errorf("retry idempotent fail err=%v called=%d", err, *called)
+	}
+	if len(delays) != 3 || delays[0] != time.Second || delays[1] != 2*time.Second || delays[2] != 3*time.Second {
+		t.Errorf("bad backoff %v", delays)
+	}
+
+	delays = nil
+	inner := errors.New("inner")
+	f, called = attempt(status(503, nil), status(503, nil), status(503, nil), NewResponseError(inner, &http.Response{StatusCode: 503}))
+	if err := rp.Retry(context.Background(), http.MethodGet, "/", f); err != inner || *called != 4 {
+		t.Errorf("expect unwrapped last error got %v called=%d", err, *called)
+	}
+
+	f, called = attempt(status(503, nil))
+	if err := rp.Retry(context.Background(), http.MethodPost, "/order", f); err == nil || *called != 1 {
+		t.Errorf("order entry should not retry on 503 err=%v called=%d", err, *called)
+	}
+
+	delays = nil
+	f, called = attempt(status(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"2"}}), exchange.NewAPIError(exchange.ErrRateLimited, "-1003", "too many"))
+	if err := rp.Retry(context.Background(), http.MethodPost, "/order", f); err != nil || *called != 3 {
+		t.Errorf("order entry should retry when rejected err=%v called=%d", err, *called)
+	}
+	if len(delays) != 2 || delays[0] != 2*time.Second {
+		t.Errorf("retry after not honored %v", delays)
+	}
+
+	f, called = attempt(status(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"10"}}))
+	if err := rp.Retry(context.Background(), http.MethodGet, "/", f); err == nil || *called != 1 {
+		t.Errorf("retry after exceed max delay should give up err=%v called=%d", err, *called)
+	}
+
+	f, called = attempt(errors.New("bad request"))
+	if err := rp.Retry(context.Background(), http.MethodGet, "/", f); err == nil || *called != 1 {
+		t.Errorf("permanent error should not retry err=%v called=%d", err, *called)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	f, called = attempt(status(502, nil))
+	if err := rp.Retry(ctx, http.MethodGet, "/", f); err == nil || *called != 1 {
+		t.Errorf("backoff exceed deadline should give up err=%v called=%d", err, *called)
+	}
+
+	var nilPolicy *RetryPolicy
+	f, called = attempt(status(502, nil))
+	if err := nilPolicy.Retry(context.Background(), http.MethodGet, "/", f); err == nil || *called != 1 {
+		t.Errorf("nil policy should do one attempt err=%v called=%d", err, *called)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d := parseRetryAfter("3"); d != 3*time.Second {
+		t.Errorf("bad duration %s", d)
+	}
+	if d := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)); d <= 50*time.Second || d > time.Minute {
+		t.Errorf("bad duration %s", d)
+	}
+	if d := parseRetryAfter("bad"); d != 0 {
+		t.Errorf("bad duration %s", d)
+	}
+}
@@ -0,0 +1,295 @@
+//Package wsrecord persist websocket streams to gzip compressed, rotating
+//json lines files and replay them through exchange codecs for research and
+//bug reproduction
+package wsrecord
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/internal/rpc"
+	"github.com/pkg/errors"
+)
+
+type (
+	//Kind of a record entry
+	Kind string
+
+	//Entry is a line of record file
+	Entry struct {
+		Time time.Time `json:"time"`
+		Kind Kind      `json:"kind"`
+		//Frame raw websocket frame of KindRecv and KindSend entry
+		Frame []byte `json:"frame,omitempty"`
+		//Exchange, Chan and Data are fields of KindNotify entry
+		Exchange string          `json:"exchange,omitempty"`
+		Chan     string          `json:"chan,omitempty"`
+		Data     json.RawMessage `json:"data,omitempty"`
+	}
+
+	//Config specific where and how record files are written
+	Config struct {
+		//Dir where record files are created
+		Dir string
+		//Prefix of record file name, default "ws"
+		Prefix string
+		//MaxSize rotate the file if uncompressed bytes exceed MaxSize, default 64MB
+		MaxSize int64
+		//MaxAge rotate the file if it is opened longer than MaxAge, 0 means no limit
+		MaxAge time.Duration
+		//Now return the current time, default time.Now
+		Now func() time.Time
+	}
+
+	//Recorder write entries to record files. it is safe for concurrent use
+	Recorder struct {
+		cfg *Config
+
+		mu     sync.Mutex
+		file   *os.File
+		gz     *gzip.Writer
+		buf    *bufio.Writer
+		size   int64
+		opened time.Time
+		seq    int
+		err    error
+		closed bool
+	}
+
+	recordCodec struct {
+		rpc.Codec
+		rec *Recorder
+	}
+)
+
+const (
+	//KindRecv raw frame received from server
+	KindRecv Kind = "recv"
+	//KindSend raw frame sent to server
+	KindSend Kind = "send"
+	//KindNotify decoded *exchange.WSNotify
+	KindNotify Kind = "notify"
+
+	//FileSuffix of record files
+	FileSuffix = ".jsonl.gz"
+
+	defaultPrefix  = "ws"
+	defaultMaxSize = 64 << 20
+)
+
+var (
+	//ErrClosed write to closed recorder
+	ErrClosed = errors.New("recorder closed")
+)
+
+//NewRecorder create a recorder which write files in cfg.Dir. the file is
+//created when the first entry is written
+func NewRecorder(cfg *Config) (*Recorder, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("empty record dir")
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = defaultPrefix
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = defaultMaxSize
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, errors.WithMessagef(err, "create record dir %s fail", cfg.Dir)
+	}
+	return &Recorder{cfg: cfg}, nil
+}
+
+//WrapCodec return a codec which record raw frames before decode and after
+//encode. it is used with exchange.WSClient.WrapCodec
+func (r *Recorder) WrapCodec(codec rpc.Codec) rpc.Codec {
+	return &recordCodec{
+		Codec: codec,
+		rec:   r,
+	}
+}
+
+//Record write the decoded notify. Data is encoded with json.Marshal
+func (r *Recorder) Record(notify *exchange.WSNotify) error {
+	data, err := json.Marshal(notify.Data)
+	if err != nil {
+		return errors.WithMessagef(err, "marshal notify %s fail", notify.Chan)
+	}
+	return r.Write(&Entry{
+		Kind:     KindNotify,
+		Exchange: notify.Exchange,
+		Chan:     notify.Chan,
+		Data:     data,
+	})
+}
+
+//Pipe record each *exchange.WSNotify read from in and forward all messages to
+//out. it return when ctx is done or in is closed
+func (r *Recorder) Pipe(ctx context.Context, in <-chan interface{}, out chan<- interface{}) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case msg, ok := <-in:
+			if !ok {
+				return nil
+			}
+			if notify, ok := msg.(*exchange.WSNotify); ok {
+				if err := r.Record(notify); err != nil {
+					return err
+				}
+			}
+
+			select {
+			case out <- msg:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+//Write append e to the current record file. e.Time is set if it is zero
+func (r *Recorder) Write(e *Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return ErrClosed
+	}
+	now := r.cfg.Now()
+	if e.Time.IsZero() {
+		e.Time = now
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return errors.WithMessage(err, "marshal entry fail")
+	}
+	line = append(line, '\n')
+
+	if r.file == nil || r.size >= r.cfg.MaxSize || r.cfg.MaxAge > 0 && now.Sub(r.opened) >= r.cfg.MaxAge {
+		if err := r.rotate(now); err != nil {
+			return r.fail(err)
+		}
+	}
+	if _, err := r.buf.Write(line); err != nil {
+		return r.fail(errors.WithMessage(err, "write entry fail"))
+	}
+	r.size += int64(len(line))
+	return nil
+}
+
+//Flush write buffered entries to the current file. the file is readable
+//with gzip after Flush but a complete gzip stream is only written by Close
+//or rotation
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	if err := r.buf.Flush(); err != nil {
+		return r.fail(err)
+	}
+	if err := r.gz.Flush(); err != nil {
+		return r.fail(err)
+	}
+	return nil
+}
+
+//Error return the first write error. entries recorded by WrapCodec codec
+//do not report error to the stream, check it with Error
+func (r *Recorder) Error() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+//Close flush and close current record file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.closeFile()
+}
+
+func (r *Recorder) rotate(now time.Time) error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+
+	for {
+		r.seq++
+		name := fmt.Sprintf("%s-%s-%06d%s", r.cfg.Prefix, now.UTC().Format("20060102T150405"), r.seq, FileSuffix)
+		file, err := os.OpenFile(filepath.Join(r.cfg.Dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return errors.WithMessagef(err, "create record file %s fail", name)
+		}
+
+		r.file = file
+		r.gz = gzip.NewWriter(file)
+		r.buf = bufio.NewWriter(r.gz)
+		r.size = 0
+		r.opened = now
+		return nil
+	}
+}
+
+func (r *Recorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	file := r.file
+	r.file = nil
+
+	if err := r.buf.Flush(); err != nil {
+		file.Close()
+		return errors.WithMessage(err, "flush record file fail")
+	}
+	if err := r.gz.Close(); err != nil {
+		file.Close()
+		return errors.WithMessage(err, "close gzip writer fail")
+	}
+	return file.Close()
+}
+
+func (r *Recorder) fail(err error) error {
+	if r.err == nil {
+		r.err = err
+	}
+	return err
+}
+
+func (rc *recordCodec) Decode(raw []byte) (rpc.Response, error) {
+	rc.rec.Write(&Entry{Kind: KindRecv, Frame: raw})
+	return rc.Codec.Decode(raw)
+}
+
+func (rc *recordCodec) Encode(req rpc.Request) ([]byte, error) {
+	msg, err := rc.Codec.Encode(req)
+	if err == nil {
+		rc.rec.Write(&Entry{Kind: KindSend, Frame: msg})
+	}
+	return msg, err
+}
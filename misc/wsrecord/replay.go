@@ -0,0 +1,179 @@
+package wsrecord
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/internal/rpc"
+	"github.com/pkg/errors"
+)
+
+type (
+	//Replayer read record files in order and replay entries with the
+	//recorded interval divided by Speed
+	Replayer struct {
+		files []string
+		//Speed replay speed, 1 means real time and 10 means 10x faster.
+		//entries are replayed without waiting if Speed <= 0
+		Speed float64
+		//OnDecodeError is called if codec fail to decode a frame, the frame
+		//is skipped as the websocket stream do if it is nil
+		OnDecodeError func(e *Entry, err error)
+	}
+)
+
+//Files return record files of prefix in dir sorted by name which is the
+//record order
+func Files(dir string, prefix string) ([]string, error) {
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, prefix+"-*"+FileSuffix))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "list record files in %s fail", dir)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+//NewReplayer create a real time replayer of files
+func NewReplayer(files ...string) *Replayer {
+	return &Replayer{
+		files: files,
+		Speed: 1,
+	}
+}
+
+//Replay decode KindRecv frames with codec and pass notify to handler. the
+//exchange websocket clients implement rpc.Handler, so the replayed notify is
+//pushed to the client data channel exactly like a live stream. codec should
+//be a new instance of the recorded client codec
+func (rp *Replayer) Replay(ctx context.Context, codec rpc.Codec, handler rpc.Handler) error {
+	return rp.Walk(ctx, func(e *Entry) error {
+		if e.Kind != KindRecv {
+			return nil
+		}
+
+		resp, err := codec.Decode(e.Frame)
+		if err != nil {
+			if rp.OnDecodeError != nil {
+				rp.OnDecodeError(e, err)
+			}
+			return nil
+		}
+		if notify, ok := resp.(*rpc.Notify); ok {
+			handler.Handle(ctx, notify)
+		}
+		return nil
+	})
+}
+
+//ReplayNotify push KindNotify entries to data as *exchange.WSNotify whose
+//Data is the recorded json.RawMessage
+func (rp *Replayer) ReplayNotify(ctx context.Context, data chan<- interface{}) error {
+	return rp.Walk(ctx, func(e *Entry) error {
+		if e.Kind != KindNotify {
+			return nil
+		}
+
+		notify := &exchange.WSNotify{
+			Exchange: e.Exchange,
+			Chan:     e.Chan,
+			Data:     e.Data,
+		}
+		select {
+		case data <- notify:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+//Walk call cb for every entry at the replay time. it stop if cb return error
+func (rp *Replayer) Walk(ctx context.Context, cb func(e *Entry) error) error {
+	var first time.Time
+	var start time.Time
+	for _, file := range rp.files {
+		err := readFile(file, func(e *Entry) error {
+			if first.IsZero() {
+				first = e.Time
+				start = time.Now()
+			}
+			if err := rp.wait(ctx, start, e.Time.Sub(first)); err != nil {
+				return err
+			}
+			return cb(e)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rp *Replayer) wait(ctx context.Context, start time.Time, offset time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if rp.Speed <= 0 || offset <= 0 {
+		return nil
+	}
+
+	d := time.Until(start.Add(time.Duration(float64(offset) / rp.Speed)))
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func readFile(name string, cb func(e *Entry) error) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return errors.WithMessagef(err, "open record file %s fail", name)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return errors.WithMessagef(err, "read record file %s fail", name)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	decoder := json.NewDecoder(reader)
+	for {
+		var e Entry
+		if err := decoder.Decode(&e); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			//the tail of a file which is not closed is truncated
+			if err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return errors.WithMessagef(err, "decode record file %s fail", name)
+		}
+		if err := cb(&e); err != nil {
+			return err
+		}
+	}
+}
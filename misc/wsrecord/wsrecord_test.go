@@ -0,0 +1,169 @@
+package wsrecord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NadiaSama/ccexgo/exchange"
+	"github.com/NadiaSama/ccexgo/exchange/binance"
+	"github.com/NadiaSama/ccexgo/exchange/binance/spot"
+	"github.com/NadiaSama/ccexgo/misc/wstest"
+)
+
+type (
+	testChannel string
+)
+
+func (tc testChannel) String() string {
+	return string(tc)
+}
+
+func tickerFrame(id int) map[string]interface{} {
+	return map[string]interface{}{
+		"u": id, "s": "BTCUSDT", "b": fmt.Sprintf("%d.1", id), "B": "1", "a": fmt.Sprintf("%d.2", id), "A": "2",
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wsrecord")
+	if err != nil {
+		t.Fatalf("create temp dir fail %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	srv := wstest.NewServer(func(c *wstest.Conn) {
+		c.Serve(context.Background(),
+			wstest.On(wstest.Field("method", binance.MethodSubscibe), func(m *wstest.Message) []interface{} {
+				ret := []interface{}{map[string]interface{}{"result": nil, "id": m.Get("id")}}
+				for i := 1; i <= 3; i++ {
+					ret = append(ret, tickerFrame(i))
+				}
+				return append(ret, "bad frame")
+			}),
+		)
+	})
+	defer srv.Close()
+
+	//rotate after every entry
+	rec, err := NewRecorder(&Config{Dir: dir, MaxSize: 1})
+	if err != nil {
+		t.Fatalf("create recorder fail %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	raw := make(chan interface{}, 8)
+	data := make(chan interface{}, 8)
+	ws := binance.NewNotifyClient(srv.URL, spot.NewCodeC(), raw, nil)
+	ws.WrapCodec(rec.WrapCodec)
+	if err := ws.Run(ctx); err != nil {
+		t.Fatalf("run fail %s", err.Error())
+	}
+	defer ws.Close()
+	go rec.Pipe(ctx, raw, data)
+
+	if err := ws.Subscribe(ctx, testChannel("btcusdt@bookTicker")); err != nil {
+		t.Fatalf("subscribe fail %s", err.Error())
+	}
+	for i := 1; i <= 3; i++ {
+		select {
+		case <-data:
+		case <-ctx.Done():
+			t.Fatalf("wait notify timeout")
+		}
+	}
+	//wait the bad frame is recorded
+	time.Sleep(time.Millisecond * 50)
+	ws.Close()
+	if err := rec.Close(); err != nil {
+		t.Fatalf("close recorder fail %s", err.Error())
+	}
+	if err := rec.Error(); err != nil {
+		t.Fatalf("recorder error %s", err.Error())
+	}
+
+	files, err := Files(dir, "")
+	//send, result, 3 tickers, bad frame and 3 notifies
+	if err != nil || len(files) != 9 {
+		t.Fatalf("bad record files %v %v", err, files)
+	}
+
+	replayed := make(chan interface{}, 8)
+	rp := NewReplayer(files...)
+	rp.Speed = 0
+	decodeErrors := 0
+	rp.OnDecodeError = func(e *Entry, err error) {
+		decodeErrors++
+	}
+	if err := rp.Replay(context.Background(), spot.NewCodeC(), binance.NewNotifyClient("", nil, replayed, nil)); err != nil {
+		t.Fatalf("replay fail %s", err.Error())
+	}
+	if len(replayed) != 3 || decodeErrors != 1 {
+		t.Fatalf("bad replay notify=%d errors=%d", len(replayed), decodeErrors)
+	}
+	for i := 1; i <= 3; i++ {
+		notify := (<-replayed).(*exchange.WSNotify)
+		bt := notify.Data.(*spot.BookTickerNotify)
+		if notify.Chan != "bookTicker" || bt.UpdateID != int64(i) || bt.Bid1Price != fmt.Sprintf("%d.1", i) {
+			t.Errorf("bad replay notify %+v", bt)
+		}
+	}
+
+	if err := rp.ReplayNotify(context.Background(), replayed); err != nil {
+		t.Fatalf("replay notify fail %s", err.Error())
+	}
+	if len(replayed) != 3 {
+		t.Fatalf("bad replay notify count %d", len(replayed))
+	}
+	notify := (<-replayed).(*exchange.WSNotify)
+	if notify.Exchange != binance.Exchange || string(notify.Data.(json.RawMessage)) != `{"u":1,"s":"BTCUSDT","b":"1.1","B":"1","a":"1.2","A":"2"}` {
+		t.Errorf("bad recorded notify %+v", notify)
+	}
+}
+
+func TestReplaySpeed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wsrecord")
+	if err != nil {
+		t.Fatalf("create temp dir fail %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Unix(1600000000, 0)
+	rec, err := NewRecorder(&Config{Dir: dir, Prefix: "speed", MaxAge: time.Second, Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("create recorder fail %s", err.Error())
+	}
+	for i := 0; i < 3; i++ {
+		if err := rec.Write(&Entry{Kind: KindRecv, Frame: []byte("{}")}); err != nil {
+			t.Fatalf("write fail %s", err.Error())
+		}
+		now = now.Add(time.Millisecond * 500)
+	}
+	rec.Close()
+	if err := rec.Write(&Entry{Kind: KindRecv}); err != ErrClosed {
+		t.Errorf("expect closed error got %v", err)
+	}
+
+	files, _ := Files(dir, "speed")
+	if len(files) != 2 {
+		t.Fatalf("bad rotate files %v", files)
+	}
+
+	//1s record replayed with 10x speed
+	rp := NewReplayer(files...)
+	rp.Speed = 10
+	count := 0
+	start := time.Now()
+	rp.Walk(context.Background(), func(e *Entry) error {
+		count++
+		return nil
+	})
+	if elapsed := time.Since(start); count != 3 || elapsed < time.Millisecond*100 || elapsed > time.Millisecond*500 {
+		t.Errorf("bad replay count=%d elapsed=%s", count, elapsed)
+	}
+}
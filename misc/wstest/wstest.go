@@ -0,0 +1,368 @@
+//Package wstest provide an in-process websocket server which script exchange
+//frames so websocket clients can be tested without network
+package wstest
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+type (
+	//Server websocket server which run Handler for each accepted connection
+	Server struct {
+		//URL websocket address of the server in form ws://127.0.0.1:port
+		URL      string
+		srv      *httptest.Server
+		handler  Handler
+		compress Compressor
+		accepted chan *Conn
+		mu       sync.Mutex
+		conns    []*Conn
+	}
+
+	//Handler script a connection. the connection is closed after Handler return
+	Handler func(c *Conn)
+
+	//Option config the server
+	Option func(*Server)
+
+	//Compressor compress frames sent by the server
+	Compressor func(data []byte) ([]byte, error)
+
+	//Conn a server side websocket connection
+	Conn struct {
+		ws       *websocket.Conn
+		header   http.Header
+		compress Compressor
+		recv     chan *Message
+		done     chan struct{}
+		closeMu  sync.Mutex
+		closed   bool
+		writeMu  sync.Mutex
+	}
+
+	//Message a frame received from client
+	Message struct {
+		Data   []byte
+		fields map[string]interface{}
+	}
+
+	//Match report whether a rule handle the message
+	Match func(m *Message) bool
+
+	//Reply build frames which are sent in response to the message. frame of
+	//[]byte or string type is sent as is, others are encoded as json
+	Reply func(m *Message) []interface{}
+
+	//Rule reply frames for the matched message
+	Rule struct {
+		match Match
+		reply Reply
+	}
+)
+
+var (
+	//ErrClosed the connection is closed
+	ErrClosed = errors.New("connection closed")
+
+	upgrader = websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+)
+
+//NewServer start a websocket server. if h is nil connections are kept open
+//until closed and can be scripted via Accept
+func NewServer(h Handler, opts ...Option) *Server {
+	ret := &Server{
+		handler:  h,
+		accepted: make(chan *Conn, 16),
+	}
+	for _, opt := range opts {
+		opt(ret)
+	}
+
+	ret.srv = httptest.NewServer(http.HandlerFunc(ret.serveHTTP))
+	ret.URL = "ws" + strings.TrimPrefix(ret.srv.URL, "http")
+	return ret
+}
+
+//WithCompression compress every frame sent by the server with c and send it
+//as binary message. huobi use Gzip and okex v3 use Deflate
+func WithCompression(c Compressor) Option {
+	return func(s *Server) {
+		s.compress = c
+	}
+}
+
+//Gzip compress data in gzip format
+func Gzip(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+//Deflate compress data in raw deflate format
+func Deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+//Accept return the next accepted connection
+func (s *Server) Accept(ctx context.Context) (*Conn, error) {
+	select {
+	case c := <-s.accepted:
+		return c, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+//Drop close all open connections without close handshake which simulate a
+//network failure
+func (s *Server) Drop() {
+	s.mu.Lock()
+	conns := s.conns
+	s.conns = nil
+	s.mu.Unlock()
+
+	for _, c := range conns {
+		c.Close()
+	}
+}
+
+//Close drop all connections and shutdown the server
+func (s *Server) Close() {
+	s.Drop()
+	s.srv.Close()
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	c := &Conn{
+		ws:       ws,
+		header:   r.Header,
+		compress: s.compress,
+		recv:     make(chan *Message, 1024),
+		done:     make(chan struct{}),
+	}
+	go c.readLoop()
+
+	s.mu.Lock()
+	s.conns = append(s.conns, c)
+	s.mu.Unlock()
+
+	if s.handler == nil {
+		select {
+		case s.accepted <- c:
+		default:
+		}
+		return
+	}
+
+	go func() {
+		defer c.Close()
+		s.handler(c)
+	}()
+}
+
+//Header return headers of the handshake request sent by client
+func (c *Conn) Header() http.Header {
+	return c.header
+}
+
+//Write send frame to client. []byte and string frames are sent as is, others
+//are encoded as json
+func (c *Conn) Write(frame interface{}) error {
+	var (
+		data []byte
+		err  error
+	)
+	switch f := frame.(type) {
+	case []byte:
+		data = f
+	case string:
+		data = []byte(f)
+	default:
+		data, err = json.Marshal(f)
+		if err != nil {
+			return errors.WithMessage(err, "encode frame fail")
+		}
+	}
+
+	typ := websocket.TextMessage
+	if c.compress != nil {
+		if data, err = c.compress(data); err != nil {
+			return errors.WithMessage(err, "compress frame fail")
+		}
+		typ = websocket.BinaryMessage
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.ws.WriteMessage(typ, data)
+}
+
+//Read return the next message from client
+func (c *Conn) Read(ctx context.Context) (*Message, error) {
+	select {
+	case m, ok := <-c.recv:
+		if !ok {
+			return nil, ErrClosed
+		}
+		return m, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+//Serve read messages and reply with the first matched rule until the
+//connection is closed or ctx is done. unmatched messages are ignored
+func (c *Conn) Serve(ctx context.Context, rules ...Rule) error {
+	for {
+		m, err := c.Read(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range rules {
+			if !r.match(m) {
+				continue
+			}
+			for _, f := range r.reply(m) {
+				if err := c.Write(f); err != nil {
+					return err
+				}
+			}
+			break
+		}
+	}
+}
+
+//Done is closed after the connection is closed
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+//Close close the underlying connection
+func (c *Conn) Close() error {
+	c.closeMu.Lock()
+	defer c.closeMu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.ws.Close()
+}
+
+func (c *Conn) readLoop() {
+	defer close(c.done)
+	defer close(c.recv)
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			c.Close()
+			return
+		}
+		c.recv <- newMessage(data)
+	}
+}
+
+func newMessage(data []byte) *Message {
+	ret := &Message{Data: data}
+	//keep numbers as json.Number so large ids are not formatted in exponent
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var fields map[string]interface{}
+	if err := dec.Decode(&fields); err == nil {
+		ret.fields = fields
+	}
+	return ret
+}
+
+//Get return the top level field of json message, nil if absent. numbers are
+//returned as json.Number
+func (m *Message) Get(key string) interface{} {
+	return m.fields[key]
+}
+
+//String return the top level field formatted as string, empty if absent
+func (m *Message) String(key string) string {
+	val, ok := m.fields[key]
+	if !ok || val == nil {
+		return ""
+	}
+	return fmt.Sprint(val)
+}
+
+//Decode unmarshal the message into v
+func (m *Message) Decode(v interface{}) error {
+	return json.Unmarshal(m.Data, v)
+}
+
+//On create a rule which reply r for messages matched by m
+func On(m Match, r Reply) Rule {
+	return Rule{match: m, reply: r}
+}
+
+//Text match message which equal to text
+func Text(text string) Match {
+	return func(m *Message) bool {
+		return string(m.Data) == text
+	}
+}
+
+//Field match json message whose top level field key formatted as string
+//equal to val
+func Field(key string, val interface{}) Match {
+	expect := fmt.Sprint(val)
+	return func(m *Message) bool {
+		v, ok := m.fields[key]
+		return ok && fmt.Sprint(v) == expect
+	}
+}
+
+//Has match json message which contains top level field key
+func Has(key string) Match {
+	return func(m *Message) bool {
+		_, ok := m.fields[key]
+		return ok
+	}
+}
+
+//Frames reply the fixed frames
+func Frames(frames ...interface{}) Reply {
+	return func(*Message) []interface{} {
+		return frames
+	}
+}
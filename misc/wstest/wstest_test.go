@@ -0,0 +1,102 @@
+package wstest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestServe(t *testing.T) {
+	srv := NewServer(func(c *Conn) {
+		c.Serve(context.Background(),
+			On(Text("ping"), Frames("pong")),
+			On(Field("id", 1), func(m *Message) []interface{} {
+				return []interface{}{map[string]interface{}{"id": m.Get("id"), "result": m.String("method")}}
+			}),
+		)
+	}, WithCompression(Gzip))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(srv.URL, nil)
+	if err != nil {
+		t.Fatalf("dial fail %s", err.Error())
+	}
+	defer conn.Close()
+
+	read := func() string {
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		typ, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read fail %s", err.Error())
+		}
+		if typ != websocket.BinaryMessage {
+			t.Fatalf("bad message type %d", typ)
+		}
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("decompress fail %s", err.Error())
+		}
+		msg, _ := ioutil.ReadAll(r)
+		return string(msg)
+	}
+
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"id":2,"method":"skip"}`))
+	conn.WriteMessage(websocket.TextMessage, []byte("ping"))
+	if msg := read(); msg != "pong" {
+		t.Errorf("bad pong %s", msg)
+	}
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"id":1,"method":"sub"}`))
+	if msg := read(); msg != `{"id":1,"result":"sub"}` {
+		t.Errorf("bad result %s", msg)
+	}
+}
+
+func TestAcceptDrop(t *testing.T) {
+	srv := NewServer(nil)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(srv.URL, nil)
+	if err != nil {
+		t.Fatalf("dial fail %s", err.Error())
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c, err := srv.Accept(ctx)
+	if err != nil {
+		t.Fatalf("accept fail %s", err.Error())
+	}
+
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"op":"login","args":["key"]}`))
+	m, err := c.Read(ctx)
+	if err != nil {
+		t.Fatalf("read fail %s", err.Error())
+	}
+	var req struct {
+		Op   string   `json:"op"`
+		Args []string `json:"args"`
+	}
+	if err := m.Decode(&req); err != nil || req.Op != "login" || len(req.Args) != 1 || m.String("missing") != "" {
+		t.Errorf("bad message %s", string(m.Data))
+	}
+
+	srv.Drop()
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, _, err := conn.ReadMessage(); err == nil {
+		t.Errorf("expect read fail after drop")
+	}
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Errorf("conn not done after drop")
+	}
+	if _, err := c.Read(ctx); err != ErrClosed {
+		t.Errorf("expect closed got %v", err)
+	}
+}